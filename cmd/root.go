@@ -26,6 +26,7 @@ import (
 	"github.com/asherda/lightwalletd/common"
 	"github.com/asherda/lightwalletd/common/logging"
 	"github.com/asherda/lightwalletd/frontend"
+	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
 )
 
@@ -59,6 +60,18 @@ var rootCmd = &cobra.Command{
 			PingEnable:          viper.GetBool("ping-very-insecure"),
 			Darkside:            viper.GetBool("darkside-very-insecure"),
 			DarksideTimeout:     viper.GetUint64("darkside-timeout"),
+			AddressIndex:        viper.GetBool("address-index"),
+			BlockFilters:        viper.GetBool("block-filters"),
+			TransparentData:     viper.GetBool("transparent-data"),
+			TxLimits: parser.Limits{
+				MaxTxSize:          viper.GetInt("max-tx-size"),
+				MaxInputs:          viper.GetInt("max-tx-inputs"),
+				MaxOutputs:         viper.GetInt("max-tx-outputs"),
+				MaxSpends:          viper.GetInt("max-tx-spends"),
+				MaxShieldedOutputs: viper.GetInt("max-tx-shielded-outputs"),
+				MaxJoinSplits:      viper.GetInt("max-tx-joinsplits"),
+				MaxScriptSize:      viper.GetInt("max-script-size"),
+			},
 		}
 
 		common.Log.Debugf("Options: %#v\n", opts)
@@ -135,11 +148,13 @@ func startServer(opts *common.Options) error {
 		server = grpc.NewServer(
 			grpc.StreamInterceptor(
 				grpc_middleware.ChainStreamServer(
-					grpc_prometheus.StreamServerInterceptor),
+					grpc_prometheus.StreamServerInterceptor,
+					frontend.StatusStreamInterceptor),
 			),
 			grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 				logging.LogInterceptor,
-				grpc_prometheus.UnaryServerInterceptor),
+				grpc_prometheus.UnaryServerInterceptor,
+				frontend.StatusUnaryInterceptor),
 			))
 	} else {
 		var transportCreds credentials.TransportCredentials
@@ -162,11 +177,13 @@ func startServer(opts *common.Options) error {
 		server = grpc.NewServer(
 			grpc.Creds(transportCreds),
 			grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-				grpc_prometheus.StreamServerInterceptor),
+				grpc_prometheus.StreamServerInterceptor,
+				frontend.StatusStreamInterceptor),
 			),
 			grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 				logging.LogInterceptor,
-				grpc_prometheus.UnaryServerInterceptor),
+				grpc_prometheus.UnaryServerInterceptor,
+				frontend.StatusUnaryInterceptor),
 			))
 	}
 	grpc_prometheus.EnableHandlingTimeHistogram()
@@ -192,8 +209,12 @@ func startServer(opts *common.Options) error {
 	} else {
 		if opts.RPCUser != "" && opts.RPCPassword != "" && opts.RPCHost != "" && opts.RPCPort != "" {
 			rpcClient, err = frontend.NewZRPCFromFlags(opts)
+			common.StreamRequest = frontend.NewStreamRequestFromFlags(opts)
 		} else {
 			rpcClient, err = frontend.NewZRPCFromConf(opts.VerusConfPath)
+			if err == nil {
+				common.StreamRequest, err = frontend.NewStreamRequestFromConf(opts.VerusConfPath)
+			}
 		}
 		if err != nil {
 			common.Log.WithFields(logrus.Fields{
@@ -239,13 +260,25 @@ func startServer(opts *common.Options) error {
 	db, err := leveldb.OpenFile(dbPath, nil)
 	defer db.Close()
 
+	parser.SetLimits(opts.TxLimits)
 	cache := common.NewBlockCache(db, chainID, saplingHeight, opts.Redownload)
+	if opts.AddressIndex {
+		cache.EnableAddressIndex()
+	}
+	if opts.BlockFilters {
+		cache.EnableBlockFilters()
+	}
+	if opts.TransparentData {
+		cache.EnableTransparentData()
+	}
 	if !opts.Darkside {
 		go common.BlockIngestor(cache, 0 /*loop forever*/)
+		go common.FinalityPoller(cache, 0 /*loop forever*/)
 	} else {
 		// Darkside wants to control starting the block ingestor.
 		common.DarksideInit(cache, int(opts.DarksideTimeout))
 	}
+	go common.MempoolTracker(0 /*loop forever*/)
 
 	// Compact transaction service initialization
 	{
@@ -329,6 +362,16 @@ func init() {
 	rootCmd.Flags().Bool("ping-very-insecure", false, "allow Ping GRPC for testing")
 	rootCmd.Flags().Bool("darkside-very-insecure", false, "run with GRPC-controllable mock zcashd for integration testing (shuts down after 30 minutes)")
 	rootCmd.Flags().Int("darkside-timeout", 30, "override 30 minute default darkside timeout")
+	rootCmd.Flags().Bool("address-index", false, "build our own transparent address index, so zcashd needn't run with -addressindex and -spentindex (standard outputs only; VerusIDs aren't supported)")
+	rootCmd.Flags().Bool("block-filters", false, "build and serve transparent block filters (GetBlockFilters); the first time, this fetches every cached block again")
+	rootCmd.Flags().Bool("transparent-data", false, "store the transparent parts of blocks as they're cached, so GetBlockRange needn't fetch blocks from verusd again to return them")
+	rootCmd.Flags().Int("max-tx-size", 0, "largest transaction to parse, in bytes (0 for the consensus limit); the parser limits also apply to blocks, so they must allow every transaction in the chain")
+	rootCmd.Flags().Int("max-tx-inputs", 0, "most transparent inputs a transaction may have (0 for no limit beyond the size)")
+	rootCmd.Flags().Int("max-tx-outputs", 0, "most transparent outputs a transaction may have (0 for no limit beyond the size)")
+	rootCmd.Flags().Int("max-tx-spends", 0, "most Sapling spends a transaction may have (0 for no limit beyond the size)")
+	rootCmd.Flags().Int("max-tx-shielded-outputs", 0, "most Sapling outputs a transaction may have (0 for no limit beyond the size)")
+	rootCmd.Flags().Int("max-tx-joinsplits", 0, "most JoinSplits a transaction may have (0 for no limit beyond the size)")
+	rootCmd.Flags().Int("max-script-size", 0, "largest transparent script, in bytes (0 for no limit beyond the size)")
 
 	viper.BindPFlag("grpc-bind-addr", rootCmd.Flags().Lookup("grpc-bind-addr"))
 	viper.SetDefault("grpc-bind-addr", "127.0.0.1:9077")
@@ -364,6 +407,26 @@ func init() {
 	viper.SetDefault("darkside-very-insecure", false)
 	viper.BindPFlag("darkside-timeout", rootCmd.Flags().Lookup("darkside-timeout"))
 	viper.SetDefault("darkside-timeout", 30)
+	viper.BindPFlag("address-index", rootCmd.Flags().Lookup("address-index"))
+	viper.SetDefault("address-index", false)
+	viper.BindPFlag("block-filters", rootCmd.Flags().Lookup("block-filters"))
+	viper.SetDefault("block-filters", false)
+	viper.BindPFlag("transparent-data", rootCmd.Flags().Lookup("transparent-data"))
+	viper.SetDefault("transparent-data", false)
+	viper.BindPFlag("max-tx-size", rootCmd.Flags().Lookup("max-tx-size"))
+	viper.SetDefault("max-tx-size", 0)
+	viper.BindPFlag("max-tx-inputs", rootCmd.Flags().Lookup("max-tx-inputs"))
+	viper.SetDefault("max-tx-inputs", 0)
+	viper.BindPFlag("max-tx-outputs", rootCmd.Flags().Lookup("max-tx-outputs"))
+	viper.SetDefault("max-tx-outputs", 0)
+	viper.BindPFlag("max-tx-spends", rootCmd.Flags().Lookup("max-tx-spends"))
+	viper.SetDefault("max-tx-spends", 0)
+	viper.BindPFlag("max-tx-shielded-outputs", rootCmd.Flags().Lookup("max-tx-shielded-outputs"))
+	viper.SetDefault("max-tx-shielded-outputs", 0)
+	viper.BindPFlag("max-tx-joinsplits", rootCmd.Flags().Lookup("max-tx-joinsplits"))
+	viper.SetDefault("max-tx-joinsplits", 0)
+	viper.BindPFlag("max-script-size", rootCmd.Flags().Lookup("max-script-size"))
+	viper.SetDefault("max-script-size", 0)
 
 	logger.SetFormatter(&logrus.TextFormatter{
 		//DisableColors:          true,
@@ -0,0 +1,521 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// The address index, if enabled, records the transparent outputs paying
+// each address and the transactions involving it, so that the address
+// endpoints don't need zcashd's -addressindex and -spentindex. Only standard
+// outputs (see parser.ScriptAddress) are indexed; smart transaction outputs,
+// such as those paying identities and reserve currencies, are not, so the
+// address endpoints don't accept VerusIDs (i-addresses and names) while it's
+// enabled, and an address's smart transaction outputs are left out.
+const (
+	addrIndexPrefix   = "A" // key is "A" + chain ID, value is the height of the first block not yet indexed
+	addrOutputPrefix  = "O" // key is "O" + outpoint, value is the unspent output; see also U
+	addrUnspentPrefix = "U" // key is "U" + address + height + outpoint, value is the unspent output
+	addrTxPrefix      = "X" // key is "X" + address + height + tx index, value is the txid
+	addrUndoPrefix    = "D" // key is "D" + height, value is what the block changed, to undo it
+)
+
+// Undo data is kept for this many blocks; a deeper reorg rebuilds the index.
+const addrUndoBlocks = 100
+
+// The number of blocks the ingestor indexes at a time while the index
+// catches up with the cache.
+const addrIndexBatch = 100
+
+// addressIndex is the state of the address index.
+type addressIndex struct {
+	next  int // height of the first block not yet indexed
+	mutex sync.Mutex
+}
+
+// indexedOutput is an unspent output, as stored in the index.
+type indexedOutput struct {
+	height  int
+	txIndex int // the transaction's position in its block
+	txid    []byte
+	vout    uint32
+	value   uint64
+	address string
+	script  []byte
+}
+
+func heightKey(height int) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, uint32(height))
+	return key
+}
+
+// addressKey makes an address a key prefix that no other address extends.
+func addressKey(prefix, address string) []byte {
+	return append([]byte(prefix+string([]byte{byte(len(address))})), address...)
+}
+
+func outpointKey(txid []byte, vout uint32) []byte {
+	key := make([]byte, 0, 36)
+	key = append(key, txid...)
+	return binary.BigEndian.AppendUint32(key, vout)
+}
+
+func (out *indexedOutput) outputKey() []byte {
+	return append([]byte(addrOutputPrefix), outpointKey(out.txid, out.vout)...)
+}
+
+func (out *indexedOutput) unspentKey() []byte {
+	key := append(addressKey(addrUnspentPrefix, out.address), heightKey(out.height)...)
+	return append(key, outpointKey(out.txid, out.vout)...)
+}
+
+func addrTxKey(address string, height, txIndex int) []byte {
+	key := append(addressKey(addrTxPrefix, address), heightKey(height)...)
+	return append(key, heightKey(txIndex)...)
+}
+
+// marshal encodes the output as: height, tx index, txid, vout, value,
+// address length, address, script.
+func (out *indexedOutput) marshal() []byte {
+	data := make([]byte, 0, 4+4+32+4+8+1+len(out.address)+len(out.script))
+	data = binary.BigEndian.AppendUint32(data, uint32(out.height))
+	data = binary.BigEndian.AppendUint32(data, uint32(out.txIndex))
+	data = append(data, out.txid...)
+	data = binary.BigEndian.AppendUint32(data, out.vout)
+	data = binary.BigEndian.AppendUint64(data, out.value)
+	data = append(data, byte(len(out.address)))
+	data = append(data, out.address...)
+	return append(data, out.script...)
+}
+
+func unmarshalIndexedOutput(data []byte) (*indexedOutput, error) {
+	if len(data) < 53 || len(data) < 53+int(data[52]) {
+		return nil, errors.New("address index output record is truncated")
+	}
+	addressEnd := 53 + int(data[52])
+	return &indexedOutput{
+		height:  int(binary.BigEndian.Uint32(data[0:])),
+		txIndex: int(binary.BigEndian.Uint32(data[4:])),
+		txid:    data[8:40],
+		vout:    binary.BigEndian.Uint32(data[40:]),
+		value:   binary.BigEndian.Uint64(data[44:]),
+		address: string(data[53:addressEnd]),
+		script:  data[addressEnd:],
+	}, nil
+}
+
+// A block's undo data is a sequence of changes: a kind (created or spent),
+// the index of the transaction that made it, and the output's record.
+const (
+	undoCreated = 0
+	undoSpent   = 1
+)
+
+type addrIndexChange struct {
+	kind    byte
+	txIndex int
+	output  *indexedOutput
+}
+
+func appendUndo(undo []byte, kind byte, txIndex int, record []byte) []byte {
+	undo = append(undo, kind)
+	undo = binary.BigEndian.AppendUint32(undo, uint32(txIndex))
+	undo = binary.BigEndian.AppendUint32(undo, uint32(len(record)))
+	return append(undo, record...)
+}
+
+func parseUndo(undo []byte) ([]addrIndexChange, error) {
+	changes := make([]addrIndexChange, 0)
+	for len(undo) > 0 {
+		if len(undo) < 9 || len(undo) < 9+int(binary.BigEndian.Uint32(undo[5:])) {
+			return nil, errors.New("address index undo record is truncated")
+		}
+		end := 9 + int(binary.BigEndian.Uint32(undo[5:]))
+		output, err := unmarshalIndexedOutput(undo[9:end])
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, addrIndexChange{
+			kind:    undo[0],
+			txIndex: int(binary.BigEndian.Uint32(undo[1:])),
+			output:  output,
+		})
+		undo = undo[end:]
+	}
+	return changes, nil
+}
+
+// EnableAddressIndex makes the cache maintain the address index as blocks
+// are added, and serve the address endpoints from it. It's called once, at
+// startup; blocks already in the cache are indexed by the block ingestor.
+func (c *BlockCache) EnableAddressIndex() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.addressIndex = &addressIndex{}
+	data, err := c.ldb.Get([]byte(addrIndexPrefix+c.verusID), nil)
+	if err == nil && len(data) == 8 {
+		c.addressIndex.next = int(binary.LittleEndian.Uint64(data))
+	}
+	// The cache may have been reset or redownloaded.
+	c.revertAddressIndex(c.nextBlock)
+	Log.Info("Address index covers ", c.addressIndex.next, " blocks")
+}
+
+// AddressIndexEnabled returns true if the address endpoints are served from
+// the address index, rather than zcashd.
+func (c *BlockCache) AddressIndexEnabled() bool {
+	return c.addressIndex != nil
+}
+
+// addressIndexNext returns the height of the first block the address index
+// is missing, or -1 if it's not enabled.
+func (c *BlockCache) addressIndexNext() int {
+	if c.addressIndex == nil {
+		return -1
+	}
+	c.addressIndex.mutex.Lock()
+	defer c.addressIndex.mutex.Unlock()
+	return c.addressIndex.next
+}
+
+// checkAddressIndex returns an error unless the address index has caught up
+// with the cache.
+func (c *BlockCache) checkAddressIndex() error {
+	if next := c.addressIndexNext(); next < c.GetNextHeight() {
+		return NotReadyError("Address index is being built, at height " + strconv.Itoa(next))
+	}
+	return nil
+}
+
+// indexAddresses adds the block at the given height, which must be the next
+// one the index is missing, to the address index.
+func (c *BlockCache) indexAddresses(height int, txs []parser.TransparentTx) error {
+	if c.addressIndex == nil {
+		return nil
+	}
+	c.addressIndex.mutex.Lock()
+	defer c.addressIndex.mutex.Unlock()
+	if height != c.addressIndex.next {
+		return errors.New("address index: unexpected block height " + strconv.Itoa(height))
+	}
+	batch := new(leveldb.Batch)
+	var undo []byte
+	// Outputs created in this block, which aren't in the db until the batch
+	// is written, but may be spent later in the block.
+	created := make(map[string][]byte)
+	for _, tx := range txs {
+		txid := append([]byte(nil), tx.Txid...)
+		for _, spent := range tx.Spends {
+			key := string(outpointKey(spent.Hash, spent.Index))
+			record, ok := created[key]
+			if ok {
+				delete(created, key)
+			} else {
+				var err error
+				record, err = c.ldb.Get([]byte(addrOutputPrefix+key), nil)
+				if err == leveldb.ErrNotFound {
+					// Not an indexed (standard) output.
+					continue
+				}
+				if err != nil {
+					return errors.Wrap(err, "address index")
+				}
+			}
+			output, err := unmarshalIndexedOutput(record)
+			if err != nil {
+				return err
+			}
+			batch.Delete(output.outputKey())
+			batch.Delete(output.unspentKey())
+			batch.Put(addrTxKey(output.address, height, tx.Index), txid)
+			undo = appendUndo(undo, undoSpent, tx.Index, record)
+		}
+		for vout, out := range tx.Outputs {
+			address := parser.ScriptAddress(out.Script)
+			if address == "" {
+				continue
+			}
+			output := &indexedOutput{
+				height:  height,
+				txIndex: tx.Index,
+				txid:    txid,
+				vout:    uint32(vout),
+				value:   out.Value,
+				address: address,
+				script:  out.Script,
+			}
+			record := output.marshal()
+			batch.Put(output.outputKey(), record)
+			batch.Put(output.unspentKey(), record)
+			batch.Put(addrTxKey(address, height, tx.Index), txid)
+			created[string(outpointKey(txid, uint32(vout)))] = record
+			undo = appendUndo(undo, undoCreated, tx.Index, record)
+		}
+	}
+	batch.Put(append([]byte(addrUndoPrefix), heightKey(height)...), undo)
+	if height >= addrUndoBlocks {
+		batch.Delete(append([]byte(addrUndoPrefix), heightKey(height-addrUndoBlocks)...))
+	}
+	c.putAddressIndexNext(batch, height+1)
+	if err := c.ldb.Write(batch, nil); err != nil {
+		return errors.Wrap(err, "address index")
+	}
+	c.addressIndex.next = height + 1
+	return nil
+}
+
+func (c *BlockCache) putAddressIndexNext(batch *leveldb.Batch, next int) {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, uint64(next))
+	batch.Put([]byte(addrIndexPrefix+c.verusID), data)
+}
+
+// revertAddressIndex removes the blocks from the given height on from the
+// address index, rebuilding it if they can't all be undone.
+func (c *BlockCache) revertAddressIndex(height int) {
+	if c.addressIndex == nil {
+		return
+	}
+	c.addressIndex.mutex.Lock()
+	defer c.addressIndex.mutex.Unlock()
+	for c.addressIndex.next > height {
+		if err := c.undoAddressIndexBlock(c.addressIndex.next - 1); err != nil {
+			Log.Warning("Rebuilding the address index: ", err)
+			c.resetAddressIndex()
+			return
+		}
+		c.addressIndex.next--
+	}
+}
+
+// undoAddressIndexBlock removes the block at the given height, the last one
+// indexed, from the address index.
+func (c *BlockCache) undoAddressIndexBlock(height int) error {
+	undoKey := append([]byte(addrUndoPrefix), heightKey(height)...)
+	undo, err := c.ldb.Get(undoKey, nil)
+	if err != nil {
+		return errors.Wrap(err, "can't undo block "+strconv.Itoa(height))
+	}
+	changes, err := parseUndo(undo)
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	// In reverse, in case an output was created and spent in the block.
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		output := change.output
+		switch change.kind {
+		case undoCreated:
+			batch.Delete(output.outputKey())
+			batch.Delete(output.unspentKey())
+		case undoSpent:
+			record := output.marshal()
+			batch.Put(output.outputKey(), record)
+			batch.Put(output.unspentKey(), record)
+		}
+		batch.Delete(addrTxKey(output.address, height, change.txIndex))
+	}
+	batch.Delete(undoKey)
+	c.putAddressIndexNext(batch, height)
+	return c.ldb.Write(batch, nil)
+}
+
+// resetAddressIndex empties the address index, so that it's rebuilt.
+func (c *BlockCache) resetAddressIndex() {
+	for _, prefix := range []string{addrOutputPrefix, addrUnspentPrefix, addrTxPrefix, addrUndoPrefix} {
+		iter := c.ldb.NewIterator(util.BytesPrefix([]byte(prefix)), nil)
+		batch := new(leveldb.Batch)
+		for iter.Next() {
+			batch.Delete(append([]byte(nil), iter.Key()...))
+			if batch.Len() >= 10000 {
+				c.ldb.Write(batch, nil)
+				batch.Reset()
+			}
+		}
+		iter.Release()
+		c.ldb.Write(batch, nil)
+	}
+	batch := new(leveldb.Batch)
+	c.putAddressIndexNext(batch, 0)
+	c.ldb.Write(batch, nil)
+	c.addressIndex.next = 0
+}
+
+// catchUpAddressIndex indexes up to count of the blocks that are in the
+// cache but not yet in the address index (initially, all of them and those
+// before the cache's first block), requesting them from zcashd. A block
+// that's different from the cached one means the cache is on a stale chain,
+// so it's reorged.
+func (c *BlockCache) catchUpAddressIndex(count int) error {
+	for ; count > 0; count-- {
+		height := c.addressIndexNext()
+		if height >= c.GetNextHeight() {
+			return nil
+		}
+		block, reader, err := getBlockAndReaderFromRPC(height)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return nil
+		}
+		if cached := c.Get(height); cached != nil && string(cached.Hash) != string(block.Hash) {
+			Log.Info("REORG: dropping block ", height, " ", displayHash(cached.Hash))
+			c.Reorg(height)
+			return nil
+		}
+		if err = c.indexAddresses(height, reader.Transparent()); err != nil {
+			return err
+		}
+	}
+	if next := c.addressIndexNext(); next < c.GetNextHeight() {
+		Log.Info("Address index at height ", next)
+	}
+	return nil
+}
+
+// GetIndexedUtxos returns the unspent outputs paying the given addresses,
+// from the address index, in the form zcashd's getaddressutxos returns them
+// (ordered by height).
+func GetIndexedUtxos(cache *BlockCache, addresses []string) ([]ZcashdRpcReplyGetaddressutxos, error) {
+	if err := cache.checkAddressIndex(); err != nil {
+		return nil, err
+	}
+	utxos := make([]ZcashdRpcReplyGetaddressutxos, 0)
+	for _, address := range addresses {
+		iter := cache.ldb.NewIterator(util.BytesPrefix(addressKey(addrUnspentPrefix, address)), nil)
+		for iter.Next() {
+			output, err := unmarshalIndexedOutput(iter.Value())
+			if err != nil {
+				iter.Release()
+				return nil, err
+			}
+			utxos = append(utxos, ZcashdRpcReplyGetaddressutxos{
+				Address:     output.address,
+				Txid:        hex.EncodeToString(parser.Reverse(output.txid)),
+				OutputIndex: int64(output.vout),
+				Script:      hex.EncodeToString(output.script),
+				Satoshis:    output.value,
+				Height:      output.height,
+			})
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return nil, errors.Wrap(err, "address index")
+		}
+	}
+	sort.SliceStable(utxos, func(i, j int) bool { return utxos[i].Height < utxos[j].Height })
+	return utxos, nil
+}
+
+// GetIndexedBalance returns the total of the unspent outputs paying the
+// given addresses, from the address index.
+func GetIndexedBalance(cache *BlockCache, addresses []string) (int64, error) {
+	utxos, err := GetIndexedUtxos(cache, addresses)
+	if err != nil {
+		return 0, err
+	}
+	var balance int64
+	for _, utxo := range utxos {
+		balance += int64(utxo.Satoshis)
+	}
+	return balance, nil
+}
+
+// addressTx is the position of a transaction in the chain.
+type addressTx struct {
+	height, index int
+}
+
+// addressTxs returns the transactions involving the given addresses within
+// the given block range, in order.
+func (c *BlockCache) addressTxs(addresses []string, start, end uint64) ([]addressTx, error) {
+	seen := make(map[addressTx]bool)
+	txs := make([]addressTx, 0)
+	for _, address := range addresses {
+		iter := c.ldb.NewIterator(&util.Range{
+			Start: append(addressKey(addrTxPrefix, address), heightKey(int(start))...),
+			Limit: append(addressKey(addrTxPrefix, address), heightKey(int(end)+1)...),
+		}, nil)
+		for iter.Next() {
+			key := iter.Key()
+			tx := addressTx{
+				height: int(binary.BigEndian.Uint32(key[len(key)-8:])),
+				index:  int(binary.BigEndian.Uint32(key[len(key)-4:])),
+			}
+			if !seen[tx] {
+				seen[tx] = true
+				txs = append(txs, tx)
+			}
+		}
+		iter.Release()
+		if err := iter.Error(); err != nil {
+			return nil, errors.Wrap(err, "address index")
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].height < txs[j].height || (txs[i].height == txs[j].height && txs[i].index < txs[j].index)
+	})
+	return txs, nil
+}
+
+// GetIndexedTransactions calls f for each transaction involving the given
+// addresses within the given block range, in order, from the address index.
+// The transactions are read from their blocks, so zcashd doesn't need
+// -txindex.
+func GetIndexedTransactions(cache *BlockCache, addresses []string, start, end uint64, f func(*walletrpc.RawTransaction) error) error {
+	if err := cache.checkAddressIndex(); err != nil {
+		return err
+	}
+	txs, err := cache.addressTxs(addresses, start, end)
+	if err != nil {
+		return err
+	}
+	// Each block is read (once) as far as its last wanted transaction.
+	var reader *parser.BlockReader
+	defer func() {
+		if reader != nil {
+			reader.Close()
+		}
+	}()
+	readerHeight, readerNext := -1, 0
+	for _, atx := range txs {
+		if atx.height != readerHeight {
+			if reader != nil {
+				reader.Close()
+			}
+			if reader, err = getBlockReaderFromRPC(atx.height); err != nil {
+				return err
+			}
+			if reader == nil {
+				return errors.New("block " + strconv.Itoa(atx.height) + " is missing")
+			}
+			readerHeight, readerNext = atx.height, 0
+		}
+		var tx *parser.Transaction
+		for ; readerNext <= atx.index; readerNext++ {
+			if tx, err = reader.Next(); err != nil {
+				return errors.Wrap(err, "error parsing block "+strconv.Itoa(atx.height))
+			}
+		}
+		if err = f(&walletrpc.RawTransaction{Data: tx.Bytes(), Height: uint64(atx.height)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -8,12 +8,16 @@ package common
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"hash/fnv"
 	"strconv"
 	"sync"
 
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/parser/sapling"
 	"github.com/asherda/lightwalletd/walletrpc"
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 )
@@ -22,8 +26,15 @@ const (
 	blockHeightPrefix = "B" // key is "B" + block height, value is block; see also H, block by hash
 	blockHashPrefix   = "H" // key is "H" + block hash, value is block; see also B, block by height
 	idPrefix          = "I" // key is "I" + chain ID, value is height (more to come), see next (verusID)
+	treePrefix        = "T" // key is "T" + block height, value is the Sapling tree after that block
+	subtreePrefix     = "S" // key is "S" + subtree index, value is the Sapling subtree root (SubtreeRoot)
+	transparentPrefix = "P" // key is "P" + block height, value is the transparent parts of the block's transactions
 )
 
+// subtreeHeight is the height of the Sapling subtrees whose roots are stored;
+// tests reduce it.
+var subtreeHeight = sapling.SubtreeHeight
+
 // BlockCache contains a consecutive set of recent compact blocks in marshalled form.
 type BlockCache struct {
 	verusID    string
@@ -32,6 +43,27 @@ type BlockCache struct {
 	latestHash []byte      // hash of the most recent (highest height) block, for detecting reorgs.
 	ldb        *leveldb.DB // levelDB connection
 	mutex      sync.RWMutex
+
+	// Sapling note commitment tree after the most recent block, or nil if
+	// it's unknown (so tree states must be requested from zcashd).
+	saplingTree *sapling.Tree
+	// Number of Sapling subtree roots stored (indices 0 to subtreeRoots-1).
+	subtreeRoots int
+
+	// Clients waiting for new blocks and reorgs.
+	subscribers broadcaster[*walletrpc.BlockUpdate]
+
+	// Height of the first block without a filter (see filters.go), or -1
+	// if block filters aren't enabled.
+	filterNext int
+
+	// Lightwalletd's own transparent address index (see addressindex.go),
+	// or nil if it's not enabled.
+	addressIndex *addressIndex
+
+	// Whether the transparent parts of blocks are stored as they're added,
+	// rather than requested from verusd when a client asks for them.
+	transparentData bool
 }
 
 // GetNextHeight returns the height of the lowest unobtained block.
@@ -77,6 +109,9 @@ func (c *BlockCache) setDbHeight(height int) {
 		c.storeNewHeight(true)
 		c.nextBlock = height
 		c.setLatestHash()
+		c.loadSaplingTree()
+		c.revertFilters(height)
+		c.revertAddressIndex(height)
 	}
 }
 
@@ -154,6 +189,46 @@ func (c *BlockCache) Reset(startHeight int) {
 	c.setDbHeight(c.firstBlock) // empty the cache
 	c.firstBlock = startHeight
 	c.nextBlock = startHeight
+	c.loadSaplingTree()
+	if c.filterNext >= 0 {
+		c.filterNext = startHeight
+		c.storeFilterNext()
+	}
+}
+
+// Caller should hold c.mutex.Lock().
+func (c *BlockCache) loadSaplingTree() {
+	c.flushSubtreeRoots(c.nextBlock)
+	if c.nextBlock == c.firstBlock {
+		// The cache starts at Sapling activation, where the tree is empty.
+		c.saplingTree = sapling.NewTree()
+		return
+	}
+	c.saplingTree = nil
+	data := c.readTreeData(c.nextBlock - 1)
+	if data == nil {
+		return
+	}
+	tree := sapling.NewTree()
+	if err := tree.UnmarshalBinary(data); err != nil {
+		Log.Warning("sapling tree at height ", c.nextBlock-1, " unreadable: ", err)
+		return
+	}
+	c.saplingTree = tree
+}
+
+// Caller should hold (at least) c.mutex.RLock().
+func (c *BlockCache) readTreeData(height int) []byte {
+	cacheResult, err := c.ldb.Get([]byte(treePrefix+strconv.Itoa(height)), nil)
+	if err != nil || len(cacheResult) < 8 {
+		return nil
+	}
+	data := cacheResult[8:]
+	if !bytes.Equal(checksum(height, data), cacheResult[:8]) {
+		Log.Warning("bad sapling tree checksum at height: ", height)
+		return nil
+	}
+	return data
 }
 
 // NewBlockCache returns an instance of a block cache object.
@@ -167,6 +242,7 @@ func NewBlockCache(db *leveldb.DB, chainID string, startHeight int, redownload b
 	c.verusID = chainID
 	c.ldb = db
 	c.firstBlock = startHeight
+	c.filterNext = -1
 
 	// Fetch the cache highwater record for the VerusCoin chain cache
 	// H prefix for height
@@ -195,6 +271,13 @@ func NewBlockCache(db *leveldb.DB, chainID string, startHeight int, redownload b
 			break
 		}
 	}
+	for c.readSubtreeRoot(c.subtreeRoots) != nil {
+		c.subtreeRoots++
+	}
+	c.loadSaplingTree()
+	if c.saplingTree == nil {
+		Log.Warning("No sapling tree for height ", c.nextBlock-1, ", tree states will be requested from zcashd")
+	}
 	Log.Info("Found ", c.nextBlock-c.firstBlock, " blocks in cache")
 	return c
 }
@@ -240,6 +323,10 @@ func (c *BlockCache) Add(height int, block *walletrpc.CompactBlock) error {
 	if err != nil {
 		Log.Fatal("hash write at height", height, "failed: ", err)
 	}
+	if c.saplingTree != nil {
+		c.addSaplingTree(height, block)
+	}
+	c.nextBlock++
 	err = c.storeNewHeight(false)
 
 	if err != nil {
@@ -250,8 +337,11 @@ func (c *BlockCache) Add(height int, block *walletrpc.CompactBlock) error {
 		c.latestHash = make([]byte, len(block.Hash))
 	}
 	copy(c.latestHash, block.Hash)
-	c.nextBlock++
 	// Invariant: m[firstBlock..nextBlock) are valid.
+	c.subscribers.broadcast(&walletrpc.BlockUpdate{
+		Block:        &walletrpc.BlockID{Height: block.Height, Hash: block.Hash},
+		CompactBlock: block,
+	})
 	return nil
 }
 
@@ -275,6 +365,208 @@ func (c *BlockCache) Reorg(height int) {
 	// adjust to the new height
 	c.nextBlock = height
 	c.setLatestHash()
+	c.loadSaplingTree()
+	c.revertFilters(height)
+	c.revertAddressIndex(height)
+	dropBlockFees(height)
+	forgetMinedTxStatuses(height)
+	c.subscribers.broadcast(&walletrpc.BlockUpdate{
+		Block: &walletrpc.BlockID{
+			Height: uint64(c.nextBlock - 1),
+			Hash:   append([]byte(nil), c.latestHash...),
+		},
+		Reorg: true,
+	})
+}
+
+// Append the block's note commitments to the Sapling tree and checkpoint it.
+// Caller should hold c.mutex.Lock().
+func (c *BlockCache) addSaplingTree(height int, block *walletrpc.CompactBlock) {
+	for _, tx := range block.Vtx {
+		for _, output := range tx.Outputs {
+			if err := c.saplingTree.Append(output.Cmu); err != nil {
+				Log.Warning("sapling tree append at height ", height, " failed: ", err)
+				c.saplingTree = nil
+				return
+			}
+			if root, ok := c.saplingTree.CompletedSubtreeRoot(subtreeHeight); ok {
+				index := int(c.saplingTree.Size()>>uint(subtreeHeight)) - 1
+				c.addSubtreeRoot(index, &walletrpc.SubtreeRoot{
+					RootHash:              root[:],
+					CompletingBlockHash:   block.Hash,
+					CompletingBlockHeight: uint64(height),
+				})
+			}
+		}
+	}
+	if err := c.storeTree(height, c.saplingTree); err != nil {
+		Log.Fatal("sapling tree write at height ", height, " failed: ", err)
+	}
+}
+
+func (c *BlockCache) storeTree(height int, tree *sapling.Tree) error {
+	data, err := tree.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	checkSummed := append(checksum(height, data), data...)
+	return c.ldb.Put([]byte(treePrefix+strconv.Itoa(height)), checkSummed, &opt.WriteOptions{Sync: false})
+}
+
+// Store a newly completed subtree root; the stored roots have no gaps, so if
+// the earlier roots aren't known (because the tree was set from zcashd) it
+// isn't stored. Caller should hold c.mutex.Lock().
+func (c *BlockCache) addSubtreeRoot(index int, root *walletrpc.SubtreeRoot) {
+	if index != c.subtreeRoots {
+		Log.Warning("sapling subtree root ", index, " not stored, ", c.subtreeRoots, " roots are known")
+		return
+	}
+	data, err := proto.Marshal(root)
+	if err != nil {
+		Log.Fatal("sapling subtree root marshal failed: ", err)
+	}
+	checkSummed := append(checksum(index, data), data...)
+	err = c.ldb.Put([]byte(subtreePrefix+strconv.Itoa(index)), checkSummed, &opt.WriteOptions{Sync: false})
+	if err != nil {
+		Log.Fatal("sapling subtree root write at index ", index, " failed: ", err)
+	}
+	c.subtreeRoots++
+}
+
+// Caller should hold (at least) c.mutex.RLock().
+func (c *BlockCache) readSubtreeRoot(index int) *walletrpc.SubtreeRoot {
+	cacheResult, err := c.ldb.Get([]byte(subtreePrefix+strconv.Itoa(index)), nil)
+	if err != nil || len(cacheResult) < 8 {
+		return nil
+	}
+	data := cacheResult[8:]
+	if !bytes.Equal(checksum(index, data), cacheResult[:8]) {
+		Log.Warning("bad sapling subtree root checksum at index: ", index)
+		return nil
+	}
+	root := &walletrpc.SubtreeRoot{}
+	if err := proto.Unmarshal(data, root); err != nil {
+		Log.Warning("sapling subtree root unmarshal at index: ", index, " failed: ", err)
+		return nil
+	}
+	return root
+}
+
+// Remove the subtree roots completed by blocks at the given height and beyond.
+// Caller should hold c.mutex.Lock().
+func (c *BlockCache) flushSubtreeRoots(height int) {
+	for c.subtreeRoots > 0 {
+		root := c.readSubtreeRoot(c.subtreeRoots - 1)
+		if root != nil && int(root.CompletingBlockHeight) < height {
+			break
+		}
+		c.subtreeRoots--
+		c.ldb.Delete([]byte(subtreePrefix+strconv.Itoa(c.subtreeRoots)), nil)
+	}
+}
+
+// GetSubtreeRoot returns the Sapling subtree root with the given index, or
+// nil if it's not known.
+func (c *BlockCache) GetSubtreeRoot(index int) *walletrpc.SubtreeRoot {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if index < 0 || index >= c.subtreeRoots {
+		return nil
+	}
+	return c.readSubtreeRoot(index)
+}
+
+// SubtreeRootsComplete returns true if the roots of all the Sapling subtrees
+// completed so far are known. They're only known if the tree has been
+// maintained from Sapling activation; if it was set from zcashd (see
+// SetSaplingTree) the cache must be redownloaded to find them.
+func (c *BlockCache) SubtreeRootsComplete() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.saplingTree != nil && int(c.saplingTree.Size()>>uint(subtreeHeight)) == c.subtreeRoots
+}
+
+// HasSaplingTree returns true if the Sapling tree after the most recent block
+// is known, so that it's maintained as blocks are added.
+func (c *BlockCache) HasSaplingTree() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.saplingTree != nil
+}
+
+// SetSaplingTree sets the Sapling tree after the block at the given height,
+// which must be the most recent block; it's used to start maintaining the
+// tree when the cache doesn't have it (for example, from z_gettreestate).
+func (c *BlockCache) SetSaplingTree(height int, tree *sapling.Tree) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if height != c.nextBlock-1 {
+		return errors.New("sapling tree height is not the latest height")
+	}
+	if err := c.storeTree(height, tree); err != nil {
+		return err
+	}
+	c.saplingTree = tree.Clone()
+	return nil
+}
+
+// CheckSaplingRoot compares the root of the Sapling tree after the block at
+// the given height (which must be the most recent block) with the root from
+// its header, returning false if they differ. The tree is then dropped, so
+// tree states at this height and beyond are requested from zcashd. An
+// all-zero root (before Sapling activation) isn't checked.
+func (c *BlockCache) CheckSaplingRoot(height int, root []byte) bool {
+	c.mutex.RLock()
+	tree := c.saplingTree
+	if tree == nil || height != c.nextBlock-1 || bytes.Equal(root, make([]byte, len(root))) {
+		c.mutex.RUnlock()
+		return true
+	}
+	// The root takes a while to compute, so it's done without the lock, on
+	// a copy (computing it caches it in the tree).
+	tree = tree.Clone()
+	c.mutex.RUnlock()
+	treeRoot := tree.Root()
+	if bytes.Equal(treeRoot[:], root) {
+		return true
+	}
+	Log.Warning("sapling tree root ", hex.EncodeToString(parser.Reverse(treeRoot[:])),
+		" does not match block ", height, " root ", hex.EncodeToString(parser.Reverse(root)))
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.saplingTree == nil || height != c.nextBlock-1 {
+		// Dropped, or the cache has changed, in the meantime.
+		return false
+	}
+	c.ldb.Delete([]byte(treePrefix+strconv.Itoa(height)), nil)
+	c.saplingTree = nil
+	c.flushSubtreeRoots(height)
+	return false
+}
+
+// GetTreeState returns the Sapling tree state after the block at the given
+// height, or nil if the cache doesn't have it. The Network isn't set.
+func (c *BlockCache) GetTreeState(height int) *walletrpc.TreeState {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if height < c.firstBlock || height >= c.nextBlock {
+		return nil
+	}
+	data := c.readTreeData(height)
+	if data == nil {
+		return nil
+	}
+	block := c.readBlock(height)
+	if block == nil {
+		return nil
+	}
+	return &walletrpc.TreeState{
+		Height: uint64(height),
+		Hash:   hex.EncodeToString(parser.Reverse(block.Hash)),
+		Time:   block.Time,
+		Tree:   hex.EncodeToString(data),
+	}
 }
 
 // Get returns the compact block at the requested height if it's
@@ -299,6 +591,67 @@ func (c *BlockCache) Get(height int) *walletrpc.CompactBlock {
 	return block
 }
 
+// EnableTransparentData makes the cache store the transparent parts of the
+// transactions of each block as it's added, so that GetBlockRange requests
+// for them needn't fetch the blocks from verusd again. It's called once, at
+// startup; blocks already cached aren't affected.
+func (c *BlockCache) EnableTransparentData() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.transparentData = true
+}
+
+// transparentDataEnabled returns true if the cache stores the transparent
+// parts of blocks.
+func (c *BlockCache) transparentDataEnabled() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.transparentData
+}
+
+// addTransparent stores the transparent parts of the transactions of the
+// block with the given height and hash, for clients that ask for them. It's
+// stored as a compact block with only those transactions (and the hash).
+func (c *BlockCache) addTransparent(height int, hash []byte, vtx []*walletrpc.CompactTx) error {
+	data, err := proto.Marshal(&walletrpc.CompactBlock{Height: uint64(height), Hash: hash, Vtx: vtx})
+	if err != nil {
+		return err
+	}
+	checkSummed := append(checksum(height, data), data...)
+	return c.ldb.Put([]byte(transparentPrefix+strconv.Itoa(height)), checkSummed, &opt.WriteOptions{Sync: false})
+}
+
+// getTransparent returns the transparent parts of the transactions of the
+// block with the given height and hash, or nil if they weren't stored (for
+// example, the block was cached by an earlier version, or without
+// EnableTransparentData()).
+func (c *BlockCache) getTransparent(height int, hash []byte) []*walletrpc.CompactTx {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if !c.transparentData || height < c.firstBlock || height >= c.nextBlock {
+		return nil
+	}
+	cacheResult, err := c.ldb.Get([]byte(transparentPrefix+strconv.Itoa(height)), nil)
+	if err != nil || len(cacheResult) < 8 {
+		return nil
+	}
+	data := cacheResult[8:]
+	if !bytes.Equal(checksum(height, data), cacheResult[:8]) {
+		Log.Warning("bad transparent data checksum at height: ", height)
+		return nil
+	}
+	block := &walletrpc.CompactBlock{}
+	if err := proto.Unmarshal(data, block); err != nil {
+		Log.Warning("transparent data unmarshal at height: ", height, " failed: ", err)
+		return nil
+	}
+	// It may be left over from a block that was replaced.
+	if !bytes.Equal(block.Hash, hash) {
+		return nil
+	}
+	return block.Vtx
+}
+
 // GetLatestHeight returns the height of the most recent block, or -1
 // if the cache is empty.
 func (c *BlockCache) GetLatestHeight() int {
@@ -332,6 +685,9 @@ func (c *BlockCache) flushBlocks(height int, last int) {
 }
 
 func (c *BlockCache) flushBlock(height int) {
+	c.ldb.Delete([]byte(treePrefix+strconv.Itoa(height)), nil)
+	c.ldb.Delete([]byte(transparentPrefix+strconv.Itoa(height)), nil)
+	c.ldb.Delete([]byte(filterPrefix+strconv.Itoa(height)), nil)
 	key := []byte(blockHashPrefix + strconv.Itoa(height))
 	// lets sync these, want deleted items to stay deleted even if we crash
 	err := c.ldb.Delete(key, &opt.WriteOptions{Sync: false})
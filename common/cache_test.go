@@ -4,6 +4,7 @@
 package common
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
@@ -11,7 +12,12 @@ import (
 	"testing"
 
 	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/parser/gcs"
+	"github.com/asherda/lightwalletd/parser/sapling"
 	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var compacts []*walletrpc.CompactBlock
@@ -22,6 +28,23 @@ const (
 	unitTestChain = "unittestnet"
 )
 
+// The leveldb databases opened by testDB, by path.
+var testDBs = map[string]*leveldb.DB{}
+
+// testDB opens the leveldb database at path for a test's cache, first
+// closing the one a previous cache there was using.
+func testDB(path string) *leveldb.DB {
+	if db, ok := testDBs[path]; ok {
+		db.Close()
+	}
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		panic(err)
+	}
+	testDBs[path] = db
+	return db
+}
+
 func TestCache(t *testing.T) {
 	type compactTest struct {
 		BlockHeight int    `json:"block"`
@@ -58,7 +81,7 @@ func TestCache(t *testing.T) {
 
 	// Pretend Sapling starts at 289460.
 	os.RemoveAll(unitTestPath)
-	cache = NewBlockCache(unitTestPath, unitTestChain, 289460, true)
+	cache = NewBlockCache(testDB(unitTestPath), unitTestChain, 289460, true)
 
 	// Initially cache is empty.
 	if cache.GetLatestHeight() != -1 {
@@ -75,7 +98,7 @@ func TestCache(t *testing.T) {
 	fillCache(t)
 
 	// Simulate a restart to ensure the db files are read correctly.
-	cache = NewBlockCache(unitTestPath, unitTestChain, 289460, false)
+	cache = NewBlockCache(testDB(unitTestPath), unitTestChain, 289460, false)
 
 	// Should still be 6 blocks.
 	if cache.nextBlock != 289466 {
@@ -111,9 +134,6 @@ func reorgCache(t *testing.T) {
 	if cache.nextBlock != 289462 {
 		t.Fatal("unexpected nextBlock height")
 	}
-	if len(cache.starts) != 3 {
-		t.Fatal("unexpected len(cache.starts)")
-	}
 
 	// some "black-box" tests (using exported interfaces)
 	if cache.GetLatestHeight() != 289461 {
@@ -134,9 +154,6 @@ func reorgCache(t *testing.T) {
 	if cache.nextBlock != 289463 {
 		t.Fatal("unexpected nextBlock height")
 	}
-	if len(cache.starts) != 4 {
-		t.Fatal("unexpected len(cache.starts)")
-	}
 
 	if cache.GetLatestHeight() != 289462 {
 		t.Fatal("unexpected GetLatestHeight")
@@ -165,9 +182,6 @@ func fillCache(t *testing.T) {
 		if cache.nextBlock != 289460+i+1 {
 			t.Fatal("unexpected nextBlock height")
 		}
-		if len(cache.starts) != i+2 {
-			t.Fatal("unexpected len(cache.starts)")
-		}
 
 		// some "black-box" tests (using exported interfaces)
 		if cache.GetLatestHeight() != 289460+i {
@@ -182,3 +196,640 @@ func fillCache(t *testing.T) {
 		}
 	}
 }
+
+// addTreeTestBlocks adds blocks at heights 1000 and on, each with the given
+// number of Sapling outputs, to an empty cache, returning the expected tree
+// after each block.
+func addTreeTestBlocks(t *testing.T, treeCache *BlockCache, outputs []int) []*sapling.Tree {
+	tree := sapling.NewTree()
+	var trees []*sapling.Tree
+	prevHash := make([]byte, 32)
+	for i, n := range outputs {
+		block := &walletrpc.CompactBlock{
+			Height:   uint64(1000 + i),
+			Hash:     bytes.Repeat([]byte{byte(i + 1)}, 32),
+			PrevHash: prevHash,
+			Time:     uint32(1600000000 + i),
+		}
+		if n > 0 {
+			tx := &walletrpc.CompactTx{}
+			for j := 0; j < n; j++ {
+				cmu := make([]byte, 32)
+				cmu[0], cmu[1] = byte(i), byte(j)
+				tx.Outputs = append(tx.Outputs, &walletrpc.CompactOutput{Cmu: cmu})
+				tree.Append(cmu)
+			}
+			block.Vtx = []*walletrpc.CompactTx{tx}
+		}
+		if err := treeCache.Add(1000+i, block); err != nil {
+			t.Fatal(err)
+		}
+		prevHash = block.Hash
+		trees = append(trees, tree.Clone())
+	}
+	return trees
+}
+
+func TestCacheSaplingTree(t *testing.T) {
+	const path = "unittesttreecache"
+	os.RemoveAll(path)
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeCache := NewBlockCache(db, unitTestChain, 1000, true)
+	if !treeCache.HasSaplingTree() || treeCache.GetTreeState(1000) != nil {
+		t.Fatal("unexpected initial tree")
+	}
+
+	// Add blocks with 0, 1, 2, 3 and 0 outputs.
+	trees := addTreeTestBlocks(t, treeCache, []int{0, 1, 2, 3, 0})
+	tree := trees[len(trees)-1]
+	checkTreeState := func(height int) {
+		t.Helper()
+		treeState := treeCache.GetTreeState(height)
+		if treeState == nil {
+			t.Fatal("missing tree state at height ", height)
+		}
+		want, _ := trees[height-1000].MarshalBinary()
+		if treeState.Tree != hex.EncodeToString(want) || treeState.Height != uint64(height) ||
+			treeState.Time != uint32(1600000000+height-1000) ||
+			treeState.Hash != hex.EncodeToString(bytes.Repeat([]byte{byte(height - 1000 + 1)}, 32)) {
+			t.Fatal("unexpected tree state at height ", height, ": ", treeState)
+		}
+	}
+	for height := 1000; height <= 1004; height++ {
+		checkTreeState(height)
+	}
+
+	// A zero root (before Sapling) isn't checked; the right root matches.
+	root := tree.Root()
+	if !treeCache.CheckSaplingRoot(1004, make([]byte, 32)) || !treeCache.CheckSaplingRoot(1004, root[:]) {
+		t.Fatal("unexpected root mismatch")
+	}
+
+	// Reorg and restart reload the tree from the checkpoints.
+	treeCache.Reorg(1003)
+	if !treeCache.HasSaplingTree() || treeCache.GetTreeState(1003) != nil {
+		t.Fatal("unexpected tree after reorg")
+	}
+	checkTreeState(1002)
+	treeCache.Close()
+	db, err = leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeCache = NewBlockCache(db, unitTestChain, 1000, false)
+	latest := treeCache.GetLatestHeight()
+	if !treeCache.HasSaplingTree() || latest < 1002 {
+		t.Fatal("missing tree after restart")
+	}
+	checkTreeState(latest)
+
+	// A mismatch drops the tree (and the bad checkpoint) until it's set again.
+	if treeCache.CheckSaplingRoot(latest, bytes.Repeat([]byte{1}, 32)) {
+		t.Fatal("expected a root mismatch")
+	}
+	if treeCache.HasSaplingTree() || treeCache.GetTreeState(latest) != nil {
+		t.Fatal("unexpected tree after mismatch")
+	}
+	checkTreeState(latest - 1)
+	if treeCache.SetSaplingTree(latest-1, trees[latest-1-1000]) == nil {
+		t.Fatal("expected an error setting the tree below the latest height")
+	}
+	if err := treeCache.SetSaplingTree(latest, trees[latest-1000]); err != nil {
+		t.Fatal(err)
+	}
+	if !treeCache.HasSaplingTree() {
+		t.Fatal("missing tree after set")
+	}
+	checkTreeState(latest)
+
+	treeCache.Close()
+	os.RemoveAll(path)
+}
+
+func TestCacheSubtreeRoots(t *testing.T) {
+	const path = "unittestsubtreecache"
+	os.RemoveAll(path)
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Use subtrees of 2 leaves, which complete at sizes 2 (in block 1002),
+	// 4 and 6 (both in block 1003).
+	subtreeHeight = 1
+	defer func() { subtreeHeight = sapling.SubtreeHeight }()
+	outputs := []int{0, 1, 2, 3, 0}
+	treeCache := NewBlockCache(db, unitTestChain, 1000, true)
+	addTreeTestBlocks(t, treeCache, outputs)
+	var leaves []sapling.Node
+	for i, n := range outputs {
+		for j := 0; j < n; j++ {
+			leaves = append(leaves, sapling.Node{byte(i), byte(j)})
+		}
+	}
+	checkRoots := func(heights ...int) {
+		t.Helper()
+		for i, height := range heights {
+			root := treeCache.GetSubtreeRoot(i)
+			if root == nil {
+				t.Fatal("missing subtree root ", i)
+			}
+			want := sapling.MerkleHash(0, &leaves[2*i], &leaves[2*i+1])
+			if root.CompletingBlockHeight != uint64(height) || !bytes.Equal(root.RootHash, want[:]) ||
+				!bytes.Equal(root.CompletingBlockHash, bytes.Repeat([]byte{byte(height - 1000 + 1)}, 32)) {
+				t.Fatal("unexpected subtree root ", i, ": ", root)
+			}
+		}
+		if treeCache.GetSubtreeRoot(len(heights)) != nil {
+			t.Fatal("unexpected subtree root ", len(heights))
+		}
+	}
+	checkRoots(1002, 1003, 1003)
+
+	// The roots are found again after a restart.
+	treeCache.Close()
+	db, err = leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeCache = NewBlockCache(db, unitTestChain, 1000, false)
+	checkRoots(1002, 1003, 1003)
+
+	// Dropping blocks drops the roots they completed.
+	treeCache.Reorg(1003)
+	checkRoots(1002)
+	treeCache.Reorg(1002)
+	checkRoots()
+	if !treeCache.SubtreeRootsComplete() {
+		t.Fatal("expected the subtree roots to be complete")
+	}
+
+	// A tree set from zcashd has completed subtrees whose roots aren't known.
+	tree := sapling.NewTree()
+	for i := range leaves[:4] {
+		if err := tree.Append(leaves[i][:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := treeCache.SetSaplingTree(treeCache.GetLatestHeight(), tree); err != nil {
+		t.Fatal(err)
+	}
+	if treeCache.SubtreeRootsComplete() {
+		t.Fatal("expected the subtree roots to be incomplete")
+	}
+
+	treeCache.Close()
+	os.RemoveAll(path)
+}
+
+func TestCacheSubscribe(t *testing.T) {
+	const path = "unittestsubscribecache"
+	os.RemoveAll(path)
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subCache := NewBlockCache(db, unitTestChain, 1000, true)
+	sub := subCache.Subscribe()
+	slow := subCache.Subscribe()
+	closed := subCache.Subscribe()
+	closed.Close()
+	closed.Close()
+	if _, ok := <-closed.C; ok {
+		t.Fatal("closed subscription should not receive updates")
+	}
+
+	addTreeTestBlocks(t, subCache, []int{0, 0, 0})
+	for i := 0; i < 3; i++ {
+		update := <-sub.C
+		if update.Reorg || update.Block.Height != uint64(1000+i) ||
+			!bytes.Equal(update.Block.Hash, bytes.Repeat([]byte{byte(i + 1)}, 32)) ||
+			update.CompactBlock.Height != update.Block.Height {
+			t.Fatal("unexpected block update ", update)
+		}
+	}
+	subCache.Reorg(1001)
+	update := <-sub.C
+	if !update.Reorg || update.CompactBlock != nil || update.Block.Height != 1000 ||
+		!bytes.Equal(update.Block.Hash, bytes.Repeat([]byte{1}, 32)) {
+		t.Fatal("unexpected reorg update ", update)
+	}
+
+	// A subscriber that doesn't keep up is dropped, without affecting others.
+	for i := 0; i < blockQueueLength; i++ {
+		sub.Close()
+		sub = subCache.Subscribe()
+		subCache.Reorg(1001)
+		if err := subCache.Add(1001, &walletrpc.CompactBlock{Height: 1001, Hash: []byte{2}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n := 0
+	for range slow.C {
+		n++
+	}
+	if n != blockQueueLength {
+		t.Fatal("unexpected number of updates before drop ", n)
+	}
+	if len(sub.C) != 2 {
+		t.Fatal("unexpected number of updates after drop ", len(sub.C))
+	}
+	sub.Close()
+
+	subCache.Close()
+	os.RemoveAll(path)
+}
+
+func TestAddressIndex(t *testing.T) {
+	const path = "unittestaddrcache"
+	os.RemoveAll(path)
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrCache := NewBlockCache(db, unitTestChain, 0, true)
+	if addrCache.AddressIndexEnabled() || addrCache.addressIndexNext() != -1 {
+		t.Fatal("address index unexpectedly enabled")
+	}
+	addrCache.EnableAddressIndex()
+
+	p2pkh := func(b byte) []byte {
+		script := []byte{0x76, 0xa9, 20}
+		script = append(script, bytes.Repeat([]byte{b}, 20)...)
+		return append(script, 0x88, 0xac)
+	}
+	a, b := parser.ScriptAddress(p2pkh(1)), parser.ScriptAddress(p2pkh(2))
+	txid := func(n byte) []byte { return bytes.Repeat([]byte{n}, 32) }
+	blocks := [][]parser.TransparentTx{
+		{{Index: 0, Txid: txid(0), Outputs: []parser.TxOutput{
+			{Value: 1000, Script: p2pkh(1)}, {Value: 500, Script: p2pkh(2)}, {Value: 0, Script: []byte{0x6a}},
+		}}},
+		// tx2 spends tx1's change in the same block.
+		{
+			{Index: 0, Txid: txid(1), Spends: []parser.Outpoint{{Hash: txid(0), Index: 0}},
+				Outputs: []parser.TxOutput{{Value: 600, Script: p2pkh(2)}, {Value: 300, Script: p2pkh(1)}}},
+			{Index: 1, Txid: txid(2), Spends: []parser.Outpoint{{Hash: txid(1), Index: 1}, {Hash: txid(9), Index: 0}},
+				Outputs: []parser.TxOutput{{Value: 250, Script: p2pkh(2)}}},
+		},
+		{{Index: 0, Txid: txid(3), Spends: []parser.Outpoint{{Hash: txid(0), Index: 1}},
+			Outputs: []parser.TxOutput{{Value: 400, Script: p2pkh(1)}}}},
+	}
+	// Blocks must be big enough for the cache not to think they're corrupt.
+	newBlock := func(height int) *walletrpc.CompactBlock {
+		return &walletrpc.CompactBlock{
+			Height:   uint64(height),
+			Hash:     bytes.Repeat([]byte{byte(height + 1)}, 32),
+			PrevHash: bytes.Repeat([]byte{byte(height)}, 32),
+		}
+	}
+	addBlock := func(height int) {
+		t.Helper()
+		if err := addrCache.indexAddresses(height, blocks[height]); err != nil {
+			t.Fatal(err)
+		}
+		if err := addrCache.Add(height, newBlock(height)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkBalance := func(address string, balance int64, utxos int) {
+		t.Helper()
+		got, err := GetIndexedBalance(addrCache, []string{address})
+		if err != nil {
+			t.Fatal(err)
+		}
+		replies, err := GetIndexedUtxos(addrCache, []string{address})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != balance || len(replies) != utxos {
+			t.Fatal("unexpected balance or utxos for ", address, ": ", got, " ", replies)
+		}
+	}
+	checkTxs := func(address string, start, end uint64, want ...addressTx) {
+		t.Helper()
+		txs, err := addrCache.addressTxs([]string{address}, start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(txs) != len(want) {
+			t.Fatal("unexpected transactions for ", address, ": ", txs)
+		}
+		for i := range txs {
+			if txs[i] != want[i] {
+				t.Fatal("unexpected transactions for ", address, ": ", txs)
+			}
+		}
+	}
+
+	for height := range blocks {
+		addBlock(height)
+	}
+	checkBalance(a, 400, 1)
+	checkBalance(b, 850, 2)
+	utxos, err := GetIndexedUtxos(addrCache, []string{b, a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utxos) != 3 || utxos[0].Height != 1 || utxos[2].Height != 2 || utxos[2].Address != a ||
+		utxos[2].Txid != hex.EncodeToString(txid(3)) || utxos[2].Script != hex.EncodeToString(p2pkh(1)) {
+		t.Fatal("unexpected utxos", utxos)
+	}
+	checkTxs(a, 0, 2, addressTx{0, 0}, addressTx{1, 0}, addressTx{1, 1}, addressTx{2, 0})
+	checkTxs(a, 1, 1, addressTx{1, 0}, addressTx{1, 1})
+	checkTxs(b, 0, 100, addressTx{0, 0}, addressTx{1, 0}, addressTx{1, 1}, addressTx{2, 0})
+
+	// The index mustn't be used while it's behind the cache.
+	if err := addrCache.Add(3, newBlock(3)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetIndexedUtxos(addrCache, []string{a}); err == nil {
+		t.Fatal("expected an error from an index that's behind")
+	}
+	if err := addrCache.indexAddresses(4, nil); err == nil {
+		t.Fatal("expected an error indexing a block out of order")
+	}
+	if err := addrCache.indexAddresses(3, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A reorg undoes blocks 2 and 3; the index survives a restart.
+	addrCache.Reorg(2)
+	checkBalance(a, 0, 0)
+	checkBalance(b, 1350, 3)
+	checkTxs(a, 0, 100, addressTx{0, 0}, addressTx{1, 0}, addressTx{1, 1})
+	addrCache.Close()
+	db, err = leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrCache = NewBlockCache(db, unitTestChain, 0, false)
+	addrCache.EnableAddressIndex()
+	if addrCache.addressIndexNext() != 2 {
+		t.Fatal("unexpected index height after restart", addrCache.addressIndexNext())
+	}
+	// The cache kept (and stored the height after) the reorg's block, which
+	// the ingestor would index to catch up.
+	if err := addrCache.indexAddresses(2, blocks[2]); err != nil {
+		t.Fatal(err)
+	}
+	checkBalance(a, 400, 1)
+	checkBalance(b, 850, 2)
+
+	// Undoing everything leaves nothing.
+	addrCache.mutex.Lock()
+	addrCache.revertAddressIndex(0)
+	addrCache.mutex.Unlock()
+	iter := db.NewIterator(nil, nil)
+	for iter.Next() {
+		if len(iter.Key()) == 0 {
+			continue
+		}
+		if prefix := string(iter.Key()[:1]); prefix == addrOutputPrefix || prefix == addrUnspentPrefix ||
+			prefix == addrTxPrefix || prefix == addrUndoPrefix {
+			t.Fatal("unexpected address index entry after undoing all blocks", iter.Key())
+		}
+	}
+	iter.Release()
+
+	addrCache.Close()
+	os.RemoveAll(path)
+}
+
+func TestCacheTransparent(t *testing.T) {
+	const path = "unittesttransparentcache"
+	os.RemoveAll(path)
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tCache := NewBlockCache(db, unitTestChain, 0, true)
+
+	newBlock := func(height int) *walletrpc.CompactBlock {
+		return &walletrpc.CompactBlock{
+			Height:   uint64(height),
+			Hash:     bytes.Repeat([]byte{byte(height + 1)}, 32),
+			PrevHash: bytes.Repeat([]byte{byte(height)}, 32),
+			// Shielded transactions 1 and 3.
+			Vtx: []*walletrpc.CompactTx{
+				{Index: 1, Hash: []byte{1}, Outputs: []*walletrpc.CompactOutput{{Cmu: []byte{1}}}},
+				{Index: 3, Hash: []byte{3}, Spends: []*walletrpc.CompactSpend{{Nf: []byte{3}}}},
+			},
+		}
+	}
+	// Transactions 0 (the coinbase), 1 and 2 have transparent parts.
+	transparent := []*walletrpc.CompactTx{
+		{Index: 0, Hash: []byte{0}, Vout: []*walletrpc.TxOut{{Value: 1000, ScriptPubKey: []byte{0x51}}}},
+		{Index: 1, Hash: []byte{1}, Vin: []*walletrpc.CompactTxIn{{PrevoutTxid: []byte{9}, PrevoutIndex: 2}}},
+		{Index: 2, Hash: []byte{2}, Vin: []*walletrpc.CompactTxIn{{PrevoutTxid: []byte{8}}},
+			Vout: []*walletrpc.TxOut{{Value: 500, ScriptPubKey: []byte{0x52}}}},
+	}
+	for height := 0; height < 2; height++ {
+		block := newBlock(height)
+		if err := tCache.addTransparent(height, block.Hash, transparent); err != nil {
+			t.Fatal(err)
+		}
+		if err := tCache.Add(height, block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tCache.getTransparent(1, newBlock(1).Hash) != nil {
+		t.Fatal("transparent data returned while it isn't enabled")
+	}
+	tCache.EnableTransparentData()
+	if tCache.getTransparent(1, newBlock(0).Hash) != nil {
+		t.Fatal("transparent data returned for the wrong block")
+	}
+	if tCache.getTransparent(2, newBlock(2).Hash) != nil {
+		t.Fatal("transparent data returned for a block that isn't cached")
+	}
+
+	blockChan := make(chan *walletrpc.CompactBlock)
+	errChan := make(chan error)
+	go GetBlockRange(tCache, blockChan, errChan, 0, 1, BlockRangeOptions{Transparent: true})
+	for height := 0; height < 2; height++ {
+		var block *walletrpc.CompactBlock
+		select {
+		case err := <-errChan:
+			t.Fatal("unexpected GetBlockRange error ", err)
+		case block = <-blockChan:
+		}
+		if int(block.Height) != height || len(block.Vtx) != 4 {
+			t.Fatal("unexpected block ", block)
+		}
+		for i, tx := range block.Vtx {
+			if tx.Index != uint64(i) {
+				t.Fatal("unexpected transaction order ", block.Vtx)
+			}
+		}
+		vtx := block.Vtx
+		if len(vtx[0].Vout) != 1 || vtx[0].Vout[0].Value != 1000 ||
+			len(vtx[1].Outputs) != 1 || len(vtx[1].Vin) != 1 || vtx[1].Vin[0].PrevoutIndex != 2 ||
+			len(vtx[2].Vin) != 1 || len(vtx[2].Vout) != 1 ||
+			len(vtx[3].Spends) != 1 || len(vtx[3].Vin) != 0 || len(vtx[3].Vout) != 0 {
+			t.Fatal("unexpected transactions ", vtx)
+		}
+	}
+	if err := <-errChan; err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the option, the blocks are as cached.
+	go GetBlockRange(tCache, blockChan, errChan, 1, 1, BlockRangeOptions{})
+	if block := <-blockChan; len(block.Vtx) != 2 || len(block.Vtx[0].Vin) != 0 {
+		t.Fatal("unexpected block ", block)
+	}
+	if err := <-errChan; err != nil {
+		t.Fatal(err)
+	}
+
+	// A block that's removed takes its transparent data with it.
+	tCache.Reorg(0)
+	if _, err := db.Get([]byte(transparentPrefix+"1"), nil); err == nil {
+		t.Fatal("transparent data not removed with its block")
+	}
+
+	tCache.Close()
+	os.RemoveAll(path)
+}
+
+func TestCacheFilters(t *testing.T) {
+	const path = "unittestfiltercache"
+	os.RemoveAll(path)
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fCache := NewBlockCache(db, unitTestChain, 0, true)
+	if err := GetBlockFilters(fCache, 0, 0, nil); status.Code(err) != codes.FailedPrecondition {
+		t.Fatal("expected an error while block filters aren't enabled, got ", err)
+	}
+	fCache.EnableBlockFilters()
+	fCache.EnableTransparentData()
+
+	newBlock := func(height int) *walletrpc.CompactBlock {
+		return &walletrpc.CompactBlock{
+			Height:   uint64(height),
+			Hash:     bytes.Repeat([]byte{byte(height + 1)}, 32),
+			PrevHash: bytes.Repeat([]byte{byte(height)}, 32),
+		}
+	}
+	script := func(n byte) []byte { return []byte{0x76, 0xa9, n} }
+	transparent := func(height int) []*walletrpc.CompactTx {
+		return []*walletrpc.CompactTx{
+			{Index: 0, Vout: []*walletrpc.TxOut{
+				{Value: 1000, ScriptPubKey: script(byte(height))},
+				{ScriptPubKey: []byte{0x6a, 1}},
+			}},
+			{Index: 1, Vin: []*walletrpc.CompactTxIn{{PrevoutTxid: bytes.Repeat([]byte{9}, 32), PrevoutIndex: uint32(height)}}},
+		}
+	}
+	outpoint := func(height int) []byte {
+		return append(bytes.Repeat([]byte{9}, 32), byte(height), 0, 0, 0)
+	}
+	addBlock := func(height int, filter bool) {
+		t.Helper()
+		block := newBlock(height)
+		if err := fCache.addTransparent(height, block.Hash, transparent(height)); err != nil {
+			t.Fatal(err)
+		}
+		if filter {
+			if err := fCache.addFilter(height, block.Hash, transparent(height)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := fCache.Add(height, block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	getFilters := func(start, end int) ([]*walletrpc.BlockFilter, error) {
+		filters := make([]*walletrpc.BlockFilter, 0)
+		err := GetBlockFilters(fCache, start, end, func(filter *walletrpc.BlockFilter) error {
+			filters = append(filters, filter)
+			return nil
+		})
+		return filters, err
+	}
+	checkFilters := func() {
+		t.Helper()
+		filters, err := getFilters(0, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prevHeader := make([]byte, 32)
+		for height, filter := range filters {
+			block := newBlock(height)
+			if int(filter.Height) != height || !bytes.Equal(filter.Hash, block.Hash) ||
+				!bytes.Equal(filter.PrevHeader, prevHeader) ||
+				!bytes.Equal(filter.Header, gcs.Header(filter.Filter, prevHeader)) {
+				t.Fatal("unexpected filter ", filter)
+			}
+			prevHeader = filter.Header
+			key := gcs.Key(block.Hash)
+			for _, item := range [][]byte{script(byte(height)), outpoint(height)} {
+				if ok, err := gcs.Match(filter.Filter, key, item); !ok || err != nil {
+					t.Fatal("filter at height ", height, " doesn't match ", item, err)
+				}
+			}
+			if ok, _ := gcs.Match(filter.Filter, key, []byte{0x6a, 1}); ok {
+				t.Fatal("filter matches an OP_RETURN script")
+			}
+			if ok, _ := gcs.Match(filter.Filter, key, script(byte(height+10))); ok {
+				t.Fatal("filter matches another block's script")
+			}
+		}
+		if len(filters) != 3 {
+			t.Fatal("unexpected number of filters ", len(filters))
+		}
+	}
+
+	addBlock(0, true)
+	addBlock(1, true)
+	if err := fCache.addFilter(3, newBlock(3).Hash, nil); err == nil {
+		t.Fatal("expected an error adding a filter out of order")
+	}
+	// The filter of a block cached without one is built to catch up.
+	addBlock(2, false)
+	if _, err := getFilters(0, 2); status.Code(err) != codes.Unavailable {
+		t.Fatal("expected a not ready error while filters are behind, got ", err)
+	}
+	if err := fCache.catchUpFilters(filterBatch); err != nil {
+		t.Fatal(err)
+	}
+	if fCache.filterNextHeight() != 3 {
+		t.Fatal("unexpected filter height ", fCache.filterNextHeight())
+	}
+	checkFilters()
+
+	for _, r := range [][2]int{{2, 1}, {1, 3}} {
+		if _, err := getFilters(r[0], r[1]); err == nil {
+			t.Fatal("expected an error for filter range ", r)
+		}
+	}
+	if filters, err := getFilters(1, 1); err != nil || len(filters) != 1 || filters[0].Height != 1 {
+		t.Fatal("unexpected filters ", filters, err)
+	}
+
+	// The filters survive a restart, and a reorg removes them with the blocks.
+	fCache.Sync()
+	fCache.Close()
+	db, err = leveldb.OpenFile(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fCache = NewBlockCache(db, unitTestChain, 0, false)
+	fCache.EnableBlockFilters()
+	fCache.EnableTransparentData()
+	checkFilters()
+	fCache.Reorg(1)
+	if fCache.filterNextHeight() != 1 {
+		t.Fatal("unexpected filter height after reorg ", fCache.filterNextHeight())
+	}
+	if _, err := db.Get([]byte(filterPrefix+"2"), nil); err == nil {
+		t.Fatal("block filter not removed with its block")
+	}
+
+	fCache.Close()
+	os.RemoveAll(path)
+}
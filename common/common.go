@@ -5,14 +5,18 @@
 package common
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"io"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/parser/sapling"
 	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/btcsuite/btcd/btcjson"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -27,25 +31,29 @@ var (
 )
 
 type Options struct {
-	GRPCBindAddr        string `json:"grpc_bind_address,omitempty"`
-	GRPCLogging         bool   `json:"grpc_logging_insecure,omitempty"`
-	HTTPBindAddr        string `json:"http_bind_address,omitempty"`
-	TLSCertPath         string `json:"tls_cert_path,omitempty"`
-	TLSKeyPath          string `json:"tls_cert_key,omitempty"`
-	LogLevel            uint64 `json:"log_level,omitempty"`
-	LogFile             string `json:"log_file,omitempty"`
-	VerusConfPath       string `json:"zcash_conf,omitempty"`
-	RPCUser             string `json:"rpcuser"`
-	RPCPassword         string `json:"rpcpassword"`
-	RPCHost             string `json:"rpchost"`
-	RPCPort             string `json:"rpcport"`
-	NoTLSVeryInsecure   bool   `json:"no_tls_very_insecure,omitempty"`
-	GenCertVeryInsecure bool   `json:"gen_cert_very_insecure,omitempty"`
-	Redownload          bool   `json:"redownload"`
-	DataDir             string `json:"data_dir"`
-	PingEnable          bool   `json:"ping_enable"`
-	Darkside            bool   `json:"darkside"`
-	DarksideTimeout     uint64 `json:"darkside_timeout"`
+	GRPCBindAddr        string        `json:"grpc_bind_address,omitempty"`
+	GRPCLogging         bool          `json:"grpc_logging_insecure,omitempty"`
+	HTTPBindAddr        string        `json:"http_bind_address,omitempty"`
+	TLSCertPath         string        `json:"tls_cert_path,omitempty"`
+	TLSKeyPath          string        `json:"tls_cert_key,omitempty"`
+	LogLevel            uint64        `json:"log_level,omitempty"`
+	LogFile             string        `json:"log_file,omitempty"`
+	VerusConfPath       string        `json:"zcash_conf,omitempty"`
+	RPCUser             string        `json:"rpcuser"`
+	RPCPassword         string        `json:"rpcpassword"`
+	RPCHost             string        `json:"rpchost"`
+	RPCPort             string        `json:"rpcport"`
+	NoTLSVeryInsecure   bool          `json:"no_tls_very_insecure,omitempty"`
+	GenCertVeryInsecure bool          `json:"gen_cert_very_insecure,omitempty"`
+	Redownload          bool          `json:"redownload"`
+	DataDir             string        `json:"data_dir"`
+	PingEnable          bool          `json:"ping_enable"`
+	Darkside            bool          `json:"darkside"`
+	DarksideTimeout     uint64        `json:"darkside_timeout"`
+	AddressIndex        bool          `json:"address_index"`
+	BlockFilters        bool          `json:"block_filters"`
+	TransparentData     bool          `json:"transparent_data"`
+	TxLimits            parser.Limits `json:"tx_limits"`
 }
 
 // RawRequest points to the function to send a an RPC request to zcashd;
@@ -53,6 +61,13 @@ type Options struct {
 // in unit tests it points to a function to mock RPCs to zcashd.
 var RawRequest func(method string, params []json.RawMessage) (json.RawMessage, error)
 
+// StreamRequest, if set, points to a function that sends an RPC request to
+// zcashd like RawRequest, but returns the reply (the whole JSON-RPC response)
+// as it arrives, rather than once it all has. It's used for getblock, whose
+// replies can be large, so that blocks are parsed while they're received;
+// when it's nil (in unit tests and darkside mode), RawRequest is used.
+var StreamRequest func(method string, params []json.RawMessage) (io.ReadCloser, error)
+
 // Time allows time-related functions to be mocked for testing,
 // so that tests can be deterministic and so they don't require
 // real time to elapse. In production, these point to the standard
@@ -119,8 +134,32 @@ type (
 	// zcashd rpc "getrawtransaction txid 1" (1 means verbose), there are
 	// many more fields but these are the only ones we current need.
 	ZcashdRpcReplyGetrawtransaction struct {
-		Hex    string
-		Height int
+		Hex       string
+		Height    int
+		Blocktime int64
+	}
+
+	// zcashd rpc "getrawmempool true" (verbose), by txid; only the fields
+	// we need.
+	ZcashdRpcReplyGetrawmempoolEntry struct {
+		Size int
+		Fee  json.Number // decimal
+		Time int64       // when the transaction entered the mempool
+	}
+
+	// zcashd rpc "getaddressdeltas"
+	ZcashdRpcRequestGetaddressdeltas struct {
+		Addresses []string `json:"addresses"`
+		Start     uint64   `json:"start"`
+		End       uint64   `json:"end"`
+	}
+	ZcashdRpcReplyGetaddressdeltas struct {
+		Satoshis   int64
+		Txid       string
+		Index      int64
+		Blockindex uint32 // the transaction's position in the block
+		Height     uint64
+		Address    string
 	}
 
 	// zcashd rpc "getaddressbalance"
@@ -128,7 +167,8 @@ type (
 		Addresses []string `json:"addresses"`
 	}
 	ZcashdRpcReplyGetaddressbalance struct {
-		Balance int64
+		Balance         int64
+		CurrencyBalance map[string]json.Number // decimal, by currency i-address
 	}
 
 	// zcashd rpc "getaddressutxos"
@@ -142,6 +182,109 @@ type (
 		Script      string
 		Satoshis    uint64
 		Height      int
+		// decimal, by currency i-address
+		CurrencyValues map[string]json.Number `json:",omitempty"`
+	}
+
+	// verusd rpc "getidentity", only the fields we need; an identity
+	// appears in both this and the "getidentityhistory" reply.
+	ZcashdRpcIdentity struct {
+		Version             uint32
+		Flags               uint32
+		PrimaryAddresses    []string
+		MinimumSignatures   int32
+		Name                string
+		IdentityAddress     string
+		Parent              string
+		SystemID            string
+		ContentMap          map[string]string
+		RevocationAuthority string
+		RecoveryAuthority   string
+		PrivateAddress      string
+		TimeLock            uint64
+	}
+	ZcashdRpcReplyGetidentity struct {
+		Identity           ZcashdRpcIdentity
+		FriendlyName       string
+		FullyQualifiedName string
+		Status             string
+		CanSpendFor        bool
+		CanSignFor         bool
+		BlockHeight        int
+		Txid               string
+		Vout               uint32
+	}
+
+	// verusd rpc "getidentityhistory"
+	ZcashdRpcIdentityUpdate struct {
+		Identity  ZcashdRpcIdentity
+		BlockHash string
+		Height    int
+		Output    struct {
+			Txid    string
+			VoutNum uint32
+		}
+	}
+	ZcashdRpcReplyGetidentityhistory struct {
+		FullyQualifiedName string
+		History            []ZcashdRpcIdentityUpdate
+	}
+
+	// verusd rpc "getcurrency", only the fields we need; amounts are decimal
+	ZcashdRpcReserveCurrency struct {
+		CurrencyID     string
+		Weight         json.Number
+		Reserves       json.Number
+		PriceInReserve json.Number
+	}
+	ZcashdRpcCurrencyState struct {
+		Supply            json.Number
+		ReserveCurrencies []ZcashdRpcReserveCurrency `json:",omitempty"`
+	}
+	ZcashdRpcReplyGetcurrency struct {
+		Name                 string
+		FullyQualifiedName   string
+		CurrencyID           string
+		Parent               string
+		SystemID             string
+		Options              uint32
+		ProofProtocol        uint32
+		NotarizationProtocol uint32
+		StartBlock           uint64
+		EndBlock             uint64
+		DefinitionTxid       string
+		BestHeight           uint64
+		BestCurrencyState    ZcashdRpcCurrencyState
+	}
+
+	// verusd rpc "estimateconversion"; amounts are decimal
+	ZcashdRpcRequestEstimateconversion struct {
+		Currency   string      `json:"currency"`
+		ConvertTo  string      `json:"convertto"`
+		Via        string      `json:"via,omitempty"`
+		Amount     json.Number `json:"amount"`
+		Preconvert bool        `json:"preconvert,omitempty"`
+	}
+	ZcashdRpcReplyEstimateconversion struct {
+		InputCurrencyID      string
+		NetInputAmount       json.Number
+		OutputCurrencyID     string
+		EstimatedCurrencyOut json.Number
+	}
+
+	// verusd rpc "getnotarizationdata"
+	ZcashdRpcNotarization struct {
+		Index        int
+		Txid         string
+		Notarization struct {
+			NotarizationHeight uint64
+		}
+	}
+	ZcashdRpcReplyGetnotarizationdata struct {
+		Notarizations       []ZcashdRpcNotarization
+		Forks               [][]int // indices into Notarizations
+		LastConfirmedHeight uint64
+		BestChain           int // index into Forks
 	}
 )
 
@@ -176,6 +319,12 @@ func FirstRPC() {
 	}
 }
 
+// consensusBranchID returns the ID of the consensus branch that the node
+// reports for its chain tip, the one clients build transactions for.
+func consensusBranchID(info *ZcashdRpcReplyGetblockchaininfo) string {
+	return info.Consensus.Chaintip
+}
+
 func GetLightdInfo() (*walletrpc.LightdInfo, error) {
 	result, rpcErr := RawRequest("getinfo", []json.RawMessage{})
 	if rpcErr != nil {
@@ -212,7 +361,7 @@ func GetLightdInfo() (*walletrpc.LightdInfo, error) {
 		TaddrSupport:            true,
 		ChainName:               getblockchaininfoReply.Name,
 		SaplingActivationHeight: uint64(saplingHeight),
-		ConsensusBranchId:       getblockchaininfoReply.Consensus.Chaintip,
+		ConsensusBranchId:       consensusBranchID(&getblockchaininfoReply),
 		BlockHeight:             uint64(getblockchaininfoReply.Blocks),
 		GitCommit:               GitCommit,
 		Branch:                  Branch,
@@ -221,10 +370,19 @@ func GetLightdInfo() (*walletrpc.LightdInfo, error) {
 		EstimatedHeight:         uint64(getblockchaininfoReply.EstimatedHeight),
 		ZcashdBuild:             getinfoReply.Build,
 		ZcashdSubversion:        getinfoReply.Subversion,
+		FinalizedHeight:         GetFinalizedHeight(),
 	}, nil
 }
 
 func getBlockFromRPC(height int) (*walletrpc.CompactBlock, error) {
+	block, _, err := getBlockAndReaderFromRPC(height)
+	return block, err
+}
+
+// getBlockReaderFromRPC requests the block at the given height from zcashd,
+// returning a reader to parse it with, or nil if zcashd doesn't have it yet.
+// The reader must be closed when it's no longer needed.
+func getBlockReaderFromRPC(height int) (*parser.BlockReader, error) {
 	params := make([]json.RawMessage, 2)
 	heightJSON, err := json.Marshal(strconv.Itoa(height))
 	if err != nil {
@@ -232,42 +390,233 @@ func getBlockFromRPC(height int) (*walletrpc.CompactBlock, error) {
 	}
 	params[0] = heightJSON
 	params[1] = json.RawMessage("0") // non-verbose (raw hex)
-	result, rpcErr := RawRequest("getblock", params)
+
+	var blockHex io.Reader
+	var body io.Closer
+	var rpcErr error
+	if StreamRequest != nil {
+		var reply io.ReadCloser
+		if reply, rpcErr = StreamRequest("getblock", params); rpcErr == nil {
+			if blockHex, rpcErr = resultStringReader(reply); rpcErr != nil {
+				reply.Close()
+			}
+			body = reply
+		}
+	} else {
+		var result json.RawMessage
+		if result, rpcErr = RawRequest("getblock", params); rpcErr == nil {
+			// The result is a JSON string of hex digits (which need no escaping).
+			if len(result) < 2 || result[0] != '"' || result[len(result)-1] != '"' {
+				return nil, errors.New("error reading JSON response")
+			}
+			blockHex = bytes.NewReader(result[1 : len(result)-1])
+		}
+	}
 
 	// For some reason, the error responses are not JSON
 	if rpcErr != nil {
 		// Check to see if we are requesting a height the zcashd doesn't have yet
-		if (strings.Split(rpcErr.Error(), ":"))[0] == "-8" {
+		if code, _, _ := ParseRPCError(rpcErr); code == rpcInvalidParameter {
 			return nil, nil
 		}
 		return nil, errors.Wrap(rpcErr, "error requesting block")
 	}
 
-	var blockDataHex string
-	err = json.Unmarshal(result, &blockDataHex)
-	if err != nil {
-		return nil, errors.Wrap(err, "error reading JSON response")
+	// Decode and parse the block as it arrives, rather than making a copy
+	// of the hex string and then of the decoded block.
+	var blockData io.Reader = hex.NewDecoder(blockHex)
+	if body != nil {
+		blockData = struct {
+			io.Reader
+			io.Closer
+		}{blockData, body}
 	}
+	return parser.NewBlockReader(blockData), nil
+}
 
-	blockData, err := hex.DecodeString(blockDataHex)
-	if err != nil {
-		return nil, errors.Wrap(err, "error decoding getblock output")
+// resultStringReader reads a JSON-RPC reply whose result is a string that
+// needs no escaping (such as hex), returning a reader of the string's
+// contents that reads the reply only as far as it's needed. If the reply is
+// an error, that's returned instead.
+func resultStringReader(reply io.Reader) (io.Reader, error) {
+	r := bufio.NewReader(reply)
+	dec := json.NewDecoder(r)
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, errors.New("error reading JSON response")
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading JSON response")
+		}
+		if key != "result" {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, errors.Wrap(err, "error reading JSON response")
+			}
+			var rpcErr *btcjson.RPCError
+			if key == "error" && json.Unmarshal(value, &rpcErr) == nil && rpcErr != nil {
+				return nil, rpcErr
+			}
+			continue
+		}
+		// The decoder has read ahead; continue from where it stopped.
+		rest := bufio.NewReader(io.MultiReader(dec.Buffered(), r))
+		if err := skipJSON(rest, ':'); err != nil {
+			return nil, err
+		}
+		if err := skipJSON(rest, '"'); err != nil {
+			// Not a string: a null result, with the error after it.
+			remainder, readErr := io.ReadAll(rest)
+			if readErr != nil {
+				return nil, errors.Wrap(readErr, "error reading JSON response")
+			}
+			var reply struct {
+				Error *btcjson.RPCError `json:"error"`
+			}
+			if json.Unmarshal(append([]byte(`{"result":`), remainder...), &reply) == nil && reply.Error != nil {
+				return nil, reply.Error
+			}
+			return nil, err
+		}
+		return &jsonStringReader{r: rest}, nil
+	}
+	return nil, errors.New("error reading JSON response: no result")
+}
+
+// skipJSON reads past whitespace and then the given character, which must
+// be next (if it isn't, it's left unread).
+func skipJSON(r *bufio.Reader, c byte) error {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return errors.Wrap(err, "error reading JSON response")
+		}
+		switch b {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case c:
+			return nil
+		}
+		r.UnreadByte()
+		return errors.New("error reading JSON response: unexpected " + strconv.QuoteRune(rune(b)))
+	}
+}
+
+// jsonStringReader reads the contents of a JSON string (whose opening quote
+// has been read) as far as its closing quote. Escapes aren't supported.
+type jsonStringReader struct {
+	r    io.Reader
+	done bool
+}
+
+func (s *jsonStringReader) Read(p []byte) (int, error) {
+	if s.done {
+		return 0, io.EOF
+	}
+	n, err := s.r.Read(p)
+	if i := bytes.IndexAny(p[:n], `"\`); i >= 0 {
+		if p[i] == '\\' {
+			return i, errors.New("error reading JSON response: unexpected escape")
+		}
+		// Anything after the string isn't needed.
+		s.done = true
+		return i, nil
 	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
 
-	block := parser.NewBlock()
-	rest, err := block.ParseFromSlice(blockData)
+// getBlockAndReaderFromRPC is getBlockFromRPC, also returning the reader
+// that parsed the block, which has its header and the fees and transparent
+// parts of its transactions.
+func getBlockAndReaderFromRPC(height int) (*walletrpc.CompactBlock, *parser.BlockReader, error) {
+	reader, err := getBlockReaderFromRPC(height)
+	if reader == nil || err != nil {
+		return nil, nil, err
+	}
+	compactBlock, err := reader.ReadCompact()
+	reader.Close()
 	if err != nil {
-		return nil, errors.Wrap(err, "error parsing block")
+		return nil, nil, errors.Wrap(err, "error parsing block")
 	}
-	if len(rest) != 0 {
-		return nil, errors.New("received overlong message")
+
+	if int(compactBlock.Height) != height {
+		return nil, nil, errors.New("received unexpected height block")
 	}
+	return compactBlock, reader, nil
+}
 
-	if block.GetHeight() != height {
-		return nil, errors.New("received unexpected height block")
+// GetTreeStateFromRPC returns the note commitment tree state from zcashd's
+// z_gettreestate for the block given by height or hash (as a JSON string).
+// If the tree didn't change in that block, the reply is for the block that
+// last changed it. The Network isn't set.
+func GetTreeStateFromRPC(id json.RawMessage) (*walletrpc.TreeState, error) {
+	params := []json.RawMessage{id}
+	var gettreestateReply ZcashdRpcReplyGettreestate
+	for {
+		result, rpcErr := RawRequest("z_gettreestate", params)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		err := json.Unmarshal(result, &gettreestateReply)
+		if err != nil {
+			return nil, err
+		}
+		if gettreestateReply.Sapling.Commitments.FinalState != "" {
+			break
+		}
+		if gettreestateReply.Sapling.SkipHash == "" {
+			break
+		}
+		hashJSON, err := json.Marshal(gettreestateReply.Sapling.SkipHash)
+		if err != nil {
+			return nil, err
+		}
+		params[0] = hashJSON
+	}
+	if gettreestateReply.Sapling.Commitments.FinalState == "" {
+		return nil, errors.New("zcashd did not return treestate")
 	}
+	return &walletrpc.TreeState{
+		Height: uint64(gettreestateReply.Height),
+		Hash:   gettreestateReply.Hash,
+		Time:   gettreestateReply.Time,
+		Tree:   gettreestateReply.Sapling.Commitments.FinalState,
+	}, nil
+}
 
-	return block.ToCompact(), nil
+// bootstrapSaplingTree starts the cache maintaining the Sapling tree, using
+// zcashd's tree state after the most recent cached block.
+func bootstrapSaplingTree(c *BlockCache) {
+	height := c.GetLatestHeight()
+	heightJSON, err := json.Marshal(strconv.Itoa(height))
+	if err != nil {
+		Log.Fatal("bootstrapSaplingTree bad height argument", height, err)
+	}
+	treeState, err := GetTreeStateFromRPC(heightJSON)
+	if err == nil {
+		var data []byte
+		data, err = hex.DecodeString(treeState.Tree)
+		tree := sapling.NewTree()
+		if err == nil {
+			err = tree.UnmarshalBinary(data)
+		}
+		if err == nil {
+			err = c.SetSaplingTree(height, tree)
+		}
+	}
+	if err != nil {
+		Log.Warning("Unable to get the sapling tree at height ", height,
+			", tree states will be requested from zcashd: ", err)
+		return
+	}
+	Log.Info("Maintaining the sapling tree from height ", height)
+	if !c.SubtreeRootsComplete() {
+		Log.Warning("Sapling subtree roots aren't known, run with --redownload to serve them")
+	}
 }
 
 var (
@@ -293,6 +642,8 @@ func stopIngestor() {
 func BlockIngestor(c *BlockCache, rep int) {
 	lastLog := Time.Now()
 	lastHeightLogged := 0
+	// Only try once to get a missing Sapling tree from zcashd.
+	treeBootstrapped := false
 
 	// Start listening for new blocks
 	for i := 0; rep == 0 || i < rep; i++ {
@@ -320,6 +671,21 @@ func BlockIngestor(c *BlockCache, rep int) {
 			Log.Fatal("error decoding getbestblockhash", err, hashHex)
 		}
 
+		// The address index must catch up before more blocks are added.
+		if next := c.addressIndexNext(); next >= 0 && next < c.GetNextHeight() {
+			if err = c.catchUpAddressIndex(addrIndexBatch); err != nil {
+				Log.Fatal("Address index catch-up failed, will retry: ", err)
+			}
+			continue
+		}
+		// So must the block filters.
+		if next := c.filterNextHeight(); next >= 0 && next < c.GetNextHeight() {
+			if err = c.catchUpFilters(filterBatch); err != nil {
+				Log.Fatal("Block filter catch-up failed, will retry: ", err)
+			}
+			continue
+		}
+
 		height := c.GetNextHeight()
 		if string(lastBestBlockHash) == string(parser.Reverse(c.GetLatestHash())) {
 			// Synced
@@ -332,15 +698,40 @@ func BlockIngestor(c *BlockCache, rep int) {
 			lastLog = Time.Now()
 			continue
 		}
+		if !treeBootstrapped && !c.HasSaplingTree() {
+			treeBootstrapped = true
+			bootstrapSaplingTree(c)
+		}
 		var block *walletrpc.CompactBlock
-		block, err = getBlockFromRPC(height)
+		var reader *parser.BlockReader
+		block, reader, err = getBlockAndReaderFromRPC(height)
 		if err != nil {
 			Log.Fatal("getblock ", height, " failed, will retry: ", err)
 		}
 		if block != nil && c.HashMatch(block.PrevHash) {
+			// Indexed first, so that the index is never behind the cache.
+			if c.addressIndexNext() == height {
+				if err = c.indexAddresses(height, reader.Transparent()); err != nil {
+					Log.Fatal("Address index add failed:", err)
+				}
+			}
+			transparent := compactTransparent(reader.Transparent())
+			if c.transparentDataEnabled() {
+				if err = c.addTransparent(height, block.Hash, transparent); err != nil {
+					Log.Fatal("Cache transparent data add failed:", err)
+				}
+			}
+			if c.filterNextHeight() == height {
+				if err = c.addFilter(height, block.Hash, transparent); err != nil {
+					Log.Fatal("Cache block filter add failed:", err)
+				}
+			}
 			if err = c.Add(height, block); err != nil {
 				Log.Fatal("Cache add failed:", err)
 			}
+			observeBlockFees(height, reader.Fees())
+			hdr, _ := reader.Header()
+			c.CheckSaplingRoot(height, hdr.HashFinalSaplingRoot)
 			// Don't log these too often.
 			if DarksideEnabled || Time.Now().Sub(lastLog).Seconds() >= 4 {
 				lastLog = Time.Now()
@@ -377,13 +768,114 @@ func GetBlock(cache *BlockCache, height int) (*walletrpc.CompactBlock, error) {
 	}
 	if block == nil {
 		// Block height is too large
-		return nil, errors.New("block requested is newer than latest block")
+		return nil, OutOfRangeError("height", "block requested is newer than latest block")
 	}
 	return block, nil
 }
 
-// GetBlockRange returns a sequence of consecutive blocks in the given range.
-func GetBlockRange(cache *BlockCache, blockOut chan<- *walletrpc.CompactBlock, errOut chan<- error, start, end int) {
+// compactTransparent returns the compact representations of the transparent
+// parts of a block's transactions.
+func compactTransparent(txs []parser.TransparentTx) []*walletrpc.CompactTx {
+	vtx := make([]*walletrpc.CompactTx, len(txs))
+	for i, tx := range txs {
+		vtx[i] = tx.ToCompact()
+	}
+	return vtx
+}
+
+// addTransparentTxs adds the transparent parts of the block's transactions,
+// given in the same (block) order as its transactions, to the compact block;
+// transactions that are only transparent are inserted.
+func addTransparentTxs(block *walletrpc.CompactBlock, transparent []*walletrpc.CompactTx) {
+	vtx := make([]*walletrpc.CompactTx, 0, len(block.Vtx)+len(transparent))
+	i := 0
+	for _, ttx := range transparent {
+		for i < len(block.Vtx) && block.Vtx[i].Index < ttx.Index {
+			vtx = append(vtx, block.Vtx[i])
+			i++
+		}
+		if i < len(block.Vtx) && block.Vtx[i].Index == ttx.Index {
+			block.Vtx[i].Vin = ttx.Vin
+			block.Vtx[i].Vout = ttx.Vout
+			continue
+		}
+		vtx = append(vtx, ttx)
+	}
+	block.Vtx = append(vtx, block.Vtx[i:]...)
+}
+
+// getTransparentBlock is GetBlock, with the transparent parts of the
+// transactions. With EnableTransparentData() they're stored alongside cached
+// blocks (except those cached before it was enabled); otherwise the block is
+// requested from verusd.
+func getTransparentBlock(cache *BlockCache, height int) (*walletrpc.CompactBlock, error) {
+	block := cache.Get(height)
+	if block != nil {
+		if transparent := cache.getTransparent(height, block.Hash); transparent != nil {
+			addTransparentTxs(block, transparent)
+			return block, nil
+		}
+	}
+	block, reader, err := getBlockAndReaderFromRPC(height)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		// Block height is too large
+		return nil, OutOfRangeError("height", "block requested is newer than latest block")
+	}
+	addTransparentTxs(block, compactTransparent(reader.Transparent()))
+	return block, nil
+}
+
+// BlockRangeOptions are what a GetBlockRange request asks for besides the
+// range itself.
+type BlockRangeOptions struct {
+	Transparent bool                   // include transparent inputs and outputs, and transparent-only transactions
+	Content     walletrpc.BlockContent // which parts of the transactions to send
+	Exclude     [][]byte               // txid prefixes of transactions to leave out
+}
+
+// filterBlock removes what the options don't ask for from the block's
+// transactions, and the transactions that are left with nothing.
+func filterBlock(block *walletrpc.CompactBlock, opts BlockRangeOptions) {
+	if opts.Content == walletrpc.BlockContent_metadataOnly {
+		block.Vtx = nil
+		return
+	}
+	if opts.Content == walletrpc.BlockContent_fullBlocks && len(opts.Exclude) == 0 {
+		return
+	}
+	vtx := block.Vtx[:0]
+	for _, tx := range block.Vtx {
+		if isExcluded(tx.Hash, opts.Exclude) {
+			continue
+		}
+		switch opts.Content {
+		case walletrpc.BlockContent_spendsOnly:
+			tx.Outputs, tx.Vout = nil, nil
+		case walletrpc.BlockContent_outputsOnly:
+			tx.Spends, tx.Vin = nil, nil
+		}
+		if len(tx.Spends) == 0 && len(tx.Outputs) == 0 && len(tx.Vin) == 0 && len(tx.Vout) == 0 {
+			continue
+		}
+		vtx = append(vtx, tx)
+	}
+	block.Vtx = vtx
+}
+
+// GetBlockRange returns a sequence of consecutive blocks in the given range,
+// with what the options ask for.
+func GetBlockRange(cache *BlockCache, blockOut chan<- *walletrpc.CompactBlock, errOut chan<- error, start, end int, opts BlockRangeOptions) {
+	if _, ok := walletrpc.BlockContent_name[int32(opts.Content)]; !ok {
+		errOut <- InvalidArgumentError("content", "unknown block content")
+		return
+	}
+	if err := checkExclude("exclude", opts.Exclude); err != nil {
+		errOut <- err
+		return
+	}
 	// Go over [start, end] inclusive
 	low := start
 	high := end
@@ -397,11 +889,18 @@ func GetBlockRange(cache *BlockCache, blockOut chan<- *walletrpc.CompactBlock, e
 			// reverse the order
 			j = high - (i - low)
 		}
-		block, err := GetBlock(cache, j)
+		var block *walletrpc.CompactBlock
+		var err error
+		if opts.Transparent {
+			block, err = getTransparentBlock(cache, j)
+		} else {
+			block, err = GetBlock(cache, j)
+		}
 		if err != nil {
 			errOut <- err
 			return
 		}
+		filterBlock(block, opts)
 		blockOut <- block
 	}
 	errOut <- nil
@@ -6,17 +6,30 @@ package common
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/btcsuite/btcd/btcjson"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ------------------------------------------ Setup
@@ -62,7 +75,7 @@ func TestMain(m *testing.M) {
 		blockJSON, _ := json.Marshal(scan.Text())
 		blocks = append(blocks, blockJSON)
 	}
-	testcache = NewBlockCache(unitTestPath, unitTestChain, 380640, true)
+	testcache = NewBlockCache(testDB(unitTestPath), unitTestChain, 380640, true)
 
 	// Setup is done; run all tests.
 	exitcode := m.Run()
@@ -107,6 +120,7 @@ func getLightdInfoStub(method string, params []json.RawMessage) (json.RawMessage
 		}
 		r, _ := json.Marshal(&ZcashdRpcReplyGetblockchaininfo{
 			Blocks:    9977,
+			Name:      "bugsbunny", // verusd's chain name; "chain" is main or test
 			Chain:     "bugsbunny",
 			Consensus: ConsensusInfo{Chaintip: "someid"},
 		})
@@ -355,7 +369,7 @@ func TestBlockIngestor(t *testing.T) {
 	Time.Sleep = sleepStub
 	Time.Now = nowStub
 	os.RemoveAll(unitTestPath)
-	testcache = NewBlockCache(unitTestPath, unitTestChain, 380640, false)
+	testcache = NewBlockCache(testDB(unitTestPath), unitTestChain, 380640, false)
 	BlockIngestor(testcache, 11)
 	if step != 19 {
 		t.Error("unexpected final step", step)
@@ -488,10 +502,10 @@ func TestGetBlockRange(t *testing.T) {
 	testT = t
 	RawRequest = getblockStub
 	os.RemoveAll(unitTestPath)
-	testcache = NewBlockCache(unitTestPath, unitTestChain, 380640, true)
+	testcache = NewBlockCache(testDB(unitTestPath), unitTestChain, 380640, true)
 	blockChan := make(chan *walletrpc.CompactBlock)
 	errChan := make(chan error)
-	go GetBlockRange(testcache, blockChan, errChan, 380640, 380642)
+	go GetBlockRange(testcache, blockChan, errChan, 380640, 380642, BlockRangeOptions{})
 
 	// read in block 380640
 	select {
@@ -519,7 +533,7 @@ func TestGetBlockRange(t *testing.T) {
 	select {
 	case err := <-errChan:
 		// this will also catch context.DeadlineExceeded from the timeout
-		if err.Error() != "block requested is newer than latest block" {
+		if status.Code(err) != codes.OutOfRange || status.Convert(err).Message() != "block requested is newer than latest block" {
 			t.Fatal("unexpected error:", err)
 		}
 	case _ = <-blockChan:
@@ -567,12 +581,12 @@ func TestGetBlockRangeReverse(t *testing.T) {
 	testT = t
 	RawRequest = getblockStubReverse
 	os.RemoveAll(unitTestPath)
-	testcache = NewBlockCache(unitTestPath, unitTestChain, 380640, true)
+	testcache = NewBlockCache(testDB(unitTestPath), unitTestChain, 380640, true)
 	blockChan := make(chan *walletrpc.CompactBlock)
 	errChan := make(chan error)
 
 	// Request the blocks in reverse order by specifying start greater than end
-	go GetBlockRange(testcache, blockChan, errChan, 380642, 380640)
+	go GetBlockRange(testcache, blockChan, errChan, 380642, 380640, BlockRangeOptions{})
 
 	// read in block 380642
 	select {
@@ -624,8 +638,8 @@ func TestGenerateCerts(t *testing.T) {
 func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, error) {
 	step++
 	switch step {
-	case 1:
-		// This will be a getblockchaininfo request
+	case 1, 5:
+		// No new block has arrived (the first time, the tip is new).
 		if method != "getblockchaininfo" {
 			testT.Fatal("expecting blockchaininfo")
 		}
@@ -635,26 +649,16 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 		})
 		return r, nil
 	case 2:
-		// No new block has arrived.
-		if method != "getblockchaininfo" {
-			testT.Fatal("expecting blockchaininfo")
-		}
-		r, _ := json.Marshal(&ZcashdRpcReplyGetblockchaininfo{
-			BestBlockHash: "010203",
-			Blocks:        200,
-		})
-		return r, nil
-	case 3:
-		// Expect a getrawmempool next.
-		if method != "getrawmempool" {
+		// Expect a (verbose) getrawmempool next.
+		if method != "getrawmempool" || string(params[0]) != "true" {
 			testT.Fatal("expecting getrawmempool")
 		}
 		// In reality, this would be a hex txid
-		r, _ := json.Marshal([]string{
-			"mempooltxid-1",
+		r, _ := json.Marshal(map[string]ZcashdRpcReplyGetrawmempoolEntry{
+			"mempooltxid-1": {Size: 2, Time: 1},
 		})
 		return r, nil
-	case 4:
+	case 3:
 		// Next, it should ask for this transaction (non-verbose).
 		if method != "getrawtransaction" {
 			testT.Fatal("expecting getrawtransaction")
@@ -666,25 +670,19 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 		}
 		r, _ := json.Marshal("aabb")
 		return r, nil
-	case 5:
-		// Simulate that still no new block has arrived ...
-		if method != "getblockchaininfo" {
-			testT.Fatal("expecting blockchaininfo")
-		}
-		r, _ := json.Marshal(&ZcashdRpcReplyGetblockchaininfo{
-			BestBlockHash: "010203",
-			Blocks:        200,
-		})
-		return r, nil
+	case 4:
+		// ... but then zcashd can't be reached ...
+		return nil, errors.New("connection refused")
 	case 6:
-		// ... but there a second tx has arrived in the mempool
+		// ... and there a second tx has arrived in the mempool
 		if method != "getrawmempool" {
 			testT.Fatal("expecting getrawmempool")
 		}
 		// In reality, this would be a hex txid
-		r, _ := json.Marshal([]string{
-			"mempooltxid-2",
-			"mempooltxid-1"})
+		r, _ := json.Marshal(map[string]ZcashdRpcReplyGetrawmempoolEntry{
+			"mempooltxid-2": {Size: 2, Time: 2},
+			"mempooltxid-1": {Size: 2, Time: 1},
+		})
 		return r, nil
 	case 7:
 		// The new mempool tx (and only that one) gets fetched
@@ -699,7 +697,7 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 		r, _ := json.Marshal("ccdd")
 		return r, nil
 	case 8:
-		// A new block arrives, this will cause these two tx to be returned
+		// A new block arrives, which mines the first tx
 		if method != "getblockchaininfo" {
 			testT.Fatal("expecting blockchaininfo")
 		}
@@ -708,65 +706,1420 @@ func mempoolStub(method string, params []json.RawMessage) (json.RawMessage, erro
 			Blocks:        201,
 		})
 		return r, nil
+	case 9:
+		if method != "getrawmempool" {
+			testT.Fatal("expecting getrawmempool")
+		}
+		r, _ := json.Marshal(map[string]ZcashdRpcReplyGetrawmempoolEntry{
+			"mempooltxid-2": {Size: 2, Time: 2},
+		})
+		return r, nil
+	case 10:
+		// The first tx is gone, so the new block is checked for it.
+		if method != "getblock" || string(params[0]) != `"201"` {
+			testT.Fatal("expecting getblock 201")
+		}
+		r, _ := json.Marshal(testBlockHex())
+		return r, nil
 	}
 	testT.Fatal("ran out of cases")
 	return nil, nil
 }
 
+// resetMempool forgets the mempool, as at startup.
+func resetMempool() {
+	mempool.mutex.Lock()
+	defer mempool.mutex.Unlock()
+	mempool.txs = nil
+	mempool.byTxid = nil
+	mempool.blockChainInfo = ZcashdRpcReplyGetblockchaininfo{}
+}
+
 func TestMempoolStream(t *testing.T) {
 	testT = t
 	RawRequest = mempoolStub
 	Time.Sleep = sleepStub
-	Time.Now = nowStub
-	// In real life, wall time is not close to zero, simulate that.
-	sleepDuration = 1000 * time.Second
+	resetMempool()
 
+	// The tracker finds the first tx.
+	MempoolTracker(1)
+	txs, sub := SubscribeMempool()
+	if len(txs) != 1 || txs[0].Txid != "mempooltxid-1" ||
+		!bytes.Equal(txs[0].Tx.Data, []byte{0xaa, 0xbb}) || txs[0].Tx.Height != 200 {
+		t.Fatal("unexpected mempool snapshot", txs)
+	}
+
+	// A client streaming the mempool gets the existing tx, then new ones
+	// until a new block arrives.
 	var replies []*walletrpc.RawTransaction
-	// The first request after startup immediately returns an empty list.
-	err := GetMempool(func(tx *walletrpc.RawTransaction) error {
-		t.Fatal("send to client function called on initial GetMempool call")
+	streamDone := make(chan error)
+	go func() {
+		streamDone <- GetMempool(nil, func(tx *walletrpc.RawTransaction) error {
+			replies = append(replies, tx)
+			return nil
+		})
+	}()
+	for {
+		mempool.subscribers.mutex.Lock()
+		n := len(mempool.subscribers.subscribers)
+		mempool.subscribers.mutex.Unlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// A failure doesn't change the mempool; then the second tx arrives,
+	// then a block.
+	MempoolTracker(3)
+	if err := <-streamDone; err != nil {
+		t.Fatal("GetMempool failed", err)
+	}
+	// The interface guarantees that the transactions will be returned
+	// in the order they entered the mempool.
+	if len(replies) != 2 ||
+		!bytes.Equal(replies[0].Data, []byte{0xaa, 0xbb}) || replies[0].Height != 200 ||
+		!bytes.Equal(replies[1].Data, []byte{0xcc, 0xdd}) || replies[1].Height != 200 {
+		t.Fatal("unexpected mempool stream", replies)
+	}
+
+	// The subscriber sees the changes, including the mined tx's removal.
+	sub.Close()
+	var events []*MempoolEvent
+	for event := range sub.C {
+		events = append(events, event)
+	}
+	if len(events) != 3 ||
+		events[0].Type != MempoolAdd || events[0].Txid != "mempooltxid-2" ||
+		events[1].Type != MempoolNewBlock || events[1].Height != 201 ||
+		events[2].Type != MempoolRemove || events[2].Txid != "mempooltxid-1" ||
+		events[2].Reason != walletrpc.MempoolTxEvent_evicted {
+		t.Fatal("unexpected mempool events", events)
+	}
+	if txs, _ := SubscribeMempool(); len(txs) != 1 || txs[0].Txid != "mempooltxid-2" {
+		t.Fatal("unexpected mempool after new block", txs)
+	}
+
+	// One poll per 2 seconds, whatever the number of clients.
+	if step != 10 || sleepCount != 4 || sleepDuration != 8*time.Second {
+		t.Fatal("unexpected number of zcashd RPCs or sleeps", step, sleepCount)
+	}
+
+	resetMempool()
+	step = 0
+	sleepCount = 0
+	sleepDuration = 0
+}
+
+// ------------------------------------------ GetIdentity(), GetIdentityHistory()
+
+const testIdentityJSON = `{
+	"version": 3, "flags": 0, "primaryaddresses": ["RLXCv2dQPB4NPqKUR5AchN2uoyodcCpbzR"],
+	"minimumsignatures": 1, "name": "alice", "identityaddress": "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq",
+	"parent": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV", "systemid": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+	"contentmap": {"aa": "bb"}, "revocationauthority": "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq",
+	"recoveryauthority": "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq", "timelock": 0}`
+
+func identityStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	switch method {
+	case "getidentity":
+		if string(params[0]) != `"alice@"` {
+			testT.Fatal("unexpected identity param", string(params[0]))
+		}
+		if len(params) != 1 {
+			testT.Fatal("unexpected getidentity params", len(params))
+		}
+		return json.RawMessage(`{"identity": ` + testIdentityJSON + `,
+			"friendlyname": "alice.VRSC@", "fullyqualifiedname": "alice.VRSC@",
+			"status": "active", "canspendfor": false, "cansignfor": false,
+			"blockheight": 380700, "txid": "0102", "vout": 1}`), nil
+	case "getidentityhistory":
+		if len(params) != 3 || string(params[1]) != "380000" || string(params[2]) != "0" {
+			testT.Fatal("unexpected getidentityhistory params")
+		}
+		return json.RawMessage(`{"fullyqualifiedname": "alice.VRSC@", "history": [
+			{"identity": ` + testIdentityJSON + `, "blockhash": "0a0b", "height": 380600,
+			 "output": {"txid": "0304", "voutnum": 0}},
+			{"identity": ` + testIdentityJSON + `, "blockhash": "0c0d", "height": 380700,
+			 "output": {"txid": "0102", "voutnum": 1}}]}`), nil
+	}
+	testT.Fatal("unexpected method", method)
+	return nil, nil
+}
+
+func TestGetIdentity(t *testing.T) {
+	testT = t
+	RawRequest = identityStub
+	step = 0
+	testcache.Reset(380640)
+
+	if _, err := GetIdentity(testcache, "", 0); err == nil {
+		t.Fatal("GetIdentity with no identity should fail")
+	}
+	for i := 0; i < 2; i++ {
+		info, err := GetIdentity(testcache, "alice@", 0)
+		if err != nil {
+			t.Fatal("GetIdentity failed", err)
+		}
+		if info.FriendlyName != "alice.VRSC@" || info.BlockHeight != 380700 || info.Vout != 1 {
+			t.Fatal("unexpected identity info", info)
+		}
+		if !bytes.Equal(info.Txid, []byte{2, 1}) {
+			t.Fatal("unexpected identity txid", info.Txid)
+		}
+		id := info.Identity
+		if id.Name != "alice" || id.Version != 3 || id.MinimumSignatures != 1 ||
+			len(id.PrimaryAddresses) != 1 || id.ContentMap["aa"] != "bb" ||
+			id.SystemID != "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV" {
+			t.Fatal("unexpected identity", id)
+		}
+	}
+	// The second request was served from the reply cache.
+	if step != 1 {
+		t.Fatal("unexpected number of verusd RPCs", step)
+	}
+	// A new block empties the reply cache.
+	if err := testcache.Add(380640, &walletrpc.CompactBlock{Height: 380640, Hash: []byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetIdentity(testcache, "alice@", 0); err != nil {
+		t.Fatal("GetIdentity failed", err)
+	}
+	if step != 2 {
+		t.Fatal("unexpected number of verusd RPCs", step)
+	}
+
+	var updates []*walletrpc.IdentityUpdate
+	err := GetIdentityHistory(testcache, "alice@", 380000, 0, func(update *walletrpc.IdentityUpdate) error {
+		updates = append(updates, update)
 		return nil
 	})
 	if err != nil {
-		t.Fatal("GetMempool failed")
+		t.Fatal("GetIdentityHistory failed", err)
+	}
+	if len(updates) != 2 || updates[0].Height != 380600 || updates[1].Vout != 1 {
+		t.Fatal("unexpected identity history", updates)
 	}
+	if !bytes.Equal(updates[0].BlockHash, []byte{0xb, 0xa}) || !bytes.Equal(updates[1].Txid, []byte{2, 1}) {
+		t.Fatal("unexpected identity history hashes")
+	}
+	if GetIdentityHistory(testcache, "alice@", 380000, 1, nil) == nil {
+		t.Fatal("GetIdentityHistory with end before start should fail")
+	}
+	step = 0
+}
 
-	// This should return two transactions.
-	err = GetMempool(func(tx *walletrpc.RawTransaction) error {
-		replies = append(replies, tx)
-		return nil
+func TestParseAmount(t *testing.T) {
+	for _, tt := range []struct {
+		amount   json.Number
+		satoshis int64
+	}{
+		{"0", 0},
+		{"1.5", 150000000},
+		{"0.00000001", 1},
+		{"-2.00000000", -200000000},
+		{"1e-8", 1},
+		{"92233720368.54775807", 9223372036854775807},
+	} {
+		satoshis, err := ParseAmount(tt.amount)
+		if err != nil || satoshis != tt.satoshis {
+			t.Fatal("ParseAmount", tt.amount, satoshis, err)
+		}
+		if s, _ := ParseAmount(FormatAmount(tt.satoshis)); s != tt.satoshis {
+			t.Fatal("FormatAmount", tt.satoshis, FormatAmount(tt.satoshis))
+		}
+	}
+	for _, bad := range []json.Number{"", "abc", "0.000000001", "92233720368.54775808"} {
+		if _, err := ParseAmount(bad); err == nil {
+			t.Fatal("ParseAmount should fail", bad)
+		}
+	}
+	if FormatAmount(-150000001) != "-1.50000001" {
+		t.Fatal("unexpected FormatAmount", FormatAmount(-150000001))
+	}
+}
+
+// ------------------------------------------ GetCurrency(), EstimateConversion()
+
+func currencyStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	switch method {
+	case "getcurrency":
+		if string(params[0]) != `"bridge.veth"` {
+			testT.Fatal("unexpected currency param", string(params[0]))
+		}
+		return json.RawMessage(`{"version": 1, "options": 33, "name": "Bridge.vETH",
+			"currencyid": "i3f7tSctFkiPpiedY8QR5Tep9p4qDVebDx", "parent": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+			"systemid": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV", "startblock": 2470000,
+			"currencies": ["i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV"], "weights": [0.25],
+			"definitiontxid": "0a0b", "bestheight": 2500000, "fullyqualifiedname": "Bridge.vETH",
+			"bestcurrencystate": {"supply": 1234.56789012, "reservecurrencies": [
+				{"currencyid": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV", "weight": 0.25,
+				 "reserves": 300.00000000, "priceinreserve": 0.97}]}}`), nil
+	case "estimateconversion":
+		var req ZcashdRpcRequestEstimateconversion
+		if err := json.Unmarshal(params[0], &req); err != nil {
+			testT.Fatal("could not unmarshal estimateconversion request")
+		}
+		if req.Currency != "vrsc" || req.ConvertTo != "bridge.veth" || req.Amount != "1.50000000" {
+			testT.Fatal("unexpected estimateconversion request", req)
+		}
+		return json.RawMessage(`{"inputcurrencyid": "i5w5MuNik5NtLcYmNzcvaoixooEebB6MGV",
+			"netinputamount": 1.49962500, "outputcurrencyid": "i3f7tSctFkiPpiedY8QR5Tep9p4qDVebDx",
+			"estimatedcurrencyout": 1.54600515}`), nil
+	}
+	testT.Fatal("unexpected method", method)
+	return nil, nil
+}
+
+func TestGetCurrency(t *testing.T) {
+	testT = t
+	RawRequest = currencyStub
+	step = 0
+
+	for _, bad := range []string{"", "bad\nname", strings.Repeat("x", 256)} {
+		if _, err := GetCurrency(testcache, bad); err == nil {
+			t.Fatal("GetCurrency should fail", bad)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		c, err := GetCurrency(testcache, "bridge.veth")
+		if err != nil {
+			t.Fatal("GetCurrency failed", err)
+		}
+		if c.CurrencyID != "i3f7tSctFkiPpiedY8QR5Tep9p4qDVebDx" || c.Options != 33 ||
+			c.StartBlock != 2470000 || c.BestHeight != 2500000 || c.Supply != 123456789012 {
+			t.Fatal("unexpected currency", c)
+		}
+		if !bytes.Equal(c.DefinitionTxid, []byte{0xb, 0xa}) || len(c.ReserveCurrencies) != 1 {
+			t.Fatal("unexpected currency", c)
+		}
+		r := c.ReserveCurrencies[0]
+		if r.Weight != 25000000 || r.Reserves != 30000000000 || r.PriceInReserve != 97000000 {
+			t.Fatal("unexpected reserve currency", r)
+		}
+	}
+	// The second request was served from the reply cache.
+	if step != 1 {
+		t.Fatal("unexpected number of verusd RPCs", step)
+	}
+
+	for _, bad := range []*walletrpc.EstimateConversionArg{
+		{Currency: "vrsc", ConvertTo: "vrsc", Amount: 1},
+		{Currency: "vrsc", ConvertTo: "bridge.veth", Amount: 0},
+		{Currency: "vrsc", ConvertTo: ""},
+		{Currency: "vrsc", ConvertTo: "bridge.veth", Via: "\x00", Amount: 1},
+	} {
+		if _, err := EstimateConversion(testcache, bad); err == nil {
+			t.Fatal("EstimateConversion should fail", bad)
+		}
+	}
+	estimate, err := EstimateConversion(testcache, &walletrpc.EstimateConversionArg{
+		Currency: "vrsc", ConvertTo: "bridge.veth", Amount: 150000000,
 	})
 	if err != nil {
-		t.Fatal("GetMempool failed")
+		t.Fatal("EstimateConversion failed", err)
 	}
-	if len(replies) != 2 {
-		t.Fatal("unexpected number of tx")
+	if estimate.NetInputAmount != 149962500 || estimate.EstimatedCurrencyOut != 154600515 ||
+		estimate.OutputCurrencyID != "i3f7tSctFkiPpiedY8QR5Tep9p4qDVebDx" {
+		t.Fatal("unexpected conversion estimate", estimate)
 	}
-	// The interface guarantees that the transactions will be returned
-	// in the order they entered the mempool.
-	if !bytes.Equal([]byte(replies[0].GetData()), []byte{0xaa, 0xbb}) {
-		t.Fatal("unexpected tx contents")
+	step = 0
+}
+
+func TestDarksideEstimateConversion(t *testing.T) {
+	RawRequest = darksideRawRequest
+	defer func() { state = darksideState{} }()
+
+	basket := &walletrpc.Currency{
+		CurrencyID: "iBasket", Name: "basket", Supply: 1000 * satoshisPerCoin,
+		ReserveCurrencies: []*walletrpc.ReserveCurrency{
+			{CurrencyID: "iA", Weight: 50000000, Reserves: 500 * satoshisPerCoin, PriceInReserve: 2 * satoshisPerCoin},
+			{CurrencyID: "iB", Weight: 50000000, Reserves: 250 * satoshisPerCoin, PriceInReserve: satoshisPerCoin / 2},
+		},
 	}
-	if replies[0].GetHeight() != 200 {
-		t.Fatal("unexpected tx height")
+	for _, c := range []*walletrpc.Currency{basket, {CurrencyID: "iA", Name: "a"}, {CurrencyID: "iB", Name: "b"}} {
+		if err := DarksideAddCurrency(CurrencyToRPC(c)); err != nil {
+			t.Fatal(err)
+		}
 	}
-	if !bytes.Equal([]byte(replies[1].GetData()), []byte{0xcc, 0xdd}) {
-		t.Fatal("unexpected tx contents")
+	c, err := GetCurrency(testcache, "Basket")
+	if err != nil || c.Supply != basket.Supply || len(c.ReserveCurrencies) != 2 {
+		t.Fatal("unexpected darkside currency", c, err)
 	}
-	if replies[1].GetHeight() != 200 {
-		t.Fatal("unexpected tx height")
+	for _, tt := range []struct {
+		from, to, via string
+		amount, out   int64
+	}{
+		{"a", "basket", "", 4 * satoshisPerCoin, 2 * satoshisPerCoin},
+		{"basket", "b", "", 2 * satoshisPerCoin, satoshisPerCoin},
+		{"a", "b", "basket", 4 * satoshisPerCoin, satoshisPerCoin},
+	} {
+		estimate, err := EstimateConversion(testcache, &walletrpc.EstimateConversionArg{
+			Currency: tt.from, ConvertTo: tt.to, Via: tt.via, Amount: tt.amount,
+		})
+		if err != nil || estimate.EstimatedCurrencyOut != tt.out {
+			t.Fatal("unexpected darkside estimate", tt, estimate, err)
+		}
 	}
+	if _, err = EstimateConversion(testcache, &walletrpc.EstimateConversionArg{
+		Currency: "a", ConvertTo: "b", Amount: 1,
+	}); err == nil {
+		t.Fatal("EstimateConversion without a path should fail")
+	}
+}
+
+// ------------------------------------------ GetFinalityStatus()
 
-	// Time started at 1000 seconds (since 1970), and just over 4 seconds
-	// should have elapsed. The units here are nanoseconds.
-	if sleepDuration != 1004400000000 {
-		t.Fatal("unexpected end time")
+func finalityStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	switch method {
+	case "getblockchaininfo":
+		r, _ := json.Marshal(&ZcashdRpcReplyGetblockchaininfo{ChainID: "iChain"})
+		return r, nil
+	case "getnotarizationdata":
+		if string(params[0]) != `"iChain"` {
+			testT.Fatal("unexpected chain ID", string(params[0]))
+		}
+		// Notarization 2 is on a fork that isn't the best.
+		return json.RawMessage(`{"version": 1, "notarizations": [
+			{"index": 0, "txid": "01", "notarization": {"notarizationheight": 380600}},
+			{"index": 1, "txid": "02", "notarization": {"notarizationheight": 380630}},
+			{"index": 2, "txid": "03", "notarization": {"notarizationheight": 380635}}],
+			"forks": [[0, 1], [0, 2]], "lastconfirmedheight": 380600, "lastconfirmed": 0, "bestchain": 0}`), nil
 	}
-	if step != 8 {
-		t.Fatal("unexpected number of zcashd RPCs")
+	testT.Fatal("unexpected method", method)
+	return nil, nil
+}
+
+func TestGetFinalityStatus(t *testing.T) {
+	testT = t
+	RawRequest = finalityStub
+	Time.Sleep = sleepStub
+	step = 0
+	testcache.Reset(380640)
+	if _, err := GetFinalityStatus(testcache, 0); err == nil {
+		t.Fatal("GetFinalityStatus of the latest block should fail, empty cache")
+	}
+	if err := testcache.Add(380640, &walletrpc.CompactBlock{Height: 380640, Hash: []byte{2}}); err != nil {
+		t.Fatal(err)
+	}
+	FinalityPoller(testcache, 1)
+	if GetFinalizedHeight() != 380600 || !IsFinal(380600) || IsFinal(380601) {
+		t.Fatal("unexpected finalized height", GetFinalizedHeight())
+	}
+	for _, tt := range []struct {
+		height uint64
+		final  bool
+	}{
+		{380599, true},
+		{380600, true},
+		{380630, false},
+		{0, false}, // the latest block, 380640
+	} {
+		status, err := GetFinalityStatus(testcache, tt.height)
+		if err != nil {
+			t.Fatal("GetFinalityStatus failed", err)
+		}
+		if status.Final != tt.final || status.LastFinalizedHeight != 380600 ||
+			status.LastNotarizedHeight != 380630 || !bytes.Equal(status.LastNotarizationTxid, []byte{2}) {
+			t.Fatal("unexpected finality status", tt.height, status)
+		}
+		if tt.height == 0 && status.Height != 380640 {
+			t.Fatal("unexpected finality status height", status.Height)
+		}
+	}
+	// All served from the reply cache after the poller's requests.
+	if step != 2 {
+		t.Fatal("unexpected number of verusd RPCs", step)
+	}
+	step = 0
+	sleepCount = 0
+	sleepDuration = 0
+}
+
+// ------------------------------------------ GetMempoolTx()
+
+func mempoolTxStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	switch method {
+	case "getblockchaininfo":
+		r, _ := json.Marshal(&ZcashdRpcReplyGetblockchaininfo{
+			BestBlockHash: "a1a2a3",
+			Blocks:        300,
+		})
+		return r, nil
+	case "getrawmempool":
+		// verusd knows the fee of the first, which has transparent inputs.
+		r, _ := json.Marshal(map[string]ZcashdRpcReplyGetrawmempoolEntry{
+			"mempooltxid-1": {Fee: "0.0001", Time: 1},
+			"mempooltxid-2": {Time: 2},
+		})
+		return r, nil
+	case "getrawtransaction":
+		var txid string
+		json.Unmarshal(params[0], &txid)
+		if txid == "mempooltxid-2" {
+			// Not a valid transaction, so it's skipped.
+			r, _ := json.Marshal("aabb")
+			return r, nil
+		}
+		// The first ZIP 243 test vector, which has Sapling spends and outputs.
+		testData, err := ioutil.ReadFile("../testdata/zip243_raw_tx")
+		if err != nil {
+			testT.Fatal(err)
+		}
+		r, _ := json.Marshal(strings.Split(string(testData), "\n")[2])
+		return r, nil
+	}
+	testT.Fatal("unexpected method", method)
+	return nil, nil
+}
+
+func TestGetMempoolTx(t *testing.T) {
+	testT = t
+	RawRequest = mempoolTxStub
+	Time.Sleep = sleepStub
+	resetMempool()
+	MempoolTracker(1)
+
+	getMempoolTx := func(exclude ...[]byte) []*walletrpc.CompactTx {
+		t.Helper()
+		var replies []*walletrpc.CompactTx
+		err := GetMempoolTx(exclude, func(tx *walletrpc.CompactTx) error {
+			replies = append(replies, tx)
+			return nil
+		})
+		if err != nil {
+			t.Fatal("GetMempoolTx failed", err)
+		}
+		return replies
+	}
+	replies := getMempoolTx()
+	if len(replies) != 1 {
+		t.Fatal("unexpected number of tx", len(replies))
+	}
+	txid, _ := hex.DecodeString("5fc4867a1b8bd5ab709799adf322a85d10607e053726d5f5ab4b1c9ab897e6bc")
+	if !bytes.Equal(replies[0].Hash, parser.Reverse(txid)) ||
+		len(replies[0].Spends) != 3 || len(replies[0].Outputs) != 1 ||
+		len(replies[0].Outputs[0].Ciphertext) != 52 {
+		t.Fatal("unexpected compact tx", replies[0])
+	}
+	if len(getMempoolTx([]byte{0xbc, 0xe6})) != 0 {
+		t.Fatal("excluded tx was returned")
+	}
+	if len(getMempoolTx([]byte{0xbd}, []byte{0xbc, 0xe7})) != 1 {
+		t.Fatal("tx with other prefix was not returned")
+	}
+	if err := GetMempoolTx([][]byte{{}}, nil); err == nil {
+		t.Fatal("GetMempoolTx with an empty txid prefix should fail")
+	}
+	if err := GetMempoolTx(make([][]byte, maxExclude+1), nil); status.Code(err) != codes.InvalidArgument {
+		t.Fatal("GetMempoolTx with too many txid prefixes should fail", err)
+	}
+	if step != 4 {
+		t.Fatal("unexpected number of zcashd RPCs", step)
+	}
+	if event := mempool.byTxid["mempooltxid-1"]; !event.feeKnown || event.fee != 10000 {
+		t.Fatal("unexpected mempool tx fee", event.fee, event.feeKnown)
+	}
+
+	resetMempool()
+	step = 0
+	sleepCount = 0
+	sleepDuration = 0
+}
+
+// ------------------------------------------ mempool removal reasons
+
+// testBlockHex returns a block containing the given transactions after the
+// coinbase of the first test block, as from getblock.
+func testBlockHex(txs ...[]byte) string {
+	blocks, err := ioutil.ReadFile("../testdata/blocks")
+	if err != nil {
+		testT.Fatal(err)
+	}
+	block, _ := hex.DecodeString(strings.Split(string(blocks), "\n")[0])
+	reader := parser.NewBlockReader(bytes.NewReader(block))
+	coinbase, err := reader.Next()
+	if err != nil || reader.TxCount() != 1 {
+		testT.Fatal("unexpected test block")
+	}
+	// The header, then the transaction count (1).
+	header := block[:len(block)-len(coinbase.Bytes())-1]
+	result := append(append([]byte{}, header...), byte(len(txs)+1))
+	result = append(result, coinbase.Bytes()...)
+	for _, tx := range txs {
+		result = append(result, tx...)
+	}
+	return hex.EncodeToString(result)
+}
+
+// The state of the mempoolReasonsStub daemon.
+var mempoolReasonsState struct {
+	height  int
+	mempool map[string][]byte // raw transactions by txid
+	blocks  map[int]string    // by height, as from getblock; the default has only a coinbase
+}
+
+func mempoolReasonsStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	switch method {
+	case "getblockchaininfo":
+		r, _ := json.Marshal(&ZcashdRpcReplyGetblockchaininfo{
+			BestBlockHash: strconv.Itoa(mempoolReasonsState.height),
+			Blocks:        mempoolReasonsState.height,
+		})
+		return r, nil
+	case "getrawmempool":
+		reply := make(map[string]ZcashdRpcReplyGetrawmempoolEntry)
+		for txid, tx := range mempoolReasonsState.mempool {
+			reply[txid] = ZcashdRpcReplyGetrawmempoolEntry{Size: len(tx)}
+		}
+		r, _ := json.Marshal(reply)
+		return r, nil
+	case "getrawtransaction":
+		var txid string
+		json.Unmarshal(params[0], &txid)
+		r, _ := json.Marshal(hex.EncodeToString(mempoolReasonsState.mempool[txid]))
+		return r, nil
+	case "getblock":
+		var heightStr string
+		json.Unmarshal(params[0], &heightStr)
+		height, _ := strconv.Atoi(heightStr)
+		block, ok := mempoolReasonsState.blocks[height]
+		if !ok {
+			block = testBlockHex()
+		}
+		r, _ := json.Marshal(block)
+		return r, nil
+	}
+	testT.Fatal("unexpected method", method)
+	return nil, nil
+}
+
+func TestMempoolRemovalReasons(t *testing.T) {
+	testT = t
+	RawRequest = mempoolReasonsStub
+	Time.Sleep = sleepStub
+	resetMempool()
+
+	// The ZIP 243 test vectors: A (which has Sapling spends) and a copy of it
+	// with a different binding signature, so a different txid but the same
+	// nullifiers, and B, which has transparent inputs and expires.
+	testData, err := ioutil.ReadFile("../testdata/zip243_raw_tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(testData), "\n")
+	txA, _ := hex.DecodeString(lines[2])
+	txB, _ := hex.DecodeString(lines[4])
+	txA2 := append([]byte{}, txA...)
+	txA2[len(txA2)-1]++
+	txid := func(txBytes []byte) string {
+		tx := parser.NewTransaction()
+		if _, err := tx.ParseFromSlice(txBytes); err != nil {
+			t.Fatal(err)
+		}
+		return hex.EncodeToString(tx.GetDisplayHash())
 	}
+	idA, idA2, idB := txid(txA), txid(txA2), txid(txB)
+
+	mempoolReasonsState.height = 100
+	mempoolReasonsState.mempool = map[string][]byte{idA: txA, idB: txB}
+	mempoolReasonsState.blocks = map[int]string{}
+	MempoolTracker(1)
+	_, sub := SubscribeMempool()
+	defer sub.Close()
+	checkRemoval := func(id string, reason walletrpc.MempoolTxEvent_RemovalReason, height uint64) {
+		t.Helper()
+		for {
+			event := <-sub.C
+			if event.Type != MempoolRemove {
+				continue
+			}
+			if event.Txid != id || event.Reason != reason || event.Height != height {
+				t.Fatal("unexpected removal ", event.Txid, " ", event.Reason, " ", event.Height)
+			}
+			txEvent := event.TxEvent()
+			if txEvent.Type != walletrpc.MempoolTxEvent_removed || txEvent.Reason != reason ||
+				hex.EncodeToString(parser.Reverse(txEvent.Txid)) != id {
+				t.Fatal("unexpected removal event ", txEvent)
+			}
+			return
+		}
+	}
+
+	// A is replaced by a conflicting transaction, and B is dropped.
+	mempoolReasonsState.mempool = map[string][]byte{idA2: txA2}
+	MempoolTracker(1)
+	checkRemoval(idA, walletrpc.MempoolTxEvent_conflicted, 100)
+	checkRemoval(idB, walletrpc.MempoolTxEvent_evicted, 100)
+
+	// The replacement is mined, and B returns.
+	mempoolReasonsState.height = 101
+	mempoolReasonsState.blocks[101] = testBlockHex(txA2)
+	mempoolReasonsState.mempool = map[string][]byte{idB: txB}
+	MempoolTracker(1)
+	checkRemoval(idA2, walletrpc.MempoolTxEvent_mined, 101)
 
+	// B isn't mined before its expiry height (the last few blocks are
+	// checked).
+	mempoolReasonsState.height = 396157507
+	mempoolReasonsState.mempool = map[string][]byte{}
+	step = 0
+	MempoolTracker(1)
+	checkRemoval(idB, walletrpc.MempoolTxEvent_expired, 396157507)
+	if step != 2+maxMinedBlocksChecked {
+		t.Fatal("unexpected number of zcashd RPCs", step)
+	}
+
+	resetMempool()
 	step = 0
 	sleepCount = 0
 	sleepDuration = 0
 }
+
+// ------------------------------------------ GetTransactionStatus()
+
+func txStatusStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	if method != "getrawtransaction" || string(params[1]) != "1" {
+		testT.Fatal("unexpected method", method)
+	}
+	var txid string
+	json.Unmarshal(params[0], &txid)
+	switch txid[:2] {
+	case "01":
+		return json.RawMessage(`{"hex": "aabb", "height": 380600}`), nil
+	case "02":
+		return nil, errors.New("-5: No information available about transaction")
+	}
+	return nil, errors.New("-28: Loading block index...")
+}
+
+func TestGetTransactionStatus(t *testing.T) {
+	testT = t
+	RawRequest = txStatusStub
+	testcache.Reset(380640)
+	if err := testcache.Add(380640, &walletrpc.CompactBlock{Height: 380640, Hash: []byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+	txidOf := func(txData []byte) []byte {
+		digest := sha256.Sum256(txData)
+		digest = sha256.Sum256(digest[:])
+		return digest[:]
+	}
+	checkStatus := func(txid []byte, status walletrpc.TransactionStatus_Status, height, confirmations uint64, reason string) {
+		t.Helper()
+		reply, err := GetTransactionStatus(testcache, txid)
+		if err != nil {
+			t.Fatal("GetTransactionStatus failed", err)
+		}
+		if !bytes.Equal(reply.Txid, txid) || reply.Status != status || reply.Height != height ||
+			reply.Confirmations != confirmations || reply.Reason != reason {
+			t.Fatal("unexpected transaction status", reply)
+		}
+	}
+
+	// Submitted transactions
+	TrackSentTransaction(testcache, []byte{1}, 0, "")
+	checkStatus(txidOf([]byte{1}), walletrpc.TransactionStatus_inMempool, 0, 0, "")
+	TrackSentTransaction(testcache, []byte{2}, rpcVerifyRejected, "bad-txns-inputs-spent")
+	checkStatus(txidOf([]byte{2}), walletrpc.TransactionStatus_rejected, 0, 0, "bad-txns-inputs-spent")
+	// Resubmitting a transaction in the mempool fails, but it's still there.
+	TrackSentTransaction(testcache, []byte{1}, rpcVerifyError, "txn-already-in-mempool")
+	checkStatus(txidOf([]byte{1}), walletrpc.TransactionStatus_inMempool, 0, 0, "")
+
+	// A transaction leaving the mempool
+	txid := hex.EncodeToString(parser.Reverse(txidOf([]byte{1})))
+	trackMempoolEvent(&MempoolEvent{Type: MempoolRemove, Txid: txid, Height: 380640, Reason: walletrpc.MempoolTxEvent_mined})
+	checkStatus(txidOf([]byte{1}), walletrpc.TransactionStatus_mined, 380640, 1, "")
+	trackMempoolEvent(&MempoolEvent{Type: MempoolRemove, Txid: txid, Height: 380640, Reason: walletrpc.MempoolTxEvent_conflicted})
+	checkStatus(txidOf([]byte{1}), walletrpc.TransactionStatus_rejected, 0, 0, "conflicted after entering the mempool")
+	if step != 0 {
+		t.Fatal("unexpected zcashd RPCs", step)
+	}
+
+	// Transactions that aren't being tracked are looked up, with the reply
+	// cached until the next block; one that's unknown isn't remembered.
+	mined := make([]byte, 32)
+	mined[31] = 0x01
+	checkStatus(mined, walletrpc.TransactionStatus_mined, 380600, 41, "")
+	checkStatus(mined, walletrpc.TransactionStatus_mined, 380600, 41, "")
+	unknown := make([]byte, 32)
+	unknown[31] = 0x02
+	checkStatus(unknown, walletrpc.TransactionStatus_unknown, 0, 0, "")
+	checkStatus(unknown, walletrpc.TransactionStatus_unknown, 0, 0, "")
+	if _, ok := getTxStatus(hex.EncodeToString(parser.Reverse(mined))); ok {
+		t.Fatal("looked up transaction is tracked")
+	}
+	if step != 3 {
+		t.Fatal("unexpected number of zcashd RPCs", step)
+	}
+	// Submitting a transaction zcashd already has, that wasn't being
+	// tracked, looks up its status rather than recording it as rejected.
+	// (The txids of these start with 01, 02 and 02.)
+	TrackSentTransaction(testcache, []byte{66}, rpcVerifyAlreadyInChain, "transaction already in block chain")
+	checkStatus(txidOf([]byte{66}), walletrpc.TransactionStatus_mined, 380600, 41, "")
+	TrackSentTransaction(testcache, []byte{61}, rpcVerifyError, "txn-already-in-mempool")
+	checkStatus(txidOf([]byte{61}), walletrpc.TransactionStatus_unknown, 0, 0, "")
+	TrackSentTransaction(testcache, []byte{94}, rpcVerifyRejected, "bad-txns-inputs-spent")
+	checkStatus(txidOf([]byte{94}), walletrpc.TransactionStatus_rejected, 0, 0, "bad-txns-inputs-spent")
+	if step != 6 {
+		t.Fatal("unexpected number of zcashd RPCs", step)
+	}
+	if _, err := GetTransactionStatus(testcache, make([]byte, 32)); err == nil {
+		t.Fatal("GetTransactionStatus should fail when zcashd fails")
+	}
+	if _, err := GetTransactionStatus(testcache, make([]byte, 31)); err == nil {
+		t.Fatal("GetTransactionStatus should fail with a short txid")
+	}
+
+	// Watching a transaction
+	done := make(chan struct{})
+	statuses := make(chan *walletrpc.TransactionStatus)
+	watchErr := make(chan error)
+	go func() {
+		watchErr <- WatchTransactionStatus(done, testcache, txidOf([]byte{2}), func(status *walletrpc.TransactionStatus) error {
+			statuses <- status
+			return nil
+		})
+	}()
+	if status := <-statuses; status.Status != walletrpc.TransactionStatus_rejected {
+		t.Fatal("unexpected initial status", status)
+	}
+	// Another transaction's change, and blocks while it's not mined, aren't sent.
+	TrackSentTransaction(testcache, []byte{3}, 0, "")
+	if err := testcache.Add(380641, &walletrpc.CompactBlock{Height: 380641, Hash: []byte{2}, PrevHash: []byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+	txid = hex.EncodeToString(parser.Reverse(txidOf([]byte{2})))
+	trackMempoolEvent(&MempoolEvent{Type: MempoolAdd, Txid: txid, Height: 380641})
+	if status := <-statuses; status.Status != walletrpc.TransactionStatus_inMempool {
+		t.Fatal("unexpected status", status)
+	}
+	trackMempoolEvent(&MempoolEvent{Type: MempoolRemove, Txid: txid, Height: 380641, Reason: walletrpc.MempoolTxEvent_mined})
+	if status := <-statuses; status.Status != walletrpc.TransactionStatus_mined || status.Confirmations != 1 {
+		t.Fatal("unexpected status", status)
+	}
+	// Each block adds a confirmation.
+	if err := testcache.Add(380642, &walletrpc.CompactBlock{Height: 380642, Hash: []byte{3}, PrevHash: []byte{2}}); err != nil {
+		t.Fatal(err)
+	}
+	if status := <-statuses; status.Status != walletrpc.TransactionStatus_mined || status.Confirmations != 2 {
+		t.Fatal("unexpected status", status)
+	}
+	close(done)
+	if err := <-watchErr; err != nil {
+		t.Fatal("WatchTransactionStatus failed", err)
+	}
+
+	// A reorg forgets the transactions mined in the blocks it removes.
+	testcache.Reorg(380641)
+	if _, ok := getTxStatus(txid); ok {
+		t.Fatal("transaction mined in a removed block is still tracked")
+	}
+	if status, ok := getTxStatus(hex.EncodeToString(parser.Reverse(txidOf([]byte{66})))); !ok ||
+		status.status != walletrpc.TransactionStatus_mined {
+		t.Fatal("transaction mined before the reorg was forgotten")
+	}
+
+	txStatuses.byTxid = nil
+	txStatuses.order = nil
+	step = 0
+}
+
+// ------------------------------------------ CheckSendTransaction()
+
+var preflightBranch string
+
+func preflightStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	if method != "getblockchaininfo" {
+		testT.Fatal("unexpected method", method)
+	}
+	if preflightBranch == "" {
+		return nil, errors.New("-28: Loading block index...")
+	}
+	// The chain tip's branch is the one checked (as in GetLightdInfo).
+	return []byte(`{"consensus": {"chaintip": "` + preflightBranch + `", "nextblock": "c2d6d0b4"}}`), nil
+}
+
+func TestCheckSendTransaction(t *testing.T) {
+	testT = t
+	RawRequest = preflightStub
+	testcache.Reset(380640)
+	if err := testcache.Add(380640, &walletrpc.CompactBlock{Height: 380640, Hash: []byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+	testData, err := ioutil.ReadFile("../testdata/zip243_raw_tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(testData), "\n")
+	txA, _ := hex.DecodeString(lines[2])
+	txB, _ := hex.DecodeString(lines[4])
+	// Copies of B (which expires at 396157507) expiring at the given height.
+	expiring := func(height uint32) []byte {
+		expiry := make([]byte, 4)
+		binary.LittleEndian.PutUint32(expiry, 396157507)
+		i := bytes.Index(txB, expiry)
+		tx := append([]byte{}, txB...)
+		binary.LittleEndian.PutUint32(tx[i:], height)
+		return tx
+	}
+	check := func(txData []byte, code codes.Code, reason string) {
+		t.Helper()
+		clearCachedReplies()
+		err := CheckSendTransaction(testcache, txData)
+		if code == codes.OK {
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			return
+		}
+		st := status.Convert(err)
+		if st.Code() != code {
+			t.Fatal("unexpected error code", err)
+		}
+		for _, detail := range st.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok {
+				if info.Reason != reason || info.Domain != "lightwalletd" {
+					t.Fatal("unexpected error info", info)
+				}
+				return
+			}
+		}
+		t.Fatal("no error info", err)
+	}
+
+	preflightBranch = "76b809bb" // Sapling
+	check(txA, codes.OK, "")
+	check(txB, codes.OK, "")
+	check(expiring(380645), codes.OK, "")
+	check(expiring(380643), codes.FailedPrecondition, "tx-expiring-soon")
+	check(expiring(380640), codes.FailedPrecondition, "tx-overwinter-expired")
+	check([]byte{7}, codes.InvalidArgument, "bad-txns-parse")
+	check(append(append([]byte{}, txA...), 0), codes.InvalidArgument, "bad-txns-trailing-data")
+	check(make([]byte, parser.MaxTxSize+1), codes.InvalidArgument, "bad-txns-oversize")
+	badGroup := append([]byte{}, txA...)
+	badGroup[4]++
+	check(badGroup, codes.InvalidArgument, "bad-sapling-tx-version-group-id")
+
+	// Wrong consensus branch
+	preflightBranch = "5ba81b19" // Overwinter
+	check(txA, codes.FailedPrecondition, "bad-tx-version-for-branch")
+	preflightBranch = "00000000" // Sprout
+	check(txA, codes.FailedPrecondition, "tx-overwinter-not-active")
+	preflightBranch = "12345678"
+	check(txA, codes.OK, "")
+	if _, logged := unknownBranches.Load("12345678"); !logged {
+		t.Fatal("unknown branch not logged")
+	}
+	// The branch can't be checked if verusd can't be asked.
+	preflightBranch = ""
+	check(txA, codes.OK, "")
+
+	// Duplicate submission
+	preflightBranch = "76b809bb"
+	TrackSentTransaction(testcache, txA, 0, "")
+	check(txA, codes.AlreadyExists, "txn-already-in-mempool")
+	TrackSentTransaction(testcache, txB, rpcVerifyRejected, "bad-txns-inputs-spent")
+	check(txB, codes.OK, "")
+	if step != 8 {
+		t.Fatal("unexpected number of zcashd RPCs", step)
+	}
+
+	txStatuses.byTxid = nil
+	txStatuses.order = nil
+	clearCachedReplies()
+	step = 0
+}
+
+// ------------------------------------------ StatusError()
+
+func TestStatusError(t *testing.T) {
+	if StatusError(nil) != nil {
+		t.Fatal("StatusError(nil) should be nil")
+	}
+	tests := []struct {
+		err    error
+		code   codes.Code
+		reason string // zcashd's error name
+	}{
+		{errors.New("-8: Block height out of range"), codes.OutOfRange, "RPC_INVALID_PARAMETER"},
+		{errors.New("-8: Invalid parameter"), codes.InvalidArgument, "RPC_INVALID_PARAMETER"},
+		{errors.Wrap(errors.New("-5: No information available about transaction"), "error requesting transaction"),
+			codes.NotFound, "RPC_INVALID_ADDRESS_OR_KEY"},
+		{errors.New("-5: Invalid address"), codes.InvalidArgument, "RPC_INVALID_ADDRESS_OR_KEY"},
+		{&btcjson.RPCError{Code: -28, Message: "Loading block index..."}, codes.Unavailable, "RPC_IN_WARMUP"},
+		{errors.New("-26: 18: bad-txns-inputs-spent"), codes.FailedPrecondition, "RPC_VERIFY_REJECTED"},
+		{errors.New("-27: transaction already in block chain"), codes.AlreadyExists, "RPC_VERIFY_ALREADY_IN_CHAIN"},
+		{errors.New("-32601: Method not found"), codes.Unimplemented, "RPC_METHOD_NOT_FOUND"},
+		{errors.New("-1: something else"), codes.Internal, "RPC_MISC_ERROR"},
+		{errors.New("-123: something new"), codes.Internal, "RPC_ERROR"},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, codes.Unavailable, ""},
+		{context.Canceled, codes.Canceled, ""},
+		{errors.New("error reading JSON response"), codes.Internal, ""},
+		{InvalidArgumentError("hash", "Transaction ID has invalid length"), codes.InvalidArgument, ""},
+		{SlowClientError("client is too slow, dropped"), codes.ResourceExhausted, ""},
+	}
+	for i, tt := range tests {
+		st := status.Convert(StatusError(tt.err))
+		if st.Code() != tt.code {
+			t.Fatal("unexpected code, case", i, st.Code())
+		}
+		var info *errdetails.ErrorInfo
+		var retry *errdetails.RetryInfo
+		for _, detail := range st.Details() {
+			switch d := detail.(type) {
+			case *errdetails.ErrorInfo:
+				info = d
+			case *errdetails.RetryInfo:
+				retry = d
+			}
+		}
+		if tt.reason != "" {
+			if info == nil || info.Domain != "zcashd" || info.Reason != tt.reason {
+				t.Fatal("unexpected error info, case", i, info)
+			}
+			if code, _, _ := ParseRPCError(tt.err); info.Metadata["code"] != strconv.FormatInt(code, 10) {
+				t.Fatal("unexpected error code metadata, case", i, info)
+			}
+		}
+		if (tt.code == codes.Unavailable) != (retry != nil) {
+			t.Fatal("unexpected retry info, case", i, retry)
+		}
+	}
+	if _, _, ok := ParseRPCError(errors.New("error reading JSON response")); ok {
+		t.Fatal("ParseRPCError should fail on an error not from zcashd")
+	}
+	if code, message, ok := ParseRPCError(errors.New("-26: 18: bad-txns-inputs-spent")); !ok ||
+		code != -26 || message != "18: bad-txns-inputs-spent" {
+		t.Fatal("ParseRPCError unexpected result", code, message)
+	}
+}
+
+// ------------------------------------------ GetFeeEstimate()
+
+func feeEstimateStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	if method != "estimatefee" {
+		testT.Fatal("unexpected method", method)
+	}
+	var target int
+	if err := json.Unmarshal(params[0], &target); err != nil {
+		testT.Fatal(err)
+	}
+	// zcashd has an estimate only for the next block.
+	if target == 1 {
+		return []byte("0.0002"), nil
+	}
+	return []byte("-1"), nil
+}
+
+func TestGetFeeEstimate(t *testing.T) {
+	testT = t
+	RawRequest = feeEstimateStub
+	resetMempool()
+	// Forget the blocks ingested by other tests.
+	blockFeeRates.byHeight = nil
+	blockFeeRates.latest = 0
+	testcache.Reset(1003)
+	clearCachedReplies()
+
+	if _, err := GetFeeEstimate(testcache, nil); status.Code(err) != codes.Unavailable {
+		t.Fatal("expected Unavailable for an empty cache", err)
+	}
+	if err := testcache.Add(1003, &walletrpc.CompactBlock{Height: 1003, Hash: []byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, targets := range [][]uint32{{0}, {1, 101}} {
+		if _, err := GetFeeEstimate(testcache, targets); status.Code(err) != codes.InvalidArgument {
+			t.Fatal("expected InvalidArgument for targets", targets, err)
+		}
+	}
+
+	full := func(rate uint64) parser.TxFee {
+		return parser.TxFee{Fee: rate * fullBlockTxBytes / 1000, Known: true, Size: fullBlockTxBytes}
+	}
+	observeBlockFees(900, []parser.TxFee{full(90000)}) // falls out of the window
+	observeBlockFees(1000, []parser.TxFee{full(5000), {Fee: 3000, Known: true, Size: 1000}})
+	observeBlockFees(1001, []parser.TxFee{{Fee: 1000, Known: true, Size: 1000}})
+	observeBlockFees(1002, []parser.TxFee{{Known: false, Size: fullBlockTxBytes}})
+	observeBlockFees(1003, []parser.TxFee{full(8000)})
+
+	mempool.mutex.Lock()
+	mempool.txs = []*MempoolEvent{
+		{Txid: "aa", fee: 12000000, feeKnown: true, size: 1000000},
+		{Txid: "bb", fee: 75000000, feeKnown: true, size: 1500000},
+		{Txid: "cc", size: 500000},
+	}
+	mempool.mutex.Unlock()
+
+	reply, err := GetFeeEstimate(testcache, []uint32{1, 2, 3})
+	if err != nil {
+		t.Fatal("GetFeeEstimate failed", err)
+	}
+	if reply.Height != 1003 || reply.MinimumFee != minimumFee ||
+		reply.ObservedBlocks != 3 || reply.ObservedMempoolTxs != 2 || len(reply.Shapes) != len(txShapes) {
+		t.Fatal("unexpected reply", reply)
+	}
+	expected := []struct {
+		feeRate, daemon, block, mempool uint64
+	}{
+		{20000, 20000, 8000, 12001},
+		{3000, 0, 3000, 0},
+		{0, 0, 0, 0},
+	}
+	for i, e := range expected {
+		estimate := reply.Estimates[i]
+		if estimate.Target != uint32(i+1) || estimate.FeeRate != e.feeRate || estimate.DaemonFeeRate != e.daemon ||
+			estimate.BlockFeeRate != e.block || estimate.MempoolFeeRate != e.mempool {
+			t.Fatal("unexpected estimate", i, estimate)
+		}
+		for j, shape := range reply.Shapes {
+			fee := e.feeRate * uint64(shape.Size) / 1000
+			if fee < minimumFee {
+				fee = minimumFee
+			}
+			if estimate.Fees[j].Shape != shape.Name || estimate.Fees[j].Fee != fee {
+				t.Fatal("unexpected shape fee", i, estimate.Fees[j])
+			}
+		}
+	}
+	if reply.Estimates[0].Fees[1].Fee != 20000*uint64(reply.Shapes[1].Size)/1000 {
+		t.Fatal("unexpected shielded fee", reply.Estimates[0].Fees[1])
+	}
+	if step != 3 {
+		t.Fatal("unexpected number of zcashd RPCs", step)
+	}
+	// A reorg drops the fee rates of the blocks it removes.
+	testcache.Reorg(1003)
+	if rates := recentBlockFeeRates(); len(rates) != 2 || blockFeeRates.latest != 1002 {
+		t.Fatal("unexpected fee rates after reorg", rates)
+	}
+
+	blockFeeRates.byHeight = nil
+	blockFeeRates.latest = 0
+	resetMempool()
+	clearCachedReplies()
+	step = 0
+}
+
+// ------------------------------------------ GetTaddressHistory()
+
+// The deltas as zcashd returns them, grouped by address.
+var historyDeltas = []ZcashdRpcReplyGetaddressdeltas{
+	{Satoshis: 5000, Txid: strings.Repeat("aa", 32), Height: 10, Blockindex: 1, Address: "R1"},
+	{Satoshis: -5000, Txid: strings.Repeat("bb", 32), Height: 10, Blockindex: 3, Address: "R1"},
+	{Satoshis: 1500, Txid: strings.Repeat("bb", 32), Index: 1, Height: 10, Blockindex: 3, Address: "R1"},
+	{Satoshis: 700, Txid: strings.Repeat("dd", 32), Height: 15, Blockindex: 2, Address: "R1"},
+	{Satoshis: 3000, Txid: strings.Repeat("bb", 32), Height: 10, Blockindex: 3, Address: "R2"},
+	{Satoshis: -3000, Txid: strings.Repeat("cc", 32), Height: 12, Blockindex: 1, Address: "R2"},
+}
+
+// The block range of the latest getaddressdeltas request.
+var historyRange ZcashdRpcRequestGetaddressdeltas
+
+func historyStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	switch method {
+	case "getaddressdeltas":
+		// (Not called concurrently.)
+		step++
+		if err := json.Unmarshal(params[0], &historyRange); err != nil {
+			testT.Fatal(err)
+		}
+		deltas := make([]ZcashdRpcReplyGetaddressdeltas, 0)
+		for _, delta := range historyDeltas {
+			if delta.Height >= historyRange.Start && delta.Height <= historyRange.End {
+				deltas = append(deltas, delta)
+			}
+		}
+		return json.Marshal(deltas)
+	case "getrawtransaction":
+		var txid string
+		if err := json.Unmarshal(params[0], &txid); err != nil {
+			return nil, err
+		}
+		if txid == strings.Repeat("ee", 32) {
+			return nil, errors.New("-5: No information available about transaction")
+		}
+		for _, delta := range historyDeltas {
+			if delta.Txid == txid {
+				return json.Marshal(&ZcashdRpcReplyGetrawtransaction{
+					Hex:       txid[:4],
+					Height:    int(delta.Height),
+					Blocktime: 1600000000 + int64(delta.Height),
+				})
+			}
+		}
+	}
+	return nil, errors.New("unexpected call to historyStub: " + method)
+}
+
+func TestGetTaddressHistory(t *testing.T) {
+	testT = t
+	RawRequest = historyStub
+	testcache.Reset(20)
+	if err := testcache.Add(20, &walletrpc.CompactBlock{Height: 20, Hash: []byte{1}}); err != nil {
+		t.Fatal(err)
+	}
+	history := func(arg *walletrpc.GetTaddressHistoryArg) ([]*walletrpc.TaddressTransaction, error) {
+		txs := make([]*walletrpc.TaddressTransaction, 0)
+		err := GetTaddressHistory(context.Background(), testcache, []string{"R1", "R2"}, arg,
+			func(tx *walletrpc.TaddressTransaction) error {
+				txs = append(txs, tx)
+				return nil
+			})
+		return txs, err
+	}
+	expect := func(txs []*walletrpc.TaddressTransaction, heights ...uint64) {
+		t.Helper()
+		if len(txs) != len(heights) {
+			t.Fatal("unexpected number of transactions", len(txs))
+		}
+		for i, tx := range txs {
+			if tx.Height != heights[i] || tx.BlockTime != 1600000000+uint32(heights[i]) {
+				t.Fatal("unexpected transaction", i, tx)
+			}
+		}
+	}
+
+	// Oldest first, two pages.
+	txs, err := history(&walletrpc.GetTaddressHistoryArg{MaxEntries: 2})
+	if err != nil {
+		t.Fatal("GetTaddressHistory failed", err)
+	}
+	expect(txs, 10, 10)
+	if historyRange.Start != 0 || historyRange.End != 20 {
+		t.Fatal("unexpected range", historyRange)
+	}
+	tx := txs[1]
+	if !bytes.Equal(tx.Txid, bytes.Repeat([]byte{0xbb}, 32)) || !bytes.Equal(tx.Data, []byte{0xbb, 0xbb}) ||
+		tx.Cursor != "10-3" || len(tx.Deltas) != 2 ||
+		tx.Deltas[0].Address != "R1" || tx.Deltas[0].ValueZat != -3500 ||
+		tx.Deltas[1].Address != "R2" || tx.Deltas[1].ValueZat != 3000 {
+		t.Fatal("unexpected transaction", tx)
+	}
+	txs, err = history(&walletrpc.GetTaddressHistoryArg{MaxEntries: 2, Cursor: tx.Cursor})
+	if err != nil {
+		t.Fatal("GetTaddressHistory failed", err)
+	}
+	expect(txs, 12, 15)
+	if historyRange.Start != 10 || historyRange.End != 20 {
+		t.Fatal("unexpected range", historyRange)
+	}
+	txs, err = history(&walletrpc.GetTaddressHistoryArg{MaxEntries: 2, Cursor: txs[1].Cursor})
+	if err != nil {
+		t.Fatal("GetTaddressHistory failed", err)
+	}
+	expect(txs)
+
+	// Newest first, within a range.
+	order := walletrpc.HistoryOrder_newestFirst
+	txs, err = history(&walletrpc.GetTaddressHistoryArg{Order: order, StartHeight: 10, EndHeight: 14, MaxEntries: 2})
+	if err != nil {
+		t.Fatal("GetTaddressHistory failed", err)
+	}
+	expect(txs, 12, 10)
+	if txs[1].Cursor != "10-3" {
+		t.Fatal("unexpected cursor", txs[1].Cursor)
+	}
+	txs, err = history(&walletrpc.GetTaddressHistoryArg{Order: order, StartHeight: 10, EndHeight: 14, Cursor: txs[1].Cursor})
+	if err != nil {
+		t.Fatal("GetTaddressHistory failed", err)
+	}
+	expect(txs, 10)
+	if txs[0].Cursor != "10-1" || historyRange.Start != 10 || historyRange.End != 10 {
+		t.Fatal("unexpected page", txs[0].Cursor, historyRange)
+	}
+
+	// Bad requests and errors
+	_, err = history(&walletrpc.GetTaddressHistoryArg{Cursor: "10"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("expected InvalidArgument for a bad cursor", err)
+	}
+	_, err = history(&walletrpc.GetTaddressHistoryArg{StartHeight: 12, EndHeight: 11})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("expected InvalidArgument for a bad range", err)
+	}
+	historyDeltas = append(historyDeltas,
+		ZcashdRpcReplyGetaddressdeltas{Satoshis: 1, Txid: strings.Repeat("ee", 32), Height: 16, Address: "R2"})
+	txs, err = history(&walletrpc.GetTaddressHistoryArg{})
+	if code, _, _ := ParseRPCError(err); code != -5 || len(txs) != 4 {
+		t.Fatal("expected the getrawtransaction error after 4 transactions", err, len(txs))
+	}
+	historyDeltas = historyDeltas[:len(historyDeltas)-1]
+	stop := errors.New("stop")
+	err = GetTaddressHistory(context.Background(), testcache, []string{"R1"}, &walletrpc.GetTaddressHistoryArg{},
+		func(tx *walletrpc.TaddressTransaction) error {
+			return stop
+		})
+	if err != stop {
+		t.Fatal("expected the callback's error", err)
+	}
+	if step != 7 {
+		t.Fatal("unexpected number of getaddressdeltas RPCs", step)
+	}
+	step = 0
+}
+
+func TestFilterBlock(t *testing.T) {
+	newBlock := func() *walletrpc.CompactBlock {
+		return &walletrpc.CompactBlock{
+			Height: 1000,
+			Vtx: []*walletrpc.CompactTx{
+				{Index: 0, Hash: []byte{0xa0, 0}, Vout: []*walletrpc.TxOut{{Value: 1000}}},
+				{Index: 1, Hash: []byte{0xa1, 1}, Spends: []*walletrpc.CompactSpend{{Nf: []byte{1}}},
+					Outputs: []*walletrpc.CompactOutput{{Cmu: []byte{1}}}},
+				{Index: 2, Hash: []byte{0xb2, 2}, Spends: []*walletrpc.CompactSpend{{Nf: []byte{2}}}},
+				{Index: 3, Hash: []byte{0xb3, 3}, Vin: []*walletrpc.CompactTxIn{{PrevoutIndex: 3}},
+					Outputs: []*walletrpc.CompactOutput{{Cmu: []byte{3}}}},
+			},
+		}
+	}
+	indexes := func(block *walletrpc.CompactBlock) []uint64 {
+		indexes := make([]uint64, 0)
+		for _, tx := range block.Vtx {
+			indexes = append(indexes, tx.Index)
+		}
+		return indexes
+	}
+	tests := []struct {
+		opts    BlockRangeOptions
+		indexes []uint64
+	}{
+		{BlockRangeOptions{}, []uint64{0, 1, 2, 3}},
+		{BlockRangeOptions{Content: walletrpc.BlockContent_spendsOnly}, []uint64{1, 2, 3}},
+		{BlockRangeOptions{Content: walletrpc.BlockContent_outputsOnly}, []uint64{0, 1, 3}},
+		{BlockRangeOptions{Content: walletrpc.BlockContent_metadataOnly}, []uint64{}},
+		{BlockRangeOptions{Exclude: [][]byte{{0xb2}, {0xa0, 0}}}, []uint64{1, 3}},
+		{BlockRangeOptions{Content: walletrpc.BlockContent_outputsOnly, Exclude: [][]byte{{0xa1, 1}}}, []uint64{0, 3}},
+	}
+	for i, tt := range tests {
+		block := newBlock()
+		filterBlock(block, tt.opts)
+		if fmt.Sprint(indexes(block)) != fmt.Sprint(tt.indexes) {
+			t.Fatalf("test %d: expected transactions %v, got %v", i, tt.indexes, indexes(block))
+		}
+		for _, tx := range block.Vtx {
+			switch tt.opts.Content {
+			case walletrpc.BlockContent_spendsOnly:
+				if len(tx.Outputs) != 0 || len(tx.Vout) != 0 {
+					t.Fatalf("test %d: outputs sent with spends only: %v", i, tx)
+				}
+			case walletrpc.BlockContent_outputsOnly:
+				if len(tx.Spends) != 0 || len(tx.Vin) != 0 {
+					t.Fatalf("test %d: spends sent with outputs only: %v", i, tx)
+				}
+			}
+		}
+	}
+
+	// Bad options are rejected before any block is sent.
+	for _, opts := range []BlockRangeOptions{
+		{Content: walletrpc.BlockContent(99)},
+		{Exclude: [][]byte{{}}},
+		{Exclude: [][]byte{make([]byte, 33)}},
+	} {
+		blockChan := make(chan *walletrpc.CompactBlock)
+		errChan := make(chan error)
+		go GetBlockRange(testcache, blockChan, errChan, 380640, 380642, opts)
+		select {
+		case err := <-errChan:
+			if status.Code(err) != codes.InvalidArgument {
+				t.Fatal("unexpected GetBlockRange error", err)
+			}
+		case <-blockChan:
+			t.Fatal("GetBlockRange sent a block despite bad options", opts)
+		}
+	}
+}
+
+// streamReply is a JSON-RPC reply as StreamRequest returns it, arriving a
+// byte at a time; closed is set when it's closed.
+type streamReply struct {
+	io.Reader
+	closed bool
+}
+
+func (r *streamReply) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestGetBlockReaderStream(t *testing.T) {
+	defer func() { StreamRequest = nil }()
+	var reply *streamReply
+	stream := func(body string) {
+		StreamRequest = func(method string, params []json.RawMessage) (io.ReadCloser, error) {
+			if method != "getblock" {
+				testT.Fatal("unexpected method ", method)
+			}
+			reply = &streamReply{Reader: iotest.OneByteReader(strings.NewReader(body))}
+			return reply, nil
+		}
+	}
+
+	blockHex := testBlockHex()
+	stream(`{"result": "` + blockHex + `", "error": null, "id": 1}`)
+	reader, err := getBlockReaderFromRPC(380640)
+	if err != nil {
+		t.Fatal("getBlockReaderFromRPC failed ", err)
+	}
+	if _, err := reader.Header(); err != nil {
+		t.Fatal("header not parsed ", err)
+	}
+	if reply.closed {
+		t.Fatal("reply closed too soon")
+	}
+	if _, err := reader.ReadCompact(); err != nil {
+		t.Fatal("block not parsed ", err)
+	}
+	reader.Close()
+	if !reply.closed {
+		t.Fatal("reply not closed")
+	}
+
+	// The error needn't follow the result.
+	stream(`{"error":{"code":-8,"message":"Block height out of range"},"result":null,"id":1}`)
+	if reader, err := getBlockReaderFromRPC(380640); reader != nil || err != nil {
+		t.Fatal("expected no block from a too-high height, got ", reader, err)
+	}
+	stream(`{"result":null,"error":{"code":-28,"message":"Loading block index..."},"id":1}`)
+	if _, err := getBlockReaderFromRPC(380640); status.Code(StatusError(err)) != codes.Unavailable {
+		t.Fatal("expected the daemon's error, got ", err)
+	}
+	if !reply.closed {
+		t.Fatal("reply not closed after an error")
+	}
+	for _, body := range []string{
+		`"` + blockHex + `"`,
+		`{"result":12,"error":null,"id":1}`,
+		`{"id":1}`,
+	} {
+		stream(body)
+		if _, err := getBlockReaderFromRPC(380640); err == nil {
+			t.Fatal("expected an error from ", body)
+		}
+	}
+	stream(`{"result":"` + blockHex[:200] + `\u0030` + blockHex[200:] + `","error":null,"id":1}`)
+	reader, err = getBlockReaderFromRPC(380640)
+	if err != nil {
+		t.Fatal("getBlockReaderFromRPC failed ", err)
+	}
+	if _, err := reader.ReadCompact(); err == nil {
+		t.Fatal("expected an error from an escape in the result")
+	}
+	stream(`{"result":"` + blockHex[:200])
+	reader, err = getBlockReaderFromRPC(380640)
+	if err != nil {
+		t.Fatal("getBlockReaderFromRPC failed ", err)
+	}
+	if _, err := reader.ReadCompact(); err == nil {
+		t.Fatal("expected an error from a truncated result")
+	}
+}
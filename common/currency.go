@@ -0,0 +1,235 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
+)
+
+// The number of satoshis in one coin of any currency.
+const satoshisPerCoin = 100000000
+
+// ParseAmount converts a decimal coin amount, as the daemon's JSON replies
+// show currency values, to satoshis.
+func ParseAmount(amount json.Number) (int64, error) {
+	r, ok := new(big.Rat).SetString(string(amount))
+	if !ok {
+		return 0, errors.New("invalid amount " + string(amount))
+	}
+	r.Mul(r, big.NewRat(satoshisPerCoin, 1))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, errors.New("amount out of range " + string(amount))
+	}
+	return r.Num().Int64(), nil
+}
+
+// parseOptionalAmount is ParseAmount, except that a missing amount is zero.
+func parseOptionalAmount(amount json.Number) (int64, error) {
+	if amount == "" {
+		return 0, nil
+	}
+	return ParseAmount(amount)
+}
+
+// FormatAmount converts satoshis to a decimal coin amount (the inverse of
+// ParseAmount).
+func FormatAmount(satoshis int64) json.Number {
+	sign := ""
+	// The magnitude of the most negative int64 is representable as uint64.
+	u := uint64(satoshis)
+	if satoshis < 0 {
+		sign = "-"
+		u = -u
+	}
+	frac := strconv.FormatUint(u%satoshisPerCoin+satoshisPerCoin, 10)[1:]
+	return json.Number(sign + strconv.FormatUint(u/satoshisPerCoin, 10) + "." + frac)
+}
+
+// CurrencyValues converts the daemon's map of currency ID to decimal amount
+// to satoshis; it returns nil if there are no values.
+func CurrencyValues(values map[string]json.Number) (map[string]int64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]int64, len(values))
+	for currency, amount := range values {
+		satoshis, err := ParseAmount(amount)
+		if err != nil {
+			return nil, errors.Wrap(err, "currency "+currency)
+		}
+		result[currency] = satoshis
+	}
+	return result, nil
+}
+
+// The longest currency name (including its parents) that's accepted.
+const maxCurrencyNameLength = 255
+
+// checkCurrency makes sure a currency name or i-address (the given request
+// field) is plausible.
+func checkCurrency(field, currency string) error {
+	if currency == "" {
+		return InvalidArgumentError(field, "must specify a currency")
+	}
+	if len(currency) > maxCurrencyNameLength {
+		return InvalidArgumentError(field, "currency name is too long")
+	}
+	if strings.IndexFunc(currency, unicode.IsControl) >= 0 {
+		return InvalidArgumentError(field, "invalid currency name")
+	}
+	return nil
+}
+
+// GetCurrency returns the definition and latest state of the currency (name
+// or i-address).
+func GetCurrency(cache *BlockCache, currency string) (*walletrpc.Currency, error) {
+	if err := checkCurrency("currency", currency); err != nil {
+		return nil, err
+	}
+	param, err := json.Marshal(currency)
+	if err != nil {
+		return nil, err
+	}
+	result, err := cachedRequest(cache, "getcurrency", []json.RawMessage{param})
+	if err != nil {
+		return nil, err
+	}
+	var reply ZcashdRpcReplyGetcurrency
+	if err = json.Unmarshal(result, &reply); err != nil {
+		return nil, errors.Wrap(err, "error reading JSON response")
+	}
+	definitionTxid, err := decodeHash(reply.DefinitionTxid)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding currency definition txid")
+	}
+	supply, err := parseOptionalAmount(reply.BestCurrencyState.Supply)
+	if err != nil {
+		return nil, errors.Wrap(err, "currency supply")
+	}
+	c := &walletrpc.Currency{
+		CurrencyID:           reply.CurrencyID,
+		Name:                 reply.Name,
+		FullyQualifiedName:   reply.FullyQualifiedName,
+		Parent:               reply.Parent,
+		SystemID:             reply.SystemID,
+		Options:              reply.Options,
+		ProofProtocol:        reply.ProofProtocol,
+		NotarizationProtocol: reply.NotarizationProtocol,
+		StartBlock:           reply.StartBlock,
+		EndBlock:             reply.EndBlock,
+		DefinitionTxid:       definitionTxid,
+		BestHeight:           reply.BestHeight,
+		Supply:               supply,
+	}
+	for _, r := range reply.BestCurrencyState.ReserveCurrencies {
+		reserve := &walletrpc.ReserveCurrency{CurrencyID: r.CurrencyID}
+		for _, v := range []struct {
+			amount json.Number
+			out    *int64
+		}{
+			{r.Weight, &reserve.Weight},
+			{r.Reserves, &reserve.Reserves},
+			{r.PriceInReserve, &reserve.PriceInReserve},
+		} {
+			if *v.out, err = parseOptionalAmount(v.amount); err != nil {
+				return nil, errors.Wrap(err, "reserve currency "+r.CurrencyID)
+			}
+		}
+		c.ReserveCurrencies = append(c.ReserveCurrencies, reserve)
+	}
+	return c, nil
+}
+
+// CurrencyToRPC is the inverse of GetCurrency's conversion of the reply.
+func CurrencyToRPC(c *walletrpc.Currency) ZcashdRpcReplyGetcurrency {
+	reply := ZcashdRpcReplyGetcurrency{
+		Name:                 c.Name,
+		FullyQualifiedName:   c.FullyQualifiedName,
+		CurrencyID:           c.CurrencyID,
+		Parent:               c.Parent,
+		SystemID:             c.SystemID,
+		Options:              c.Options,
+		ProofProtocol:        c.ProofProtocol,
+		NotarizationProtocol: c.NotarizationProtocol,
+		StartBlock:           c.StartBlock,
+		EndBlock:             c.EndBlock,
+		DefinitionTxid:       hex.EncodeToString(parser.Reverse(c.DefinitionTxid)),
+		BestHeight:           c.BestHeight,
+	}
+	reply.BestCurrencyState.Supply = FormatAmount(c.Supply)
+	for _, r := range c.ReserveCurrencies {
+		reply.BestCurrencyState.ReserveCurrencies = append(reply.BestCurrencyState.ReserveCurrencies,
+			ZcashdRpcReserveCurrency{
+				CurrencyID:     r.CurrencyID,
+				Weight:         FormatAmount(r.Weight),
+				Reserves:       FormatAmount(r.Reserves),
+				PriceInReserve: FormatAmount(r.PriceInReserve),
+			})
+	}
+	return reply
+}
+
+// EstimateConversion returns the daemon's estimate of the result of the
+// given conversion at the latest block.
+func EstimateConversion(cache *BlockCache, arg *walletrpc.EstimateConversionArg) (*walletrpc.ConversionEstimate, error) {
+	if err := checkCurrency("currency", arg.Currency); err != nil {
+		return nil, err
+	}
+	if err := checkCurrency("convertTo", arg.ConvertTo); err != nil {
+		return nil, err
+	}
+	if arg.Via != "" {
+		if err := checkCurrency("via", arg.Via); err != nil {
+			return nil, err
+		}
+	}
+	if strings.EqualFold(arg.Currency, arg.ConvertTo) {
+		return nil, InvalidArgumentError("convertTo", "can't convert a currency to itself")
+	}
+	if arg.Amount <= 0 {
+		return nil, InvalidArgumentError("amount", "conversion amount must be positive")
+	}
+	param, err := json.Marshal(&ZcashdRpcRequestEstimateconversion{
+		Currency:   arg.Currency,
+		ConvertTo:  arg.ConvertTo,
+		Via:        arg.Via,
+		Amount:     FormatAmount(arg.Amount),
+		Preconvert: arg.Preconvert,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := cachedRequest(cache, "estimateconversion", []json.RawMessage{param})
+	if err != nil {
+		return nil, err
+	}
+	var reply ZcashdRpcReplyEstimateconversion
+	if err = json.Unmarshal(result, &reply); err != nil {
+		return nil, errors.Wrap(err, "error reading JSON response")
+	}
+	netInput, err := ParseAmount(reply.NetInputAmount)
+	if err != nil {
+		return nil, errors.Wrap(err, "net input amount")
+	}
+	estimate, err := ParseAmount(reply.EstimatedCurrencyOut)
+	if err != nil {
+		return nil, errors.Wrap(err, "estimated currency out")
+	}
+	return &walletrpc.ConversionEstimate{
+		InputCurrencyID:      reply.InputCurrencyID,
+		NetInputAmount:       netInput,
+		OutputCurrencyID:     reply.OutputCurrencyID,
+		EstimatedCurrencyOut: estimate,
+	}, nil
+}
@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -49,6 +51,12 @@ type darksideState struct {
 
 	// Unordered list of replies
 	getAddressUtxos []ZcashdRpcReplyGetaddressutxos
+
+	// Identity revisions in order of arrival (not necessarily sorted by height)
+	identities []ZcashdRpcIdentityUpdate
+
+	// Currencies in order of arrival
+	currencies []ZcashdRpcReplyGetcurrency
 }
 
 var state darksideState
@@ -460,11 +468,12 @@ func darksideRawRequest(method string, params []json.RawMessage) (json.RawMessag
 		return []byte(hex.EncodeToString(tx.GetDisplayHash())), nil
 
 	case "getrawmempool":
-		reply := make([]string, 0)
+		// Verbose, but without fees.
+		reply := make(map[string]ZcashdRpcReplyGetrawmempoolEntry)
 		addTxToReply := func(txBytes []byte) {
 			ctx := parser.NewTransaction()
 			ctx.ParseFromSlice(txBytes)
-			reply = append(reply, hex.EncodeToString(ctx.GetDisplayHash()))
+			reply[hex.EncodeToString(ctx.GetDisplayHash())] = ZcashdRpcReplyGetrawmempoolEntry{Size: len(txBytes)}
 		}
 		for _, blockBytes := range state.stagedBlocks {
 			block := parser.NewBlock()
@@ -495,6 +504,27 @@ func darksideRawRequest(method string, params []json.RawMessage) (json.RawMessag
 		}
 		return json.Marshal(utxosReply)
 
+	case "getidentity":
+		return darksideGetIdentity(params)
+
+	case "getidentityhistory":
+		return darksideGetIdentityHistory(params)
+
+	case "getcurrency":
+		var name string
+		err := json.Unmarshal(params[0], &name)
+		if err != nil {
+			return nil, errors.New("failed to parse getcurrency JSON")
+		}
+		currency := darksideFindCurrency(name)
+		if currency == nil {
+			return nil, errors.New("-5: Cannot find currency " + name)
+		}
+		return json.Marshal(currency)
+
+	case "estimateconversion":
+		return darksideEstimateConversion(params)
+
 	default:
 		return nil, errors.New("there was an attempt to call an unsupported RPC")
 	}
@@ -631,3 +661,205 @@ func DarksideClearAddressUtxos() error {
 	state.getAddressUtxos = nil
 	return nil
 }
+
+// darksideIdentityRevisions returns the revisions of the given identity
+// (name@ or i-address) with heights in [start, end], sorted by height.
+func darksideIdentityRevisions(params []json.RawMessage, start, end int) ([]ZcashdRpcIdentityUpdate, error) {
+	var identity string
+	err := json.Unmarshal(params[0], &identity)
+	if err != nil {
+		return nil, errors.New("failed to parse identity JSON")
+	}
+	revisions := make([]ZcashdRpcIdentityUpdate, 0)
+	for _, update := range state.identities {
+		if update.Height < start || update.Height > end {
+			continue
+		}
+		if identity == update.Identity.IdentityAddress ||
+			strings.EqualFold(identity, update.Identity.Name+"@") {
+			revisions = append(revisions, update)
+		}
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Height < revisions[j].Height
+	})
+	return revisions, nil
+}
+
+func darksideHeightParam(params []json.RawMessage, i int) (int, error) {
+	if len(params) <= i || string(params[i]) == "0" {
+		return state.latestHeight, nil
+	}
+	var height int
+	if err := json.Unmarshal(params[i], &height); err != nil {
+		return 0, errors.New("failed to parse height JSON")
+	}
+	return height, nil
+}
+
+func darksideGetIdentity(params []json.RawMessage) (json.RawMessage, error) {
+	height, err := darksideHeightParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := darksideIdentityRevisions(params, 0, height)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, errors.New("-5: Identity not found")
+	}
+	latest := revisions[len(revisions)-1]
+	return json.Marshal(&ZcashdRpcReplyGetidentity{
+		Identity:           latest.Identity,
+		FriendlyName:       latest.Identity.Name + "@",
+		FullyQualifiedName: latest.Identity.Name + "@",
+		Status:             "active",
+		BlockHeight:        latest.Height,
+		Txid:               latest.Output.Txid,
+		Vout:               latest.Output.VoutNum,
+	})
+}
+
+func darksideGetIdentityHistory(params []json.RawMessage) (json.RawMessage, error) {
+	var start int
+	if len(params) > 1 {
+		if err := json.Unmarshal(params[1], &start); err != nil {
+			return nil, errors.New("failed to parse height JSON")
+		}
+	}
+	end, err := darksideHeightParam(params, 2)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := darksideIdentityRevisions(params, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, errors.New("-5: Identity not found")
+	}
+	return json.Marshal(&ZcashdRpcReplyGetidentityhistory{
+		FullyQualifiedName: revisions[0].Identity.Name + "@",
+		History:            revisions,
+	})
+}
+
+// DarksideAddIdentity adds a revision of an identity, to be returned by the
+// getidentity and getidentityhistory rpcs.
+func DarksideAddIdentity(update ZcashdRpcIdentityUpdate) error {
+	state.identities = append(state.identities, update)
+	clearCachedReplies()
+	return nil
+}
+
+// DarksideClearIdentities removes all identity revisions.
+func DarksideClearIdentities() error {
+	state.identities = nil
+	clearCachedReplies()
+	return nil
+}
+
+// darksideFindCurrency returns the currency with the given ID or name, or nil.
+func darksideFindCurrency(name string) *ZcashdRpcReplyGetcurrency {
+	for i := range state.currencies {
+		c := &state.currencies[i]
+		if name == c.CurrencyID || strings.EqualFold(name, c.Name) ||
+			strings.EqualFold(name, c.FullyQualifiedName) {
+			return c
+		}
+	}
+	return nil
+}
+
+// darksideConvert converts the amount of currency from to currency to, one
+// of which must be a reserve of the other, at the basket's reserve price.
+func darksideConvert(amount int64, from, to *ZcashdRpcReplyGetcurrency) (int64, error) {
+	price := func(basket, reserve *ZcashdRpcReplyGetcurrency) *big.Int {
+		for _, r := range basket.BestCurrencyState.ReserveCurrencies {
+			if r.CurrencyID == reserve.CurrencyID {
+				if p, err := ParseAmount(r.PriceInReserve); err == nil && p > 0 {
+					return big.NewInt(p)
+				}
+			}
+		}
+		return nil
+	}
+	out := big.NewInt(amount)
+	if p := price(to, from); p != nil {
+		out.Mul(out, big.NewInt(satoshisPerCoin))
+		out.Div(out, p)
+	} else if p := price(from, to); p != nil {
+		out.Mul(out, p)
+		out.Div(out, big.NewInt(satoshisPerCoin))
+	} else {
+		return 0, errors.New("-8: no conversion path from " + from.CurrencyID + " to " + to.CurrencyID)
+	}
+	if !out.IsInt64() {
+		return 0, errors.New("-8: conversion overflow")
+	}
+	return out.Int64(), nil
+}
+
+func darksideEstimateConversion(params []json.RawMessage) (json.RawMessage, error) {
+	var req ZcashdRpcRequestEstimateconversion
+	err := json.Unmarshal(params[0], &req)
+	if err != nil {
+		return nil, errors.New("failed to parse estimateconversion JSON")
+	}
+	amount, err := ParseAmount(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	from := darksideFindCurrency(req.Currency)
+	to := darksideFindCurrency(req.ConvertTo)
+	if from == nil || to == nil {
+		return nil, errors.New("-5: Cannot find currency")
+	}
+	var out int64
+	if req.Via != "" {
+		via := darksideFindCurrency(req.Via)
+		if via == nil {
+			return nil, errors.New("-5: Cannot find currency " + req.Via)
+		}
+		if out, err = darksideConvert(amount, from, via); err == nil {
+			out, err = darksideConvert(out, via, to)
+		}
+	} else {
+		out, err = darksideConvert(amount, from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&ZcashdRpcReplyEstimateconversion{
+		InputCurrencyID:      from.CurrencyID,
+		NetInputAmount:       FormatAmount(amount),
+		OutputCurrencyID:     to.CurrencyID,
+		EstimatedCurrencyOut: FormatAmount(out),
+	})
+}
+
+// DarksideAddCurrency adds a currency to be returned by the getcurrency rpc,
+// replacing any with the same ID.
+func DarksideAddCurrency(currency ZcashdRpcReplyGetcurrency) error {
+	if currency.CurrencyID == "" {
+		return errors.New("currency has no ID")
+	}
+	for i := range state.currencies {
+		if state.currencies[i].CurrencyID == currency.CurrencyID {
+			state.currencies[i] = currency
+			clearCachedReplies()
+			return nil
+		}
+	}
+	state.currencies = append(state.currencies, currency)
+	clearCachedReplies()
+	return nil
+}
+
+// DarksideClearCurrencies removes all currencies.
+func DarksideClearCurrencies() error {
+	state.currencies = nil
+	clearCachedReplies()
+	return nil
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/walletrpc"
+)
+
+// The conventional transaction fee (DEFAULT_FEE in verusd's wallet), in
+// zatoshis; no fee is suggested below it.
+const minimumFee = 10000
+
+// The number of recent blocks whose fee rates are remembered, which is also
+// the greatest confirmation target.
+const feeBlockWindow = 100
+
+// The most block space transactions can take up (MAX_BLOCK_SIZE); a block
+// whose transactions use less than 90% of it had room for any transaction.
+const (
+	maxBlockTxBytes  = 2000000
+	fullBlockTxBytes = maxBlockTxBytes * 9 / 10
+)
+
+const zatoshisPerCoin = 100000000
+
+// The confirmation targets estimated if the client doesn't give any.
+var defaultFeeTargets = []uint32{1, 3, 6, 12}
+
+// The typical transactions that fees are suggested for.
+var txShapes = []*walletrpc.TxShape{
+	{Name: "transparent", TransparentInputs: 1, TransparentOutputs: 2},
+	{Name: "shielded", SaplingSpends: 1, SaplingOutputs: 2},
+	{Name: "shielding", TransparentInputs: 1, SaplingOutputs: 1},
+	{Name: "deshielding", TransparentOutputs: 1, SaplingSpends: 1, SaplingOutputs: 1},
+}
+
+func init() {
+	for _, shape := range txShapes {
+		shape.Size = uint32(parser.EstimateTxSize(int(shape.TransparentInputs), int(shape.TransparentOutputs),
+			int(shape.SaplingSpends), int(shape.SaplingOutputs)))
+	}
+}
+
+// The fee rate (zatoshis per 1000 bytes) each recent block required, by
+// height: the lowest rate among its transactions whose fees are known, or
+// zero if it had room to spare. Blocks that were full but whose fees aren't
+// known aren't included.
+var blockFeeRates struct {
+	byHeight map[int]uint64
+	latest   int
+	mutex    sync.Mutex
+}
+
+// feeRate returns the fee per 1000 bytes.
+func feeRate(fee uint64, size int) uint64 {
+	if size <= 0 {
+		return 0
+	}
+	return fee * 1000 / uint64(size)
+}
+
+// observeBlockFees records the fee rate that the block at the given height
+// required, from the fees of its transactions (other than the coinbase).
+func observeBlockFees(height int, fees []parser.TxFee) {
+	size := 0
+	var rate uint64
+	known := false
+	for _, fee := range fees {
+		size += fee.Size
+		if !fee.Known {
+			continue
+		}
+		if r := feeRate(fee.Fee, fee.Size); !known || r < rate {
+			rate = r
+			known = true
+		}
+	}
+	if size < fullBlockTxBytes {
+		rate = 0
+	} else if !known {
+		return
+	}
+
+	blockFeeRates.mutex.Lock()
+	defer blockFeeRates.mutex.Unlock()
+	if blockFeeRates.byHeight == nil {
+		blockFeeRates.byHeight = make(map[int]uint64)
+	}
+	if height > blockFeeRates.latest {
+		blockFeeRates.latest = height
+	}
+	if height <= blockFeeRates.latest-feeBlockWindow {
+		return
+	}
+	blockFeeRates.byHeight[height] = rate
+	for h := range blockFeeRates.byHeight {
+		if h <= blockFeeRates.latest-feeBlockWindow {
+			delete(blockFeeRates.byHeight, h)
+		}
+	}
+}
+
+// dropBlockFees forgets the fee rates of the blocks from the given height on,
+// which a reorg has removed.
+func dropBlockFees(height int) {
+	blockFeeRates.mutex.Lock()
+	defer blockFeeRates.mutex.Unlock()
+	for h := range blockFeeRates.byHeight {
+		if h >= height {
+			delete(blockFeeRates.byHeight, h)
+		}
+	}
+	if blockFeeRates.latest >= height {
+		blockFeeRates.latest = height - 1
+	}
+}
+
+// recentBlockFeeRates returns the recent blocks' fee rates, lowest first.
+func recentBlockFeeRates() []uint64 {
+	blockFeeRates.mutex.Lock()
+	defer blockFeeRates.mutex.Unlock()
+	rates := make([]uint64, 0, len(blockFeeRates.byHeight))
+	for _, rate := range blockFeeRates.byHeight {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+	return rates
+}
+
+// blockTargetFeeRate returns the rate at which a transaction would have been
+// mined in at least 1 in target recent blocks, so should wait about that many.
+func blockTargetFeeRate(rates []uint64, target int) uint64 {
+	if len(rates) == 0 {
+		return 0
+	}
+	// The rate that 1/target of the blocks required no more than.
+	return rates[(len(rates)+target-1)/target-1]
+}
+
+// mempoolFee is the fee and size of a mempool transaction.
+type mempoolFee struct {
+	rate uint64
+	size int
+}
+
+// mempoolFees returns the fees of the mempool transactions whose fees are
+// known, highest rate first.
+func mempoolFees() []mempoolFee {
+	mempool.mutex.Lock()
+	fees := make([]mempoolFee, 0, len(mempool.txs))
+	for _, event := range mempool.txs {
+		if event.feeKnown {
+			fees = append(fees, mempoolFee{rate: feeRate(event.fee, event.size), size: event.size})
+		}
+	}
+	mempool.mutex.Unlock()
+	sort.Slice(fees, func(i, j int) bool { return fees[i].rate > fees[j].rate })
+	return fees
+}
+
+// mempoolTargetFeeRate returns the rate that a transaction needs to be
+// mined ahead of enough of the mempool to fit in the next target blocks.
+func mempoolTargetFeeRate(fees []mempoolFee, target int) uint64 {
+	backlog := 0
+	for _, fee := range fees {
+		backlog += fee.size
+		if backlog > target*maxBlockTxBytes {
+			return fee.rate + 1
+		}
+	}
+	return 0
+}
+
+// daemonFeeRate returns verusd's estimate of the fee rate needed to be mined
+// within target blocks, or zero if it has none.
+func daemonFeeRate(cache *BlockCache, target int) uint64 {
+	result, err := cachedRequest(cache, "estimatefee", []json.RawMessage{json.RawMessage(strconv.Itoa(target))})
+	if err != nil {
+		return 0
+	}
+	// In coins per 1000 bytes; -1 if there isn't enough data.
+	var rate float64
+	if err = json.Unmarshal(result, &rate); err != nil || rate <= 0 {
+		return 0
+	}
+	return uint64(math.Round(rate * zatoshisPerCoin))
+}
+
+// GetFeeEstimate suggests fee rates, and fees for typical transactions, for
+// each confirmation target (in blocks).
+func GetFeeEstimate(cache *BlockCache, targets []uint32) (*walletrpc.FeeEstimateReply, error) {
+	if len(targets) == 0 {
+		targets = defaultFeeTargets
+	}
+	for _, target := range targets {
+		if target == 0 || target > feeBlockWindow {
+			return nil, InvalidArgumentError("targets",
+				"confirmation targets must be from 1 to "+strconv.Itoa(feeBlockWindow)+" blocks")
+		}
+	}
+	latest := cache.GetLatestHeight()
+	if latest == -1 {
+		return nil, NotReadyError("Cache is empty. Server is probably not yet ready")
+	}
+	blockRates := recentBlockFeeRates()
+	fees := mempoolFees()
+	reply := &walletrpc.FeeEstimateReply{
+		Height:             uint64(latest),
+		MinimumFee:         minimumFee,
+		Shapes:             txShapes,
+		ObservedBlocks:     uint32(len(blockRates)),
+		ObservedMempoolTxs: uint32(len(fees)),
+	}
+	for _, target := range targets {
+		estimate := &walletrpc.FeeEstimate{
+			Target:         target,
+			DaemonFeeRate:  daemonFeeRate(cache, int(target)),
+			BlockFeeRate:   blockTargetFeeRate(blockRates, int(target)),
+			MempoolFeeRate: mempoolTargetFeeRate(fees, int(target)),
+		}
+		estimate.FeeRate = estimate.DaemonFeeRate
+		if estimate.BlockFeeRate > estimate.FeeRate {
+			estimate.FeeRate = estimate.BlockFeeRate
+		}
+		if estimate.MempoolFeeRate > estimate.FeeRate {
+			estimate.FeeRate = estimate.MempoolFeeRate
+		}
+		for _, shape := range txShapes {
+			fee := estimate.FeeRate * uint64(shape.Size) / 1000
+			if fee < minimumFee {
+				fee = minimumFee
+			}
+			estimate.Fees = append(estimate.Fees, &walletrpc.ShapeFee{Shape: shape.Name, Fee: fee})
+		}
+		reply.Estimates = append(reply.Estimates, estimate)
+	}
+	return reply, nil
+}
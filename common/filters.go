@@ -0,0 +1,236 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/asherda/lightwalletd/parser/gcs"
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"google.golang.org/grpc/codes"
+)
+
+// When block filters are enabled (--block-filters), each cached block has a
+// filter (see walletrpc.BlockFilter) of the transparent scripts and outpoints
+// in it, so that wallets can find the blocks they need without revealing
+// their addresses. The filter headers form a chain from the cache's first
+// block.
+const (
+	filterPrefix     = "F" // key is "F" + block height, value is the block's filter (BlockFilter)
+	filterNextPrefix = "G" // key is "G" + chain ID, value is the height of the first block without a filter
+)
+
+// The number of blocks the ingestor builds filters for at a time while the
+// filters catch up with the cache.
+const filterBatch = 100
+
+// opReturn is the opcode that starts unspendable (data carrier) scripts.
+const opReturn = 0x6a
+
+// blockFilterItems returns what a block's filter contains: the scripts of
+// its transactions' transparent outputs and the outpoints they spend.
+func blockFilterItems(vtx []*walletrpc.CompactTx) [][]byte {
+	items := make([][]byte, 0)
+	for _, tx := range vtx {
+		for _, in := range tx.Vin {
+			outpoint := make([]byte, 0, 36)
+			outpoint = append(outpoint, in.PrevoutTxid...)
+			items = append(items, binary.LittleEndian.AppendUint32(outpoint, in.PrevoutIndex))
+		}
+		for _, out := range tx.Vout {
+			if len(out.ScriptPubKey) > 0 && out.ScriptPubKey[0] != opReturn {
+				items = append(items, out.ScriptPubKey)
+			}
+		}
+	}
+	return items
+}
+
+// Caller should hold c.mutex.Lock().
+func (c *BlockCache) storeFilterNext() {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, uint64(c.filterNext))
+	if err := c.ldb.Put([]byte(filterNextPrefix+c.verusID), data, nil); err != nil {
+		Log.Fatal("block filter height write failed: ", err)
+	}
+}
+
+// EnableBlockFilters makes the cache build a filter for each block as it's
+// added, and serve them. It's called once, at startup; the filters of blocks
+// already in the cache are built by the block ingestor before it adds more,
+// which, the first time, takes as long as downloading them again.
+func (c *BlockCache) EnableBlockFilters() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.filterNext = c.firstBlock
+	data, err := c.ldb.Get([]byte(filterNextPrefix+c.verusID), nil)
+	if err == nil && len(data) == 8 {
+		c.filterNext = int(binary.LittleEndian.Uint64(data))
+	}
+	// The cache may have been reset or redownloaded.
+	if c.filterNext > c.nextBlock {
+		c.filterNext = c.nextBlock
+	}
+	if c.filterNext < c.firstBlock {
+		c.filterNext = c.firstBlock
+	}
+	c.storeFilterNext()
+	Log.Info("Block filters cover ", c.filterNext-c.firstBlock, " blocks")
+}
+
+// BlockFiltersEnabled returns true if the cache builds and serves block
+// filters.
+func (c *BlockCache) BlockFiltersEnabled() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.filterNext >= 0
+}
+
+// revertFilters forgets the filters of the blocks from the given height on;
+// their records are removed with the blocks, or overwritten.
+// Caller should hold c.mutex.Lock().
+func (c *BlockCache) revertFilters(height int) {
+	if c.filterNext > height {
+		c.filterNext = height
+		c.storeFilterNext()
+	}
+}
+
+// Caller should hold (at least) c.mutex.RLock().
+func (c *BlockCache) readFilter(height int) *walletrpc.BlockFilter {
+	cacheResult, err := c.ldb.Get([]byte(filterPrefix+strconv.Itoa(height)), nil)
+	if err != nil || len(cacheResult) < 8 {
+		return nil
+	}
+	data := cacheResult[8:]
+	if !bytes.Equal(checksum(height, data), cacheResult[:8]) {
+		Log.Warning("bad block filter checksum at height: ", height)
+		return nil
+	}
+	filter := &walletrpc.BlockFilter{}
+	if err := proto.Unmarshal(data, filter); err != nil {
+		Log.Warning("block filter unmarshal at height: ", height, " failed: ", err)
+		return nil
+	}
+	return filter
+}
+
+// addFilter builds and stores the filter of the block with the given height
+// and hash, whose transactions have the given transparent parts. Filters are
+// added in order, each one next after the last.
+func (c *BlockCache) addFilter(height int, hash []byte, vtx []*walletrpc.CompactTx) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if height != c.filterNext {
+		return errors.New("block filter for height " + strconv.Itoa(height) +
+			" added out of order, expecting " + strconv.Itoa(c.filterNext))
+	}
+	prevHeader := make([]byte, 32)
+	if height > c.firstBlock {
+		prev := c.readFilter(height - 1)
+		if prev == nil {
+			return errors.New("no block filter at height " + strconv.Itoa(height-1))
+		}
+		prevHeader = prev.Header
+	}
+	data := gcs.Build(gcs.Key(hash), blockFilterItems(vtx))
+	filter := &walletrpc.BlockFilter{
+		Height:     uint64(height),
+		Hash:       hash,
+		Filter:     data,
+		Header:     gcs.Header(data, prevHeader),
+		PrevHeader: prevHeader,
+	}
+	record, err := proto.Marshal(filter)
+	if err != nil {
+		return err
+	}
+	checkSummed := append(checksum(height, record), record...)
+	if err = c.ldb.Put([]byte(filterPrefix+strconv.Itoa(height)), checkSummed, &opt.WriteOptions{Sync: false}); err != nil {
+		return err
+	}
+	c.filterNext++
+	c.storeFilterNext()
+	return nil
+}
+
+// filterNextHeight returns the height of the first block without a filter,
+// or -1 if block filters aren't enabled.
+func (c *BlockCache) filterNextHeight() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.filterNext
+}
+
+// catchUpFilters builds the filters of up to count blocks that are cached
+// without them, from their stored transparent parts or from zcashd.
+func (c *BlockCache) catchUpFilters(count int) error {
+	for ; count > 0; count-- {
+		height := c.filterNextHeight()
+		if height >= c.GetNextHeight() {
+			return nil
+		}
+		block, err := getTransparentBlock(c, height)
+		if err != nil {
+			return err
+		}
+		if cached := c.Get(height); cached != nil && string(cached.Hash) != string(block.Hash) {
+			Log.Info("REORG: dropping block ", height, " ", displayHash(cached.Hash))
+			c.Reorg(height)
+			return nil
+		}
+		if err = c.addFilter(height, block.Hash, block.Vtx); err != nil {
+			return err
+		}
+	}
+	if next := c.filterNextHeight(); next < c.GetNextHeight() {
+		Log.Info("Block filters at height ", next)
+	}
+	return nil
+}
+
+// GetBlockFilters calls f, in order, with the filter of each block from start
+// to end inclusive.
+func GetBlockFilters(cache *BlockCache, start, end int, f func(*walletrpc.BlockFilter) error) error {
+	if start > end {
+		return InvalidArgumentError("end", "end height is less than start height")
+	}
+	cache.mutex.RLock()
+	first, next, filterNext := cache.firstBlock, cache.nextBlock, cache.filterNext
+	cache.mutex.RUnlock()
+	if filterNext < 0 {
+		return newStatusError(codes.FailedPrecondition, "Block filters not enabled, start lightwalletd with --block-filters")
+	}
+	if start < first {
+		return OutOfRangeError("start", "block filters start at height "+strconv.Itoa(first))
+	}
+	if end >= next {
+		return OutOfRangeError("end", "block requested is newer than latest block")
+	}
+	if end >= filterNext {
+		return NotReadyError("Block filters are being built, at height " + strconv.Itoa(filterNext))
+	}
+	for height := start; height <= end; height++ {
+		cache.mutex.RLock()
+		var filter *walletrpc.BlockFilter
+		if height < cache.filterNext {
+			filter = cache.readFilter(height)
+		}
+		cache.mutex.RUnlock()
+		if filter == nil {
+			// Removed by a reorg since the range was checked.
+			return NotReadyError("Block filter at height " + strconv.Itoa(height) + " isn't available")
+		}
+		if err := f(filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
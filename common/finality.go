@@ -0,0 +1,135 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
+)
+
+// The latest notarization status seen, so that blocks and LightdInfo can
+// report finality without asking verusd.
+var notarizationStatus struct {
+	lastNotarizedHeight  uint64
+	lastFinalizedHeight  uint64
+	lastNotarizationTxid []byte
+	mutex                sync.RWMutex
+}
+
+// updateNotarizationStatus asks verusd for this chain's notarization data.
+func updateNotarizationStatus(cache *BlockCache) error {
+	result, err := cachedRequest(cache, "getblockchaininfo", []json.RawMessage{})
+	if err != nil {
+		return err
+	}
+	var blockchaininfo ZcashdRpcReplyGetblockchaininfo
+	if err = json.Unmarshal(result, &blockchaininfo); err != nil {
+		return errors.Wrap(err, "error reading JSON response")
+	}
+	if blockchaininfo.ChainID == "" {
+		return errors.New("notarization data isn't available, no chain ID")
+	}
+	chainIDJSON, err := json.Marshal(blockchaininfo.ChainID)
+	if err != nil {
+		return err
+	}
+	result, err = cachedRequest(cache, "getnotarizationdata", []json.RawMessage{chainIDJSON})
+	if err != nil {
+		return err
+	}
+	var reply ZcashdRpcReplyGetnotarizationdata
+	if err = json.Unmarshal(result, &reply); err != nil {
+		return errors.Wrap(err, "error reading JSON response")
+	}
+
+	// The latest notarization is the one with the greatest height on the
+	// best fork; confirmed notarizations are on every fork.
+	lastNotarizedHeight := reply.LastConfirmedHeight
+	var lastNotarizationTxid []byte
+	if reply.BestChain >= 0 && reply.BestChain < len(reply.Forks) {
+		for _, index := range reply.Forks[reply.BestChain] {
+			if index < 0 || index >= len(reply.Notarizations) {
+				return errors.New("bad notarization index in fork")
+			}
+			n := &reply.Notarizations[index]
+			if n.Notarization.NotarizationHeight >= lastNotarizedHeight {
+				lastNotarizedHeight = n.Notarization.NotarizationHeight
+				if lastNotarizationTxid, err = decodeHash(n.Txid); err != nil {
+					return errors.Wrap(err, "error decoding notarization txid")
+				}
+			}
+		}
+	}
+	notarizationStatus.mutex.Lock()
+	defer notarizationStatus.mutex.Unlock()
+	notarizationStatus.lastNotarizedHeight = lastNotarizedHeight
+	notarizationStatus.lastFinalizedHeight = reply.LastConfirmedHeight
+	notarizationStatus.lastNotarizationTxid = lastNotarizationTxid
+	return nil
+}
+
+// GetFinalizedHeight returns the latest notarized and confirmed block height
+// known, or zero. It doesn't ask verusd.
+func GetFinalizedHeight() uint64 {
+	notarizationStatus.mutex.RLock()
+	defer notarizationStatus.mutex.RUnlock()
+	return notarizationStatus.lastFinalizedHeight
+}
+
+// IsFinal reports whether the block at the given height is known to be
+// notarized and confirmed. It doesn't ask verusd.
+func IsFinal(height uint64) bool {
+	finalized := GetFinalizedHeight()
+	return finalized > 0 && height <= finalized
+}
+
+// GetFinalityStatus returns the finality of the block at the given height,
+// or of the latest block if height is zero.
+func GetFinalityStatus(cache *BlockCache, height uint64) (*walletrpc.FinalityStatus, error) {
+	if height == 0 {
+		latest := cache.GetLatestHeight()
+		if latest == -1 {
+			return nil, NotReadyError("Cache is empty. Server is probably not yet ready")
+		}
+		height = uint64(latest)
+	}
+	if err := updateNotarizationStatus(cache); err != nil {
+		return nil, err
+	}
+	notarizationStatus.mutex.RLock()
+	defer notarizationStatus.mutex.RUnlock()
+	return &walletrpc.FinalityStatus{
+		Height:               height,
+		Final:                notarizationStatus.lastFinalizedHeight > 0 && height <= notarizationStatus.lastFinalizedHeight,
+		LastNotarizedHeight:  notarizationStatus.lastNotarizedHeight,
+		LastFinalizedHeight:  notarizationStatus.lastFinalizedHeight,
+		LastNotarizationTxid: notarizationStatus.lastNotarizationTxid,
+	}, nil
+}
+
+// FinalityPoller keeps the notarization status up to date, checking it
+// after each new block; it runs forever (or rep times, for testing).
+func FinalityPoller(c *BlockCache, rep int) {
+	var lastHash []byte
+	loggedError := false
+	for i := 0; rep == 0 || i < rep; i++ {
+		if hash := c.GetLatestHash(); hash != nil && !bytes.Equal(hash, lastHash) {
+			lastHash = hash
+			if err := updateNotarizationStatus(c); err != nil {
+				// Not all chains are notarized, so only log this once.
+				if !loggedError {
+					loggedError = true
+					Log.Warn("can't get notarization status: ", err)
+				}
+			}
+		}
+		Time.Sleep(5 * time.Second)
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
+)
+
+// The number of transactions in a page of address history if the client
+// doesn't say, and the most it can ask for.
+const (
+	defaultHistoryPageSize = 100
+	maxHistoryPageSize     = 1000
+)
+
+// The number of a page's transactions requested from zcashd at once.
+const historyFetchConcurrency = 8
+
+// historyEntry is a transaction in an address history, with its net change
+// to each address's balance.
+type historyEntry struct {
+	txid       string // big-endian hex
+	height     uint64
+	blockIndex uint32
+	deltas     map[string]int64
+}
+
+// before reports whether e comes before (is older than) the given position.
+func (e *historyEntry) before(height uint64, blockIndex uint32) bool {
+	return e.height < height || (e.height == height && e.blockIndex < blockIndex)
+}
+
+// cursor identifies the transaction's place in the history, so that a
+// later request can resume after it.
+func (e *historyEntry) cursor() string {
+	return fmt.Sprintf("%d-%d", e.height, e.blockIndex)
+}
+
+// parseHistoryCursor is the inverse of historyEntry.cursor.
+func parseHistoryCursor(cursor string) (height uint64, blockIndex uint32, err error) {
+	parts := strings.SplitN(cursor, "-", 2)
+	if len(parts) == 2 {
+		height, err = strconv.ParseUint(parts[0], 10, 64)
+		if err == nil {
+			var index uint64
+			index, err = strconv.ParseUint(parts[1], 10, 32)
+			blockIndex = uint32(index)
+		}
+	}
+	if len(parts) != 2 || err != nil {
+		return 0, 0, InvalidArgumentError("cursor", "invalid history cursor")
+	}
+	return height, blockIndex, nil
+}
+
+// getHistoryEntries returns the transactions within the given block range
+// that involve the addresses, oldest first.
+func getHistoryEntries(addresses []string, start, end uint64) ([]*historyEntry, error) {
+	param, err := json.Marshal(&ZcashdRpcRequestGetaddressdeltas{
+		Addresses: addresses,
+		Start:     start,
+		End:       end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, rpcErr := RawRequest("getaddressdeltas", []json.RawMessage{param})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	var deltas []ZcashdRpcReplyGetaddressdeltas
+	if err = json.Unmarshal(result, &deltas); err != nil {
+		return nil, errors.Wrap(err, "error reading JSON response")
+	}
+	// There's a delta for each input and output, grouped by address.
+	byTxid := make(map[string]*historyEntry)
+	entries := make([]*historyEntry, 0)
+	for _, delta := range deltas {
+		entry, ok := byTxid[delta.Txid]
+		if !ok {
+			entry = &historyEntry{
+				txid:       delta.Txid,
+				height:     delta.Height,
+				blockIndex: delta.Blockindex,
+				deltas:     make(map[string]int64),
+			}
+			byTxid[delta.Txid] = entry
+			entries = append(entries, entry)
+		}
+		entry.deltas[delta.Address] += delta.Satoshis
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].before(entries[j].height, entries[j].blockIndex)
+	})
+	return entries, nil
+}
+
+// getHistoryTransaction returns the entry's transaction, as sent to clients.
+func getHistoryTransaction(entry *historyEntry) (*walletrpc.TaddressTransaction, error) {
+	txid, err := decodeHash(entry.txid)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding txid")
+	}
+	txidJSON, err := json.Marshal(entry.txid)
+	if err != nil {
+		return nil, err
+	}
+	result, rpcErr := RawRequest("getrawtransaction", []json.RawMessage{txidJSON, json.RawMessage("1")})
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	var txinfo ZcashdRpcReplyGetrawtransaction
+	if err = json.Unmarshal(result, &txinfo); err != nil {
+		return nil, errors.Wrap(err, "error reading JSON response")
+	}
+	txBytes, err := hex.DecodeString(txinfo.Hex)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding transaction")
+	}
+	addresses := make([]string, 0, len(entry.deltas))
+	for address := range entry.deltas {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	deltas := make([]*walletrpc.AddressDelta, len(addresses))
+	for i, address := range addresses {
+		deltas[i] = &walletrpc.AddressDelta{Address: address, ValueZat: entry.deltas[address]}
+	}
+	return &walletrpc.TaddressTransaction{
+		Txid:      txid,
+		Data:      txBytes,
+		Height:    entry.height,
+		BlockTime: uint32(txinfo.Blocktime),
+		Deltas:    deltas,
+		Cursor:    entry.cursor(),
+	}, nil
+}
+
+// GetTaddressHistory calls f, in order, for each transaction in the page of
+// the addresses' history that the request asks for. The transactions are
+// requested from zcashd several at a time.
+func GetTaddressHistory(ctx context.Context, cache *BlockCache, addresses []string, arg *walletrpc.GetTaddressHistoryArg, f func(*walletrpc.TaddressTransaction) error) error {
+	pageSize := int(arg.MaxEntries)
+	if pageSize == 0 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+	if arg.EndHeight > 0 && arg.EndHeight < arg.StartHeight {
+		return InvalidArgumentError("endHeight", "history end height is less than start height")
+	}
+	latest := cache.GetLatestHeight()
+	if latest == -1 {
+		return NotReadyError("Cache is empty. Server is probably not yet ready")
+	}
+	start, end := arg.StartHeight, arg.EndHeight
+	if end == 0 || end > uint64(latest) {
+		end = uint64(latest)
+	}
+	newestFirst := arg.Order == walletrpc.HistoryOrder_newestFirst
+
+	// Only the rest of the range (including the cursor's block) is needed.
+	var cursorHeight uint64
+	var cursorIndex uint32
+	if arg.Cursor != "" {
+		var err error
+		if cursorHeight, cursorIndex, err = parseHistoryCursor(arg.Cursor); err != nil {
+			return err
+		}
+		if newestFirst && cursorHeight < end {
+			end = cursorHeight
+		}
+		if !newestFirst && cursorHeight > start {
+			start = cursorHeight
+		}
+	}
+	if start > end {
+		return nil
+	}
+	entries, err := getHistoryEntries(addresses, start, end)
+	if err != nil {
+		return err
+	}
+	if newestFirst {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+	page := make([]*historyEntry, 0, pageSize)
+	for _, entry := range entries {
+		if len(page) == pageSize {
+			break
+		}
+		if arg.Cursor != "" {
+			atCursor := entry.height == cursorHeight && entry.blockIndex == cursorIndex
+			if atCursor || entry.before(cursorHeight, cursorIndex) != newestFirst {
+				continue
+			}
+		}
+		page = append(page, entry)
+	}
+
+	// Fetch the transactions concurrently, but send them in order.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type fetched struct {
+		tx  *walletrpc.TaddressTransaction
+		err error
+	}
+	results := make([]chan fetched, len(page))
+	for i := range results {
+		results[i] = make(chan fetched, 1)
+	}
+	go func() {
+		slots := make(chan struct{}, historyFetchConcurrency)
+		for i, entry := range page {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func(i int, entry *historyEntry) {
+				tx, err := getHistoryTransaction(entry)
+				results[i] <- fetched{tx, err}
+				<-slots
+			}(i, entry)
+		}
+	}()
+	for i := range page {
+		var result fetched
+		select {
+		case result = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if result.err != nil {
+			return result.err
+		}
+		if err = f(result.tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
+)
+
+func identityParams(identity string, heights ...uint64) ([]json.RawMessage, error) {
+	if identity == "" {
+		return nil, InvalidArgumentError("identity", "must specify an identity")
+	}
+	identityJSON, err := json.Marshal(identity)
+	if err != nil {
+		return nil, err
+	}
+	params := []json.RawMessage{identityJSON}
+	for _, height := range heights {
+		params = append(params, json.RawMessage(strconv.FormatUint(height, 10)))
+	}
+	return params, nil
+}
+
+func identityFromRPC(id *ZcashdRpcIdentity) *walletrpc.Identity {
+	return &walletrpc.Identity{
+		Version:             id.Version,
+		Flags:               id.Flags,
+		PrimaryAddresses:    id.PrimaryAddresses,
+		MinimumSignatures:   id.MinimumSignatures,
+		Name:                id.Name,
+		IdentityAddress:     id.IdentityAddress,
+		Parent:              id.Parent,
+		SystemID:            id.SystemID,
+		ContentMap:          id.ContentMap,
+		RevocationAuthority: id.RevocationAuthority,
+		RecoveryAuthority:   id.RecoveryAuthority,
+		PrivateAddress:      id.PrivateAddress,
+		TimeLock:            id.TimeLock,
+	}
+}
+
+// IdentityToRPC is the inverse of identityFromRPC.
+func IdentityToRPC(id *walletrpc.Identity) ZcashdRpcIdentity {
+	return ZcashdRpcIdentity{
+		Version:             id.Version,
+		Flags:               id.Flags,
+		PrimaryAddresses:    id.PrimaryAddresses,
+		MinimumSignatures:   id.MinimumSignatures,
+		Name:                id.Name,
+		IdentityAddress:     id.IdentityAddress,
+		Parent:              id.Parent,
+		SystemID:            id.SystemID,
+		ContentMap:          id.ContentMap,
+		RevocationAuthority: id.RevocationAuthority,
+		RecoveryAuthority:   id.RecoveryAuthority,
+		PrivateAddress:      id.PrivateAddress,
+		TimeLock:            id.TimeLock,
+	}
+}
+
+// decodeHash decodes a (big-endian) hex txid or block hash to the
+// little-endian byte order used by the walletrpc messages.
+func decodeHash(s string) ([]byte, error) {
+	hash, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return parser.Reverse(hash), nil
+}
+
+// GetIdentity returns the identity (friendly name or i-address) as of the
+// given height, or the latest block if height is zero.
+func GetIdentity(cache *BlockCache, identity string, height uint64) (*walletrpc.IdentityInfo, error) {
+	var params []json.RawMessage
+	var err error
+	if height > 0 {
+		params, err = identityParams(identity, height)
+	} else {
+		params, err = identityParams(identity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result, err := cachedRequest(cache, "getidentity", params)
+	if err != nil {
+		return nil, err
+	}
+	var reply ZcashdRpcReplyGetidentity
+	if err = json.Unmarshal(result, &reply); err != nil {
+		return nil, errors.Wrap(err, "error reading JSON response")
+	}
+	txid, err := decodeHash(reply.Txid)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding identity txid")
+	}
+	return &walletrpc.IdentityInfo{
+		Identity:           identityFromRPC(&reply.Identity),
+		FriendlyName:       reply.FriendlyName,
+		FullyQualifiedName: reply.FullyQualifiedName,
+		Status:             reply.Status,
+		CanSpendFor:        reply.CanSpendFor,
+		CanSignFor:         reply.CanSignFor,
+		BlockHeight:        uint64(reply.BlockHeight),
+		Txid:               txid,
+		Vout:               reply.Vout,
+	}, nil
+}
+
+// GetIdentityHistory calls f for each revision of the identity within the
+// given block range (an end of zero means the latest block), oldest first.
+func GetIdentityHistory(cache *BlockCache, identity string, start, end uint64, f func(*walletrpc.IdentityUpdate) error) error {
+	if end > 0 && end < start {
+		return InvalidArgumentError("endHeight", "identity history end height is less than start height")
+	}
+	params, err := identityParams(identity, start, end)
+	if err != nil {
+		return err
+	}
+	result, err := cachedRequest(cache, "getidentityhistory", params)
+	if err != nil {
+		return err
+	}
+	var reply ZcashdRpcReplyGetidentityhistory
+	if err = json.Unmarshal(result, &reply); err != nil {
+		return errors.Wrap(err, "error reading JSON response")
+	}
+	for i := range reply.History {
+		update := &reply.History[i]
+		blockHash, err := decodeHash(update.BlockHash)
+		if err != nil {
+			return errors.Wrap(err, "error decoding identity block hash")
+		}
+		txid, err := decodeHash(update.Output.Txid)
+		if err != nil {
+			return errors.Wrap(err, "error decoding identity txid")
+		}
+		err = f(&walletrpc.IdentityUpdate{
+			Identity:  identityFromRPC(&update.Identity),
+			BlockHash: blockHash,
+			Height:    uint64(update.Height),
+			Txid:      txid,
+			Vout:      update.Output.VoutNum,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
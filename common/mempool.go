@@ -1,147 +1,447 @@
 package common
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
 )
 
-type txid string
+// The number of mempool events that can be waiting for a subscriber; one that
+// falls further behind than this is dropped, rather than hold up the tracker.
+const mempoolQueueLength = 1000
 
-var (
-	// Set of mempool txids that have been seen during the current block interval.
-	// The zcashd RPC `getrawmempool` returns the entire mempool each time, so
-	// this allows us to ignore the txids that we've already seen.
-	g_txidSeen map[txid]struct{} = map[txid]struct{}{}
+// The most blocks that are fetched to find out why transactions left the
+// mempool; if more than this arrive at once, the earlier ones aren't checked.
+const maxMinedBlocksChecked = 10
 
-	// List of transactions during current block interval, in order received. Each
-	// client thread can keep an index into this slice to record which transactions
-	// it's sent back to the client (everything before that index). The g_txidSeen
-	// map allows this list to not contain duplicates.
-	g_txList []*walletrpc.RawTransaction
+// MempoolEventType is the kind of change a MempoolEvent reports.
+type MempoolEventType int
 
-	// The most recent absolute time that we fetched the mempool and the latest
-	// (tip) block hash (so we know when a new block has been mined).
-	g_lastTime time.Time
+const (
+	// MempoolAdd reports a transaction entering the mempool.
+	MempoolAdd MempoolEventType = iota
+	// MempoolRemove reports a transaction leaving the mempool (and the
+	// Reason).
+	MempoolRemove
+	// MempoolNewBlock reports that a new block has arrived (Height is its
+	// height); the removals of the transactions it mined follow.
+	MempoolNewBlock
+)
+
+// MempoolEvent is a change to the mempool, as published to subscribers.
+type MempoolEvent struct {
+	Type MempoolEventType
+	// Txid is in big-endian hex, as from getrawmempool (unset for a new block).
+	Txid string
+	Tx   *walletrpc.RawTransaction
+	// CompactTx is nil if the transaction has no Sapling elements.
+	CompactTx *walletrpc.CompactTx
+	// Height is the latest block height, or for a mined transaction, the
+	// height of the block that mined it.
+	Height uint64
+	Reason walletrpc.MempoolTxEvent_RemovalReason
+
+	// What the transaction spends (see spendKeys), and the last height at
+	// which it can be mined (zero if it doesn't expire).
+	spends       []string
+	expiryHeight uint64
+
+	// The transaction's fee, if zcashd reports it or it can be calculated
+	// (see parser.Fee), and size.
+	fee      uint64
+	feeKnown bool
+	size     int
+}
+
+// TxEvent returns the event as it's sent to clients, or nil for a new block.
+func (e *MempoolEvent) TxEvent() *walletrpc.MempoolTxEvent {
+	event := &walletrpc.MempoolTxEvent{
+		Reason:    e.Reason,
+		Height:    e.Height,
+		CompactTx: e.CompactTx,
+	}
+	switch e.Type {
+	case MempoolAdd:
+		event.Type = walletrpc.MempoolTxEvent_added
+	case MempoolRemove:
+		event.Type = walletrpc.MempoolTxEvent_removed
+	default:
+		return nil
+	}
+	// A txid that isn't hex (only in tests) is left out.
+	event.Txid, _ = decodeHash(e.Txid)
+	return event
+}
+
+// The state of the mempool, owned by MempoolTracker; clients only read it.
+var mempool struct {
+	// The transactions currently in the mempool, in the order they were seen,
+	// and the same transactions by txid.
+	txs    []*MempoolEvent
+	byTxid map[string]*MempoolEvent
 
 	// The most recent zcashd getblockchaininfo reply, for height and best block
 	// hash (tip) which is used to detect when a new block arrives.
-	g_lastBlockChainInfo *ZcashdRpcReplyGetblockchaininfo = &ZcashdRpcReplyGetblockchaininfo{}
+	blockChainInfo ZcashdRpcReplyGetblockchaininfo
 
-	// Mutex to protect the above variables.
-	g_lock sync.Mutex
-)
+	subscribers broadcaster[*MempoolEvent]
 
-func GetMempool(sendToClient func(*walletrpc.RawTransaction) error) error {
-	g_lock.Lock()
-	index := 0
-	// Stay in this function until the tip block hash changes.
-	stayHash := g_lastBlockChainInfo.BestBlockHash
+	// Protects the above; held while publishing, so that a new subscriber
+	// sees each change either in its snapshot or as an event, not both.
+	mutex sync.Mutex
+}
 
-	// Wait for more transactions to be added to the list
-	for {
-		// Don't fetch the mempool more often than every 2 seconds.
-		now := Time.Now()
-		if now.After(g_lastTime.Add(2 * time.Second)) {
-			blockChainInfo, err := getLatestBlockChainInfo()
-			if err != nil {
-				g_lock.Unlock()
-				return err
-			}
-			if g_lastBlockChainInfo.BestBlockHash != blockChainInfo.BestBlockHash {
-				// A new block has arrived
-				g_lastBlockChainInfo = blockChainInfo
-				Log.Infoln("Latest Block changed, clearing everything")
-				// We're the first thread to notice, clear cached state.
-				g_txidSeen = map[txid]struct{}{}
-				g_txList = []*walletrpc.RawTransaction{}
-				g_lastTime = time.Time{}
-				break
+// MempoolTracker polls zcashd's mempool every 2 seconds, on behalf of all
+// clients, and publishes the changes; it runs forever (or rep times, for
+// testing).
+func MempoolTracker(rep int) {
+	loggedError := false
+	for i := 0; rep == 0 || i < rep; i++ {
+		if err := updateMempool(); err != nil {
+			// Keep trying, but don't fill the log while zcashd is down.
+			if !loggedError {
+				loggedError = true
+				Log.Warn("can't update mempool: ", err)
 			}
-			if err = refreshMempoolTxns(); err != nil {
-				g_lock.Unlock()
-				return err
+		} else {
+			loggedError = false
+		}
+		Time.Sleep(2 * time.Second)
+	}
+}
+
+// SubscribeMempool returns the transactions now in the mempool (as MempoolAdd
+// events) and a subscription to the changes that follow; the caller must
+// Close it.
+func SubscribeMempool() ([]*MempoolEvent, *Subscription[*MempoolEvent]) {
+	mempool.mutex.Lock()
+	defer mempool.mutex.Unlock()
+	txs := append([]*MempoolEvent(nil), mempool.txs...)
+	return txs, mempool.subscribers.subscribe(mempoolQueueLength)
+}
+
+// GetMempool sends each mempool transaction to the client, as it arrives,
+// until a new block arrives, the client is dropped for falling behind, or
+// done is closed.
+func GetMempool(done <-chan struct{}, sendToClient func(*walletrpc.RawTransaction) error) error {
+	txs, sub := SubscribeMempool()
+	defer sub.Close()
+	for _, event := range txs {
+		if err := sendToClient(event.Tx); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-done:
+			return nil
+		case event, ok := <-sub.C:
+			if !ok {
+				return SlowClientError("mempool client is too slow, dropped")
 			}
-			g_lastTime = now
-		}
-		// Send transactions we haven't sent yet, best to not do so while
-		// holding the mutex, since this call may get flow-controlled.
-		toSend := g_txList[index:]
-		index = len(g_txList)
-		g_lock.Unlock()
-		for _, tx := range toSend {
-			if err := sendToClient(tx); err != nil {
-				return err
+			switch event.Type {
+			case MempoolAdd:
+				if err := sendToClient(event.Tx); err != nil {
+					return err
+				}
+			case MempoolNewBlock:
+				return nil
 			}
 		}
-		Time.Sleep(200 * time.Millisecond)
-		g_lock.Lock()
-		if g_lastBlockChainInfo.BestBlockHash != stayHash {
-			break
+	}
+}
+
+// GetMempoolTx sends the compact form of each mempool transaction that has
+// Sapling elements, except those whose txid (in the byte order of
+// CompactTx.Hash) starts with one of the exclude prefixes.
+func GetMempoolTx(exclude [][]byte, sendToClient func(*walletrpc.CompactTx) error) error {
+	if err := checkExclude("txid", exclude); err != nil {
+		return err
+	}
+	mempool.mutex.Lock()
+	txs := mempool.txs
+	mempool.mutex.Unlock()
+	for _, event := range txs {
+		if event.CompactTx == nil || isExcluded(event.CompactTx.Hash, exclude) {
+			continue
+		}
+		if err := sendToClient(event.CompactTx); err != nil {
+			return err
 		}
 	}
-	g_lock.Unlock()
 	return nil
 }
 
-// RefreshMempoolTxns gets all new mempool txns and sends any new ones to waiting clients
-func refreshMempoolTxns() error {
-	Log.Infoln("Refreshing mempool")
+// maxExclude is the most txid prefixes an exclude list may have; each
+// transaction sent is checked against all of them.
+const maxExclude = 1000
 
-	params := []json.RawMessage{}
-	result, rpcErr := RawRequest("getrawmempool", params)
-	if rpcErr != nil {
-		return rpcErr
+// checkExclude returns an error for the given request field if the exclude
+// list is too long, or any of its txid prefixes is empty or too long.
+func checkExclude(field string, exclude [][]byte) error {
+	if len(exclude) > maxExclude {
+		return InvalidArgumentError(field, "exclude list has more than "+strconv.Itoa(maxExclude)+" txid prefixes")
 	}
-	var mempoolList []string
-	err := json.Unmarshal(result, &mempoolList)
+	for _, prefix := range exclude {
+		if len(prefix) == 0 || len(prefix) > 32 {
+			return InvalidArgumentError(field, "bad txid prefix length in exclude list")
+		}
+	}
+	return nil
+}
+
+func isExcluded(txid []byte, exclude [][]byte) bool {
+	for _, prefix := range exclude {
+		if bytes.HasPrefix(txid, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateMempool fetches the mempool from zcashd and publishes the differences
+// from the last time. Only MempoolTracker calls it, so it can read the state
+// without the lock.
+func updateMempool() error {
+	blockChainInfo, err := getLatestBlockChainInfo()
+	if err != nil {
+		return err
+	}
+	// Verbose, for the fees, which can't be calculated from the transactions
+	// themselves if they have transparent inputs.
+	result, err := RawRequest("getrawmempool", []json.RawMessage{json.RawMessage("true")})
 	if err != nil {
 		return err
 	}
+	var entries map[string]ZcashdRpcReplyGetrawmempoolEntry
+	if err = json.Unmarshal(result, &entries); err != nil {
+		return err
+	}
+	// In the order they entered the mempool.
+	mempoolList := make([]string, 0, len(entries))
+	for txidstr := range entries {
+		mempoolList = append(mempoolList, txidstr)
+	}
+	sort.Slice(mempoolList, func(i, j int) bool {
+		ti, tj := entries[mempoolList[i]].Time, entries[mempoolList[j]].Time
+		return ti < tj || ti == tj && mempoolList[i] < mempoolList[j]
+	})
+	height := uint64(blockChainInfo.Blocks)
 
-	// Fetch all new mempool txns and add them into `newTxns`
+	// Fetch the new transactions before taking the lock.
+	current := make(map[string]struct{}, len(mempoolList))
+	var added []*MempoolEvent
 	for _, txidstr := range mempoolList {
-		if _, ok := g_txidSeen[txid(txidstr)]; ok {
-			// We've already fetched this transaction
+		current[txidstr] = struct{}{}
+		if _, ok := mempool.byTxid[txidstr]; ok {
 			continue
 		}
-		g_txidSeen[txid(txidstr)] = struct{}{}
-		// We haven't fetched this transaction already.
-		txidJSON, err := json.Marshal(txidstr)
+		txBytes, err := getRawTransaction(txidstr)
 		if err != nil {
-			return err
-		}
-		// The "0" is because we only need the raw hex, which is returned as
-		// just a hex string, and not even a json string (with quotes).
-		params := []json.RawMessage{txidJSON, json.RawMessage("0")}
-		result, rpcErr := RawRequest("getrawtransaction", params)
-		if rpcErr != nil {
 			// Not an error; mempool transactions can disappear
 			continue
 		}
-		// strip the quotes
-		var txStr string
-		err = json.Unmarshal(result, &txStr)
-		if err != nil {
-			return err
+		Log.Infoln("appending", txidstr)
+		event := &MempoolEvent{
+			Type:   MempoolAdd,
+			Txid:   txidstr,
+			Tx:     &walletrpc.RawTransaction{Data: txBytes, Height: height},
+			Height: height,
 		}
-		txBytes, err := hex.DecodeString(txStr)
-		if err != nil {
-			return err
+		parseMempoolTx(event, txBytes)
+		if fee, err := ParseAmount(entries[txidstr].Fee); err == nil && fee >= 0 {
+			event.fee, event.feeKnown = uint64(fee), true
 		}
-		Log.Infoln("appending", txidstr)
-		newRtx := &walletrpc.RawTransaction{
-			Data:   txBytes,
-			Height: uint64(g_lastBlockChainInfo.Blocks),
+		added = append(added, event)
+	}
+	var kept, removed []*MempoolEvent
+	for _, event := range mempool.txs {
+		if _, ok := current[event.Txid]; ok {
+			kept = append(kept, event)
+		} else {
+			removed = append(removed, event)
 		}
-		g_txList = append(g_txList, newRtx)
 	}
+
+	// Find out why the transactions were removed: what the new blocks mined
+	// and spent, and what the mempool now spends.
+	newBlock := mempool.blockChainInfo.BestBlockHash != blockChainInfo.BestBlockHash ||
+		mempool.blockChainInfo.Blocks != blockChainInfo.Blocks
+	mined := make(map[string]uint64)
+	spent := make(map[string]struct{})
+	if newBlock && len(removed) > 0 {
+		start := mempool.blockChainInfo.Blocks + 1
+		if start > blockChainInfo.Blocks {
+			// reorg
+			start = blockChainInfo.Blocks
+		}
+		if start <= blockChainInfo.Blocks-maxMinedBlocksChecked {
+			start = blockChainInfo.Blocks - maxMinedBlocksChecked + 1
+		}
+		for h := start; h <= blockChainInfo.Blocks; h++ {
+			if err := getBlockSpends(h, mined, spent); err != nil {
+				return err
+			}
+		}
+	}
+	for _, events := range [][]*MempoolEvent{kept, added} {
+		for _, event := range events {
+			for _, key := range event.spends {
+				spent[key] = struct{}{}
+			}
+		}
+	}
+
+	mempool.mutex.Lock()
+	defer mempool.mutex.Unlock()
+	if newBlock {
+		mempool.blockChainInfo = *blockChainInfo
+		mempool.subscribers.broadcast(&MempoolEvent{Type: MempoolNewBlock, Height: height})
+	}
+	for _, event := range removed {
+		delete(mempool.byTxid, event.Txid)
+		removal := &MempoolEvent{
+			Type:      MempoolRemove,
+			Txid:      event.Txid,
+			Tx:        event.Tx,
+			CompactTx: event.CompactTx,
+			Height:    height,
+			Reason:    walletrpc.MempoolTxEvent_evicted,
+		}
+		if minedHeight, ok := mined[event.Txid]; ok {
+			removal.Height = minedHeight
+			removal.Reason = walletrpc.MempoolTxEvent_mined
+		} else if isSpent(event.spends, spent) {
+			removal.Reason = walletrpc.MempoolTxEvent_conflicted
+		} else if event.expiryHeight > 0 && height >= event.expiryHeight {
+			removal.Reason = walletrpc.MempoolTxEvent_expired
+		}
+		Log.Infoln("removing", event.Txid, removal.Reason)
+		mempool.subscribers.broadcast(removal)
+		trackMempoolEvent(removal)
+	}
+	if mempool.byTxid == nil {
+		mempool.byTxid = make(map[string]*MempoolEvent)
+	}
+	for _, event := range added {
+		mempool.byTxid[event.Txid] = event
+		mempool.subscribers.broadcast(event)
+		trackMempoolEvent(event)
+	}
+	mempool.txs = append(kept, added...)
 	return nil
 }
 
+// getBlockSpends adds the txids of the transactions in the block at the given
+// height to mined, and the funds they spend (see spendKeys) to spent.
+func getBlockSpends(height int, mined map[string]uint64, spent map[string]struct{}) error {
+	heightJSON, err := json.Marshal(strconv.Itoa(height))
+	if err != nil {
+		return err
+	}
+	params := []json.RawMessage{heightJSON, json.RawMessage("0")} // non-verbose (raw hex)
+	result, err := RawRequest("getblock", params)
+	if err != nil {
+		return errors.Wrap(err, "error requesting block")
+	}
+	var blockHex string
+	if err = json.Unmarshal(result, &blockHex); err != nil {
+		return errors.Wrap(err, "error reading JSON response")
+	}
+	reader := parser.NewBlockReader(hex.NewDecoder(bytes.NewReader([]byte(blockHex))))
+	for {
+		tx, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "error parsing block")
+		}
+		mined[hex.EncodeToString(tx.GetDisplayHash())] = uint64(height)
+		for _, key := range spendKeys(tx) {
+			spent[key] = struct{}{}
+		}
+	}
+}
+
+// spendKeys returns a key for each transparent output and each nullifier that
+// the transaction spends; two transactions that share a key conflict.
+func spendKeys(tx *parser.Transaction) []string {
+	var keys []string
+	for _, outpoint := range tx.SpentOutpoints() {
+		index := make([]byte, 4)
+		binary.LittleEndian.PutUint32(index, outpoint.Index)
+		keys = append(keys, "o"+string(outpoint.Hash)+string(index))
+	}
+	for _, nf := range tx.Nullifiers() {
+		keys = append(keys, "n"+string(nf))
+	}
+	return keys
+}
+
+func isSpent(keys []string, spent map[string]struct{}) bool {
+	for _, key := range keys {
+		if _, ok := spent[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getRawTransaction returns the transaction with the given (big-endian hex)
+// txid.
+func getRawTransaction(txidstr string) ([]byte, error) {
+	txidJSON, err := json.Marshal(txidstr)
+	if err != nil {
+		return nil, err
+	}
+	// The "0" is because we only need the raw hex, which is returned as
+	// just a hex string, and not even a json string (with quotes).
+	params := []json.RawMessage{txidJSON, json.RawMessage("0")}
+	result, err := RawRequest("getrawtransaction", params)
+	if err != nil {
+		return nil, err
+	}
+	// strip the quotes
+	var txStr string
+	if err = json.Unmarshal(result, &txStr); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(txStr)
+}
+
+// parseMempoolTx sets the event's compact transaction (if it has Sapling
+// elements), what it spends, its expiry height and its fee. A transaction
+// that can't be parsed has none of these.
+func parseMempoolTx(event *MempoolEvent, txBytes []byte) {
+	tx := parser.NewTransaction()
+	rest, err := tx.ParseFromSlice(txBytes)
+	if err == nil && len(rest) != 0 {
+		err = errors.New("extra data after transaction")
+	}
+	if err != nil {
+		Log.Warn("can't parse mempool transaction: ", err)
+		return
+	}
+	if tx.HasSaplingElements() {
+		event.CompactTx = tx.ToCompact(0)
+	}
+	event.spends = spendKeys(tx)
+	event.expiryHeight = uint64(tx.ExpiryHeight())
+	event.fee, event.feeKnown = tx.Fee()
+	event.size = len(txBytes)
+}
+
 func getLatestBlockChainInfo() (*ZcashdRpcReplyGetblockchaininfo, error) {
 	result, rpcErr := RawRequest("getblockchaininfo", []json.RawMessage{})
 	if rpcErr != nil {
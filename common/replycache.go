@@ -0,0 +1,67 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// The number of replies cached before the cache is emptied.
+const maxCachedReplies = 10000
+
+// Replies from verusd that can change only when the best block does, such as
+// those about identities, currencies, notarizations and fee estimates, are
+// cached until the cache's latest block hash changes. This one cache is shared
+// by all such requests; use cachedRequest rather than adding another.
+var cachedReplies struct {
+	tipHash []byte
+	replies map[string]json.RawMessage
+	mutex   sync.Mutex
+}
+
+// clearCachedReplies empties the reply cache.
+func clearCachedReplies() {
+	cachedReplies.mutex.Lock()
+	defer cachedReplies.mutex.Unlock()
+	cachedReplies.replies = nil
+}
+
+// cachedRequest is RawRequest with the reply cached.
+func cachedRequest(cache *BlockCache, method string, params []json.RawMessage) (json.RawMessage, error) {
+	key := method
+	for _, param := range params {
+		key += " " + string(param)
+	}
+	tipHash := cache.GetLatestHash()
+
+	cachedReplies.mutex.Lock()
+	if !bytes.Equal(cachedReplies.tipHash, tipHash) {
+		cachedReplies.tipHash = tipHash
+		cachedReplies.replies = nil
+	}
+	result, ok := cachedReplies.replies[key]
+	cachedReplies.mutex.Unlock()
+	if ok {
+		return result, nil
+	}
+
+	result, rpcErr := RawRequest(method, params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	cachedReplies.mutex.Lock()
+	defer cachedReplies.mutex.Unlock()
+	// Don't cache a reply that may predate a new block.
+	if bytes.Equal(cachedReplies.tipHash, tipHash) {
+		if cachedReplies.replies == nil || len(cachedReplies.replies) >= maxCachedReplies {
+			cachedReplies.replies = make(map[string]json.RawMessage)
+		}
+		cachedReplies.replies[key] = result
+	}
+	return result, nil
+}
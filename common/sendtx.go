@@ -0,0 +1,145 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// Transactions expiring within this many blocks of the next one aren't
+// accepted into the mempool (TX_EXPIRING_SOON_THRESHOLD in verusd).
+const txExpiringSoonThreshold = 3
+
+// The transaction format required by each of Verus's consensus branches, by
+// branch ID (as in GetLightdInfo's ConsensusBranchId). Version zero means
+// the transaction must not be overwintered.
+var branchTxFormats = map[string]struct {
+	version        uint32
+	versionGroupID uint32
+}{
+	// Sprout, before Overwinter activates (as on a new regtest chain).
+	"00000000": {0, 0},
+	// Overwinter: v3 transactions.
+	"5ba81b19": {3, parser.OverwinterVersionGroupID},
+	// Sapling: v4 transactions, the current Verus format (Verus has no
+	// later network upgrades with branch IDs of their own).
+	"76b809bb": {4, parser.SaplingVersionGroupID},
+}
+
+// The branch IDs not in branchTxFormats that have been logged, so that
+// each is logged only once.
+var unknownBranches sync.Map
+
+// badTransaction returns an InvalidArgument error for a transaction that
+// can never be valid. The ErrorInfo reason is the one verusd would give.
+func badTransaction(reason, description string) error {
+	return newStatusError(codes.InvalidArgument, description,
+		&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "data", Description: description},
+		}},
+	)
+}
+
+// unacceptableTransaction returns an error with the given code for a
+// transaction that can't be accepted in the chain's current state.
+func unacceptableTransaction(code codes.Code, reason, subject, description string) error {
+	return newStatusError(code, description,
+		&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain},
+		&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: reason, Subject: subject, Description: description},
+		}},
+	)
+}
+
+// CheckSendTransaction checks a transaction a client wants to submit, so
+// that one verusd would certainly reject is rejected without asking it.
+// The error is a gRPC status whose details say why.
+func CheckSendTransaction(cache *BlockCache, txData []byte) error {
+	if maxTxSize := parser.GetLimits().MaxTxSize; len(txData) > maxTxSize {
+		return badTransaction("bad-txns-oversize",
+			fmt.Sprintf("transaction size %d exceeds limit %d", len(txData), maxTxSize))
+	}
+	tx := parser.NewTransaction()
+	rest, err := tx.ParseFromSlice(txData)
+	if err != nil {
+		return badTransaction("bad-txns-parse", "transaction can't be parsed: "+err.Error())
+	}
+	if len(rest) > 0 {
+		return badTransaction("bad-txns-trailing-data",
+			fmt.Sprintf("%d bytes follow the transaction", len(rest)))
+	}
+	if err = tx.CheckStructure(); err != nil {
+		return badTransaction(err.Error(), "transaction is malformed: "+err.Error())
+	}
+
+	txid := hex.EncodeToString(tx.GetDisplayHash())
+	if old, ok := getTxStatus(txid); ok {
+		switch old.status {
+		case walletrpc.TransactionStatus_inMempool:
+			return unacceptableTransaction(codes.AlreadyExists, "txn-already-in-mempool", txid,
+				"transaction is already in the mempool")
+		case walletrpc.TransactionStatus_mined:
+			return unacceptableTransaction(codes.AlreadyExists, "txn-already-known", txid,
+				fmt.Sprintf("transaction was mined at height %d", old.height))
+		}
+	}
+
+	if latest := cache.GetLatestHeight(); latest >= 0 && tx.ExpiryHeight() != 0 {
+		nextHeight := uint64(latest) + 1
+		expiry := uint64(tx.ExpiryHeight())
+		if nextHeight > expiry {
+			return unacceptableTransaction(codes.FailedPrecondition, "tx-overwinter-expired", "expiryHeight",
+				fmt.Sprintf("transaction expired at height %d, the next block is %d", expiry, nextHeight))
+		}
+		if nextHeight+txExpiringSoonThreshold > expiry {
+			return unacceptableTransaction(codes.FailedPrecondition, "tx-expiring-soon", "expiryHeight",
+				fmt.Sprintf("transaction expires at height %d, too soon after the next block %d", expiry, nextHeight))
+		}
+	}
+
+	// The consensus branch check is best-effort; if verusd can't be asked,
+	// it will be asked to accept the transaction anyway.
+	result, rpcErr := cachedRequest(cache, "getblockchaininfo", []json.RawMessage{})
+	if rpcErr != nil {
+		return nil
+	}
+	var blockchaininfo ZcashdRpcReplyGetblockchaininfo
+	if err = json.Unmarshal(result, &blockchaininfo); err != nil {
+		return nil
+	}
+	branchID := consensusBranchID(&blockchaininfo)
+	format, ok := branchTxFormats[branchID]
+	if !ok {
+		if _, logged := unknownBranches.LoadOrStore(branchID, true); !logged {
+			Log.WithFields(logrus.Fields{
+				"branch": branchID,
+			}).Warn("unknown consensus branch, transactions won't be checked against it")
+		}
+		return nil
+	}
+	switch {
+	case format.version == 0 && tx.IsOverwintered():
+		return unacceptableTransaction(codes.FailedPrecondition, "tx-overwinter-not-active", "consensusBranchId",
+			"overwintered transaction isn't valid on consensus branch "+branchID)
+	case format.version > 0 && !tx.IsOverwintered():
+		return unacceptableTransaction(codes.FailedPrecondition, "tx-overwinter-flag-not-set", "consensusBranchId",
+			"transaction must be overwintered on consensus branch "+branchID)
+	case format.version > 0 && (tx.Version() != format.version || tx.VersionGroupID() != format.versionGroupID):
+		return unacceptableTransaction(codes.FailedPrecondition, "bad-tx-version-for-branch", "consensusBranchId",
+			fmt.Sprintf("transaction version %d (group %08x) isn't valid on consensus branch %s",
+				tx.Version(), tx.VersionGroupID(), branchID))
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// The domains of the ErrorInfo details attached to errors: those found by
+// lightwalletd itself, and those returned by verusd (in the "zcashd"
+// domain), whose JSON-RPC error code and message are in the metadata.
+const (
+	errorDomain       = "lightwalletd"
+	zcashdErrorDomain = "zcashd"
+)
+
+// How long clients are asked to wait before retrying when verusd or the
+// cache isn't ready.
+const unavailableRetryDelay = 10 * time.Second
+
+// verusd's JSON-RPC error codes (src/rpc/protocol.h).
+const (
+	rpcMiscError               = -1
+	rpcTypeError               = -3
+	rpcInvalidAddressOrKey     = -5
+	rpcInvalidParameter        = -8
+	rpcClientNotConnected      = -9
+	rpcClientInInitialDownload = -10
+	rpcDatabaseError           = -20
+	rpcDeserializationError    = -22
+	rpcVerifyError             = -25
+	rpcVerifyRejected          = -26
+	rpcVerifyAlreadyInChain    = -27
+	rpcInWarmup                = -28
+	rpcInvalidRequest          = -32600
+	rpcMethodNotFound          = -32601
+	rpcInvalidParams           = -32602
+	rpcInternalError           = -32603
+	rpcParseError              = -32700
+)
+
+// The names of verusd's error codes, used as the ErrorInfo reason.
+var rpcErrorNames = map[int64]string{
+	rpcMiscError:               "RPC_MISC_ERROR",
+	rpcTypeError:               "RPC_TYPE_ERROR",
+	rpcInvalidAddressOrKey:     "RPC_INVALID_ADDRESS_OR_KEY",
+	rpcInvalidParameter:        "RPC_INVALID_PARAMETER",
+	rpcClientNotConnected:      "RPC_CLIENT_NOT_CONNECTED",
+	rpcClientInInitialDownload: "RPC_CLIENT_IN_INITIAL_DOWNLOAD",
+	rpcDatabaseError:           "RPC_DATABASE_ERROR",
+	rpcDeserializationError:    "RPC_DESERIALIZATION_ERROR",
+	rpcVerifyError:             "RPC_VERIFY_ERROR",
+	rpcVerifyRejected:          "RPC_VERIFY_REJECTED",
+	rpcVerifyAlreadyInChain:    "RPC_VERIFY_ALREADY_IN_CHAIN",
+	rpcInWarmup:                "RPC_IN_WARMUP",
+	rpcInvalidRequest:          "RPC_INVALID_REQUEST",
+	rpcMethodNotFound:          "RPC_METHOD_NOT_FOUND",
+	rpcInvalidParams:           "RPC_INVALID_PARAMS",
+	rpcInternalError:           "RPC_INTERNAL_ERROR",
+	rpcParseError:              "RPC_PARSE_ERROR",
+}
+
+// ParseRPCError returns verusd's JSON-RPC error code and message from an
+// error returned (possibly wrapped) by RawRequest; ok is false if the error
+// didn't come from verusd.
+func ParseRPCError(err error) (code int64, message string, ok bool) {
+	if err == nil {
+		return 0, "", false
+	}
+	var rpcErr *btcjson.RPCError
+	if errors.As(err, &rpcErr) {
+		return int64(rpcErr.Code), rpcErr.Message, true
+	}
+	// For some reason, the error responses are not JSON, they're "code: message".
+	parts := strings.SplitN(errors.Cause(err).Error(), ":", 2)
+	if len(parts) < 2 {
+		return 0, "", false
+	}
+	code, parseErr := strconv.ParseInt(parts[0], 10, 64)
+	if parseErr != nil {
+		return 0, "", false
+	}
+	return code, strings.TrimSpace(parts[1]), true
+}
+
+// rpcStatusCode returns the gRPC code for verusd's error code and message.
+func rpcStatusCode(code int64, message string) codes.Code {
+	switch code {
+	case rpcInvalidAddressOrKey:
+		// Also returned for unknown transactions and blocks.
+		if strings.HasPrefix(message, "Invalid") {
+			return codes.InvalidArgument
+		}
+		return codes.NotFound
+	case rpcInvalidParameter:
+		if strings.Contains(strings.ToLower(message), "out of range") {
+			return codes.OutOfRange
+		}
+		return codes.InvalidArgument
+	case rpcTypeError, rpcDeserializationError, rpcInvalidRequest, rpcInvalidParams, rpcParseError:
+		return codes.InvalidArgument
+	case rpcClientNotConnected, rpcClientInInitialDownload, rpcInWarmup:
+		return codes.Unavailable
+	case rpcVerifyError, rpcVerifyRejected:
+		return codes.FailedPrecondition
+	case rpcVerifyAlreadyInChain:
+		return codes.AlreadyExists
+	case rpcMethodNotFound:
+		return codes.Unimplemented
+	}
+	return codes.Internal
+}
+
+// newStatusError returns a gRPC status error with the given details.
+func newStatusError(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st, err := status.New(code, message).WithDetails(details...)
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+func retryInfo() *errdetails.RetryInfo {
+	return &errdetails.RetryInfo{RetryDelay: durationpb.New(unavailableRetryDelay)}
+}
+
+// StatusError converts an error from a handler to a gRPC status error that
+// clients can act on: verusd's errors are mapped by their JSON-RPC code, and
+// anything unexpected is Internal. Status errors are returned unchanged.
+func StatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	if code, message, ok := ParseRPCError(err); ok {
+		reason, ok := rpcErrorNames[code]
+		if !ok {
+			reason = "RPC_ERROR"
+		}
+		grpcCode := rpcStatusCode(code, message)
+		details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+			Reason: reason,
+			Domain: zcashdErrorDomain,
+			Metadata: map[string]string{
+				"code":    strconv.FormatInt(code, 10),
+				"message": message,
+			},
+		}}
+		if grpcCode == codes.Unavailable {
+			details = append(details, retryInfo())
+		}
+		return newStatusError(grpcCode, err.Error(), details...)
+	}
+	// verusd couldn't be reached.
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return newStatusError(codes.Unavailable, err.Error(), retryInfo())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// InvalidArgumentError returns an InvalidArgument error for the given
+// request field.
+func InvalidArgumentError(field, description string) error {
+	return newStatusError(codes.InvalidArgument, description, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+}
+
+// OutOfRangeError returns an OutOfRange error for the given request field,
+// such as a block height beyond the latest block.
+func OutOfRangeError(field, description string) error {
+	return newStatusError(codes.OutOfRange, description, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+}
+
+// NotReadyError returns an Unavailable error asking the client to retry,
+// for when the cache or verusd isn't ready yet.
+func NotReadyError(description string) error {
+	return newStatusError(codes.Unavailable, description, retryInfo())
+}
+
+// SlowClientError returns a ResourceExhausted error for a streaming client
+// that didn't keep up with updates and was dropped.
+func SlowClientError(description string) error {
+	return newStatusError(codes.ResourceExhausted, description,
+		&errdetails.ErrorInfo{Reason: "CLIENT_TOO_SLOW", Domain: errorDomain})
+}
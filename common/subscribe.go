@@ -0,0 +1,75 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"sync"
+
+	"github.com/asherda/lightwalletd/walletrpc"
+)
+
+// The number of block updates that can be waiting for a subscriber; one that
+// falls further behind than this is dropped, rather than hold up ingestion.
+const blockQueueLength = 100
+
+// Subscription receives the events published by a broadcaster.
+type Subscription[T any] struct {
+	// C is closed if the subscriber falls too far behind.
+	C <-chan T
+	c chan T
+	b *broadcaster[T]
+}
+
+// BlockSubscription receives the updates to the tip of the best chain.
+type BlockSubscription = Subscription[*walletrpc.BlockUpdate]
+
+// broadcaster fans out each event to all subscribers, so that they all share
+// the one copy of it, and the publisher never waits for a subscriber.
+type broadcaster[T any] struct {
+	subscribers map[*Subscription[T]]struct{}
+	mutex       sync.Mutex
+}
+
+// subscribe returns a new subscription that can queue up to n events.
+func (b *broadcaster[T]) subscribe(n int) *Subscription[T] {
+	c := make(chan T, n)
+	sub := &Subscription[T]{C: c, c: c, b: b}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.subscribers == nil {
+		b.subscribers = make(map[*Subscription[T]]struct{})
+	}
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (b *broadcaster[T]) broadcast(event T) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for sub := range b.subscribers {
+		select {
+		case sub.c <- event:
+		default:
+			delete(b.subscribers, sub)
+			close(sub.c)
+		}
+	}
+}
+
+// Close stops the subscription; it's safe to call more than once.
+func (sub *Subscription[T]) Close() {
+	sub.b.mutex.Lock()
+	defer sub.b.mutex.Unlock()
+	if _, ok := sub.b.subscribers[sub]; ok {
+		delete(sub.b.subscribers, sub)
+		close(sub.c)
+	}
+}
+
+// Subscribe returns a subscription to the blocks added to the cache, and to
+// reorgs; the caller must Close it.
+func (c *BlockCache) Subscribe() *BlockSubscription {
+	return c.subscribers.subscribe(blockQueueLength)
+}
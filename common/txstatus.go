@@ -0,0 +1,269 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/asherda/lightwalletd/parser"
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
+)
+
+// The most transactions whose status is remembered; beyond this, the ones
+// first seen longest ago are forgotten.
+const maxTrackedTxs = 100000
+
+// The number of status changes (of any transaction) that can be waiting for
+// a client watching one transaction, before it's dropped.
+const txStatusQueueLength = 10000
+
+type txStatus struct {
+	status walletrpc.TransactionStatus_Status
+	height uint64 // the block that mined it
+	reason string // why it was rejected
+}
+
+// The status of the transactions submitted by clients or seen in the mempool,
+// by txid (big-endian hex). Other transactions' status is looked up each time
+// it's asked for, with the reply cached only until the next block, so that
+// clients can't push these out by asking about other transactions.
+var txStatuses struct {
+	byTxid map[string]txStatus
+	order  []string // txids in the order they were first seen
+
+	// Each change is published as the txid.
+	subscribers broadcaster[string]
+
+	mutex sync.Mutex
+}
+
+// setTxStatus records the transaction's new status and publishes the change.
+func setTxStatus(txid string, status txStatus) {
+	txStatuses.mutex.Lock()
+	defer txStatuses.mutex.Unlock()
+	old, ok := txStatuses.byTxid[txid]
+	if ok && old == status {
+		return
+	}
+	if !ok {
+		if txStatuses.byTxid == nil {
+			txStatuses.byTxid = make(map[string]txStatus)
+		}
+		if len(txStatuses.order) >= maxTrackedTxs {
+			delete(txStatuses.byTxid, txStatuses.order[0])
+			txStatuses.order = txStatuses.order[1:]
+		}
+		txStatuses.order = append(txStatuses.order, txid)
+	}
+	txStatuses.byTxid[txid] = status
+	txStatuses.subscribers.broadcast(txid)
+}
+
+func getTxStatus(txid string) (txStatus, bool) {
+	txStatuses.mutex.Lock()
+	defer txStatuses.mutex.Unlock()
+	status, ok := txStatuses.byTxid[txid]
+	return status, ok
+}
+
+// forgetTxStatus stops tracking the transaction, so that its status is
+// looked up again.
+func forgetTxStatus(txid string) {
+	txStatuses.mutex.Lock()
+	defer txStatuses.mutex.Unlock()
+	if _, ok := txStatuses.byTxid[txid]; !ok {
+		return
+	}
+	delete(txStatuses.byTxid, txid)
+	for i, t := range txStatuses.order {
+		if t == txid {
+			txStatuses.order = append(txStatuses.order[:i], txStatuses.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// forgetMinedTxStatuses forgets the status of the transactions mined at or
+// above the given height, whose blocks a reorg has removed, so that it's
+// looked up again.
+func forgetMinedTxStatuses(height int) {
+	txStatuses.mutex.Lock()
+	defer txStatuses.mutex.Unlock()
+	order := txStatuses.order[:0]
+	for _, txid := range txStatuses.order {
+		status := txStatuses.byTxid[txid]
+		if status.status == walletrpc.TransactionStatus_mined && status.height >= uint64(height) {
+			delete(txStatuses.byTxid, txid)
+			continue
+		}
+		order = append(order, txid)
+	}
+	txStatuses.order = order
+}
+
+// TrackSentTransaction records the result of submitting the transaction to
+// verusd: accepted into the mempool if rejectReason is empty, otherwise the
+// RPC error code and message it was rejected with.
+func TrackSentTransaction(cache *BlockCache, txData []byte, rejectCode int64, rejectReason string) {
+	digest := sha256.Sum256(txData)
+	digest = sha256.Sum256(digest[:])
+	txid := hex.EncodeToString(parser.Reverse(digest[:]))
+	if rejectReason == "" {
+		if old, ok := getTxStatus(txid); !ok || old.status != walletrpc.TransactionStatus_mined {
+			setTxStatus(txid, txStatus{status: walletrpc.TransactionStatus_inMempool})
+		}
+		return
+	}
+	// Resubmitting a transaction that's in the mempool or mined fails, but
+	// doesn't change its status.
+	if old, ok := getTxStatus(txid); ok && (old.status == walletrpc.TransactionStatus_inMempool ||
+		old.status == walletrpc.TransactionStatus_mined) {
+		return
+	}
+	// If we didn't know it was, verusd does.
+	if rejectCode == rpcVerifyAlreadyInChain || rejectReason == "txn-already-in-mempool" {
+		status, err := lookupTxStatus(cache, txid)
+		if err != nil || status.status == walletrpc.TransactionStatus_unknown {
+			// Without -txindex, verusd can't find mined transactions.
+			forgetTxStatus(txid)
+			return
+		}
+		setTxStatus(txid, status)
+		return
+	}
+	setTxStatus(txid, txStatus{status: walletrpc.TransactionStatus_rejected, reason: rejectReason})
+}
+
+// trackMempoolEvent records the status change of a transaction entering or
+// leaving the mempool.
+func trackMempoolEvent(event *MempoolEvent) {
+	switch event.Type {
+	case MempoolAdd:
+		setTxStatus(event.Txid, txStatus{status: walletrpc.TransactionStatus_inMempool})
+	case MempoolRemove:
+		switch event.Reason {
+		case walletrpc.MempoolTxEvent_mined:
+			setTxStatus(event.Txid, txStatus{status: walletrpc.TransactionStatus_mined, height: event.Height})
+		case walletrpc.MempoolTxEvent_expired:
+			setTxStatus(event.Txid, txStatus{status: walletrpc.TransactionStatus_expired})
+		default:
+			setTxStatus(event.Txid, txStatus{
+				status: walletrpc.TransactionStatus_rejected,
+				reason: event.Reason.String() + " after entering the mempool",
+			})
+		}
+	}
+}
+
+// lookupTxStatus asks verusd for the status of the transaction with the given
+// txid (big-endian hex); it only knows of mined and mempool transactions.
+func lookupTxStatus(cache *BlockCache, txid string) (txStatus, error) {
+	var status txStatus
+	txidJSON, err := json.Marshal(txid)
+	if err != nil {
+		return status, err
+	}
+	result, rpcErr := cachedRequest(cache, "getrawtransaction", []json.RawMessage{txidJSON, json.RawMessage("1")})
+	// RPC_INVALID_ADDRESS_OR_KEY means verusd doesn't know of the transaction.
+	if code, _, _ := ParseRPCError(rpcErr); rpcErr != nil && code != rpcInvalidAddressOrKey {
+		return status, rpcErr
+	}
+	if rpcErr == nil {
+		var txinfo ZcashdRpcReplyGetrawtransaction
+		if err = json.Unmarshal(result, &txinfo); err != nil {
+			return status, errors.Wrap(err, "error reading JSON response")
+		}
+		status.status = walletrpc.TransactionStatus_inMempool
+		if txinfo.Height > 0 {
+			status.status = walletrpc.TransactionStatus_mined
+			status.height = uint64(txinfo.Height)
+		}
+	}
+	return status, nil
+}
+
+// GetTransactionStatus returns the status of the transaction with the given
+// txid (little-endian, as in TxFilter). For a transaction that isn't being
+// tracked, it asks verusd, which only knows of mined and mempool transactions.
+func GetTransactionStatus(cache *BlockCache, txid []byte) (*walletrpc.TransactionStatus, error) {
+	if len(txid) != 32 {
+		return nil, InvalidArgumentError("hash", "Transaction ID has invalid length")
+	}
+	txidstr := hex.EncodeToString(parser.Reverse(txid))
+	status, ok := getTxStatus(txidstr)
+	if !ok {
+		var err error
+		if status, err = lookupTxStatus(cache, txidstr); err != nil {
+			return nil, err
+		}
+	}
+	reply := &walletrpc.TransactionStatus{
+		Txid:   txid,
+		Status: status.status,
+		Height: status.height,
+		Reason: status.reason,
+	}
+	if latest := cache.GetLatestHeight(); status.status == walletrpc.TransactionStatus_mined &&
+		latest >= int(status.height) {
+		reply.Confirmations = uint64(latest) - status.height + 1
+	}
+	return reply, nil
+}
+
+// WatchTransactionStatus sends the status of the transaction with the given
+// txid (little-endian), then sends it again each time it changes (including
+// its confirmations), until done is closed.
+func WatchTransactionStatus(done <-chan struct{}, cache *BlockCache, txid []byte, send func(*walletrpc.TransactionStatus) error) error {
+	// Subscribe first so that no change is missed.
+	statusSub := txStatuses.subscribers.subscribe(txStatusQueueLength)
+	defer statusSub.Close()
+	blockSub := cache.Subscribe()
+	defer blockSub.Close()
+	txidstr := hex.EncodeToString(parser.Reverse(txid))
+
+	var last *walletrpc.TransactionStatus
+	update := func() error {
+		status, err := GetTransactionStatus(cache, txid)
+		if err != nil {
+			return err
+		}
+		if last != nil && status.Status == last.Status && status.Height == last.Height &&
+			status.Confirmations == last.Confirmations && status.Reason == last.Reason {
+			return nil
+		}
+		last = status
+		return send(status)
+	}
+	if err := update(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-done:
+			return nil
+		case changed, ok := <-statusSub.C:
+			if !ok {
+				return SlowClientError("transaction status client is too slow, dropped")
+			}
+			if changed != txidstr {
+				continue
+			}
+		case _, ok := <-blockSub.C:
+			if !ok {
+				return SlowClientError("transaction status client is too slow, dropped")
+			}
+			if last.Status != walletrpc.TransactionStatus_mined {
+				continue
+			}
+		}
+		if err := update(); err != nil {
+			return err
+		}
+	}
+}
@@ -11,13 +11,24 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/asherda/lightwalletd/common"
+	"github.com/asherda/lightwalletd/parser/sapling"
 	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -34,9 +45,26 @@ const (
 	unitTestChain = "unittestnet"
 )
 
+// The leveldb databases opened by testDB, by path.
+var testDBs = map[string]*leveldb.DB{}
+
+// testDB opens the leveldb database at path for a test's cache, first
+// closing the one a previous cache there was using.
+func testDB(path string) *leveldb.DB {
+	if db, ok := testDBs[path]; ok {
+		db.Close()
+	}
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		panic(err)
+	}
+	testDBs[path] = db
+	return db
+}
+
 func testsetup() (walletrpc.CompactTxStreamerServer, *common.BlockCache) {
 	os.RemoveAll(unitTestPath)
-	cache := common.NewBlockCache(unitTestPath, unitTestChain, 380640, true)
+	cache := common.NewBlockCache(testDB(unitTestPath), unitTestChain, 380640, true)
 	lwd, err := NewLwdStreamer(cache, "main", false /* enablePing */)
 	if err != nil {
 		os.Stderr.WriteString(fmt.Sprint("NewLwdStreamer failed:", err))
@@ -115,7 +143,7 @@ func TestGetTransaction(t *testing.T) {
 	if err == nil {
 		testT.Fatal("GetTransaction unexpectedly succeeded")
 	}
-	if err.Error() != "Please call GetTransaction with txid" {
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "Please call GetTransaction with txid" {
 		testT.Fatal("GetTransaction unexpected error message")
 	}
 	if rawtx != nil {
@@ -127,7 +155,8 @@ func TestGetTransaction(t *testing.T) {
 	if err == nil {
 		testT.Fatal("GetTransaction unexpectedly succeeded")
 	}
-	if err.Error() != "Can't GetTransaction with a blockhash+num. Please call GetTransaction with txid" {
+	if status.Code(err) != codes.InvalidArgument ||
+		status.Convert(err).Message() != "Can't GetTransaction with a blockhash+num. Please call GetTransaction with txid" {
 		testT.Fatal("GetTransaction unexpected error message")
 	}
 	if rawtx != nil {
@@ -169,7 +198,7 @@ func TestGetLatestBlock(t *testing.T) {
 	if err == nil {
 		t.Fatal("GetLatestBlock should have failed, empty cache")
 	}
-	if err.Error() != "Cache is empty. Server is probably not yet ready" {
+	if status.Code(err) != codes.Unavailable || status.Convert(err).Message() != "Cache is empty. Server is probably not yet ready" {
 		t.Fatal("GetLatestBlock incorrect error", err)
 	}
 	if blockID != nil {
@@ -224,7 +253,7 @@ func zcashdrpcStub(method string, params []json.RawMessage) (json.RawMessage, er
 		if len(filter.Addresses) != 1 {
 			testT.Fatal("wrong number of addresses")
 		}
-		if filter.Addresses[0] != "t1234567890123456789012345678901234" {
+		if filter.Addresses[0] != "R123456789012345678901234567890123" {
 			testT.Fatal("wrong address")
 		}
 		if filter.Start != 20 {
@@ -288,13 +317,13 @@ func TestGetTaddressTxids(t *testing.T) {
 		if err == nil {
 			t.Fatal("GetTaddressTxids should have failed on bad address, case", i)
 		}
-		if err.Error() != "Invalid address" {
+		if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "Invalid address" {
 			t.Fatal("GetTaddressTxids incorrect error on bad address, case", i)
 		}
 	}
 
 	// valid address
-	addressBlockFilter.Address = "t1234567890123456789012345678901234"
+	addressBlockFilter.Address = "R123456789012345678901234567890123"
 	err := lwd.GetTaddressTxids(addressBlockFilter, &testgettx{})
 	if err != nil {
 		t.Fatal("GetTaddressTxids failed", err)
@@ -346,6 +375,30 @@ func TestGetTaddressTxidsNilArgs(t *testing.T) {
 	}
 }
 
+type testhistory struct {
+	walletrpc.CompactTxStreamer_GetTaddressHistoryServer
+}
+
+func (th *testhistory) Context() context.Context {
+	return context.Background()
+}
+
+func TestGetTaddressHistoryBadArgs(t *testing.T) {
+	lwd, _ := testsetup()
+
+	err := lwd.GetTaddressHistory(&walletrpc.GetTaddressHistoryArg{}, &testhistory{})
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "Must specify at least one address" {
+		t.Fatal("GetTaddressHistory should fail without addresses", err)
+	}
+	for i, addressTest := range addressTests {
+		arg := &walletrpc.GetTaddressHistoryArg{Addresses: []string{addressTest}}
+		err = lwd.GetTaddressHistory(arg, &testhistory{})
+		if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "Invalid address" {
+			t.Fatal("GetTaddressHistory incorrect error on bad address, case", i, err)
+		}
+	}
+}
+
 func TestGetBlock(t *testing.T) {
 	testT = t
 	common.RawRequest = getblockStub
@@ -363,7 +416,7 @@ func TestGetBlock(t *testing.T) {
 	if err == nil {
 		t.Fatal("GetBlock should have failed")
 	}
-	if err.Error() != "GetBlock by Hash is not yet implemented" {
+	if status.Code(err) != codes.Unimplemented || status.Convert(err).Message() != "GetBlock by Hash is not yet implemented" {
 		t.Fatal("GetBlock hash unimplemented error message failed")
 	}
 
@@ -446,19 +499,255 @@ func TestGetBlockRangeNilArgs(t *testing.T) {
 	}
 }
 
+type testgetfilters struct {
+	walletrpc.CompactTxStreamer_GetBlockFiltersServer
+}
+
+func (tg *testgetfilters) Context() context.Context {
+	return context.Background()
+}
+
+func (tg *testgetfilters) Send(filter *walletrpc.BlockFilter) error {
+	return nil
+}
+
+func TestGetBlockFiltersBadArgs(t *testing.T) {
+	lwd, _ := testsetup()
+
+	for _, span := range []*walletrpc.BlockRange{
+		{Start: &walletrpc.BlockID{Height: 380640}},
+		{End: &walletrpc.BlockID{Height: 380640}},
+		{Start: &walletrpc.BlockID{Height: 380641}, End: &walletrpc.BlockID{Height: 380640}},
+	} {
+		err := lwd.GetBlockFilters(span, &testgetfilters{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatal("GetBlockFilters bad range should fail", span, err)
+		}
+	}
+}
+
+type testgetsubtrees struct {
+	walletrpc.CompactTxStreamer_GetSubtreeRootsServer
+	roots []*walletrpc.SubtreeRoot
+}
+
+func (tg *testgetsubtrees) Send(root *walletrpc.SubtreeRoot) error {
+	tg.roots = append(tg.roots, root)
+	return nil
+}
+
+func TestGetSubtreeRoots(t *testing.T) {
+	lwd, _ := testsetup()
+
+	resp := &testgetsubtrees{}
+	err := lwd.GetSubtreeRoots(&walletrpc.GetSubtreeRootsArg{ShieldedProtocol: walletrpc.ShieldedProtocol_orchard}, resp)
+	if err == nil {
+		t.Fatal("GetSubtreeRoots orchard should fail")
+	}
+	// No subtree has been completed.
+	err = lwd.GetSubtreeRoots(&walletrpc.GetSubtreeRootsArg{}, resp)
+	if err != nil {
+		t.Fatal("GetSubtreeRoots failed", err)
+	}
+	if len(resp.roots) != 0 {
+		t.Fatal("unexpected subtree roots", resp.roots)
+	}
+
+	// A tree set from verusd, with a complete subtree whose root isn't known.
+	lwd, cache := testsetup()
+	node := append([]byte{1}, make([]byte, 32)...)
+	data := append(append(append([]byte{}, node...), node...), 15)
+	for i := 0; i < 15; i++ {
+		data = append(data, node...)
+	}
+	tree := sapling.NewTree()
+	if err := tree.UnmarshalBinary(data); err != nil || tree.Size() != 1<<16 {
+		t.Fatal("unexpected tree", tree.Size(), err)
+	}
+	if err := cache.SetSaplingTree(380640-1, tree); err != nil {
+		t.Fatal(err)
+	}
+	err = lwd.GetSubtreeRoots(&walletrpc.GetSubtreeRootsArg{}, resp)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatal("GetSubtreeRoots should fail with incomplete roots", err)
+	}
+}
+
+func identityBalanceStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	step++
+	switch method {
+	case "getidentity":
+		if string(params[0]) != `"alice@"` {
+			testT.Fatal("unexpected identity", string(params[0]))
+		}
+		return json.Marshal(&common.ZcashdRpcReplyGetidentity{
+			Identity: common.ZcashdRpcIdentity{
+				Name:             "alice",
+				IdentityAddress:  "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq",
+				PrimaryAddresses: []string{"RLXCv2dQPB4NPqKUR5AchN2uoyodcCpbzR"},
+			},
+			FriendlyName: "alice.VRSC@",
+			Txid:         "01",
+		})
+	case "getaddressbalance":
+		var req common.ZcashdRpcRequestGetaddressbalance
+		if err := json.Unmarshal(params[0], &req); err != nil {
+			testT.Fatal("could not unmarshal addresses")
+		}
+		// The identity's primary address was also requested directly.
+		if strings.Join(req.Addresses, " ") != "RLXCv2dQPB4NPqKUR5AchN2uoyodcCpbzR iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq" {
+			testT.Fatal("unexpected addresses", req.Addresses)
+		}
+		return json.Marshal(&common.ZcashdRpcReplyGetaddressbalance{
+			Balance:         12345,
+			CurrencyBalance: map[string]json.Number{"iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq": "1.5"},
+		})
+	}
+	testT.Fatal("unexpected call to identityBalanceStub")
+	return nil, nil
+}
+
+func TestGetTaddressBalanceIdentity(t *testing.T) {
+	testT = t
+	lwd, _ := testsetup()
+	common.RawRequest = identityBalanceStub
+
+	balance, err := lwd.GetTaddressBalance(context.Background(), &walletrpc.AddressList{
+		Addresses: []string{"RLXCv2dQPB4NPqKUR5AchN2uoyodcCpbzR", "alice@"},
+	})
+	if err != nil {
+		t.Fatal("GetTaddressBalance failed", err)
+	}
+	if balance.ValueZat != 12345 || len(balance.Resolutions) != 1 ||
+		balance.CurrencyValues["iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq"] != 150000000 {
+		t.Fatal("unexpected balance", balance)
+	}
+	resolution := balance.Resolutions[0]
+	if resolution.Address != "alice@" || resolution.FriendlyName != "alice.VRSC@" ||
+		resolution.IdentityAddress != "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq" || len(resolution.Addresses) != 2 {
+		t.Fatal("unexpected resolution", resolution)
+	}
+	if step != 2 {
+		t.Fatal("unexpected number of verusd RPCs", step)
+	}
+	// Not an identity or a transparent address
+	if _, err = lwd.GetTaddressBalance(context.Background(), &walletrpc.AddressList{
+		Addresses: []string{"@"},
+	}); status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "Invalid address" {
+		t.Fatal("GetTaddressBalance should have failed on bad address")
+	}
+	step = 0
+
+	// lightwalletd's address index doesn't have identities' outputs.
+	lwd, cache := testsetup()
+	cache.EnableAddressIndex()
+	for _, addr := range []string{"alice@", "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq"} {
+		if _, err = lwd.GetTaddressBalance(context.Background(), &walletrpc.AddressList{
+			Addresses: []string{addr},
+		}); status.Code(err) != codes.FailedPrecondition {
+			t.Fatal("GetTaddressBalance should have failed on an identity with the address index, got ", err)
+		}
+	}
+	if step != 0 {
+		t.Fatal("unexpected verusd RPCs", step)
+	}
+}
+
+func identityUtxosStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method == "getidentity" {
+		return identityBalanceStub(method, params)
+	}
+	step++
+	if method != "getaddressutxos" {
+		testT.Fatal("unexpected call to identityUtxosStub")
+	}
+	return json.Marshal([]common.ZcashdRpcReplyGetaddressutxos{{
+		Address:     "RLXCv2dQPB4NPqKUR5AchN2uoyodcCpbzR",
+		Txid:        "01",
+		OutputIndex: 1,
+		Script:      "76a914",
+		Satoshis:    5000,
+		Height:      1000,
+	}})
+}
+
+type testutxos struct {
+	walletrpc.CompactTxStreamer_GetAddressUtxosStreamServer
+	utxos   []*walletrpc.GetAddressUtxosReply
+	trailer metadata.MD
+}
+
+func (tu *testutxos) Send(utxo *walletrpc.GetAddressUtxosReply) error {
+	tu.utxos = append(tu.utxos, utxo)
+	return nil
+}
+
+func (tu *testutxos) SetTrailer(md metadata.MD) {
+	tu.trailer = metadata.Join(tu.trailer, md)
+}
+
+func TestGetAddressUtxosStreamIdentity(t *testing.T) {
+	testT = t
+	lwd, _ := testsetup()
+	common.RawRequest = identityUtxosStub
+
+	resp := &testutxos{}
+	err := lwd.GetAddressUtxosStream(&walletrpc.GetAddressUtxosArg{Addresses: []string{"alice@"}}, resp)
+	if err != nil {
+		t.Fatal("GetAddressUtxosStream failed", err)
+	}
+	if len(resp.utxos) != 1 || resp.utxos[0].Identity != "alice@" {
+		t.Fatal("unexpected utxos", resp.utxos)
+	}
+	// The resolution is in the trailer.
+	values := resp.trailer.Get(ResolutionsTrailer)
+	if len(values) != 1 {
+		t.Fatal("unexpected trailer", resp.trailer)
+	}
+	resolution := &walletrpc.AddressResolution{}
+	if err = proto.Unmarshal([]byte(values[0]), resolution); err != nil {
+		t.Fatal(err)
+	}
+	if resolution.Address != "alice@" || resolution.IdentityAddress != "iJhCezBExJHvtyH3fGhNnt2NhU4Ztkf2yq" {
+		t.Fatal("unexpected resolution", resolution)
+	}
+	step = 0
+
+	// Each identity costs a verusd call, so there's a limit.
+	addresses := make([]string, maxIdentities+1)
+	for i := range addresses {
+		addresses[i] = fmt.Sprintf("name%d@", i)
+	}
+	resp = &testutxos{}
+	err = lwd.GetAddressUtxosStream(&walletrpc.GetAddressUtxosArg{Addresses: addresses}, resp)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("GetAddressUtxosStream should fail with too many identities", err)
+	}
+	if step != 0 || resp.trailer != nil {
+		t.Fatal("unexpected verusd RPCs or trailer", step, resp.trailer)
+	}
+}
+
 func sendrawtransactionStub(method string, params []json.RawMessage) (json.RawMessage, error) {
+	if method == "getblockchaininfo" {
+		// SendTransaction's consensus branch check
+		return []byte(`{"consensus": {"chaintip": "76b809bb", "nextblock": "76b809bb"}}`), nil
+	}
 	step++
 	if method != "sendrawtransaction" {
 		testT.Fatal("unexpected method")
 	}
-	if string(params[0]) != "\"07\"" {
-		testT.Fatal("unexpected tx data")
-	}
 	switch step {
 	case 1:
+		if string(params[0]) != "\""+hex.EncodeToString(rawTxData[0])+"\"" {
+			testT.Fatal("unexpected tx data")
+		}
 		return []byte("sendtxresult"), nil
 	case 2:
-		return nil, errors.New("-17: some error")
+		if string(params[0]) != "\""+hex.EncodeToString(rawTxData[1])+"\"" {
+			testT.Fatal("unexpected tx data")
+		}
+		return nil, errors.New("-26: 18: bad-txns-inputs-spent")
 	}
 	testT.Fatal("unexpected call to sendrawtransactionStub")
 	return nil, nil
@@ -468,7 +757,7 @@ func TestSendTransaction(t *testing.T) {
 	testT = t
 	lwd, _ := testsetup()
 	common.RawRequest = sendrawtransactionStub
-	rawtx := walletrpc.RawTransaction{Data: []byte{7}}
+	rawtx := walletrpc.RawTransaction{Data: rawTxData[0]}
 	sendresult, err := lwd.SendTransaction(context.Background(), &rawtx)
 	if err != nil {
 		t.Fatal("SendTransaction failed", err)
@@ -480,17 +769,30 @@ func TestSendTransaction(t *testing.T) {
 		t.Fatal("SendTransaction unexpected ErrorMessage return")
 	}
 
-	// sendrawtransactionStub case 2 (error)
-	// but note that the error is send within the response
+	// sendrawtransactionStub case 2 (error), which is returned in the
+	// response with verusd's code
+	rawtx = walletrpc.RawTransaction{Data: rawTxData[1]}
 	sendresult, err = lwd.SendTransaction(context.Background(), &rawtx)
 	if err != nil {
-		t.Fatal("SendTransaction failed:", err)
+		t.Fatal("SendTransaction failed", err)
 	}
-	if sendresult.ErrorCode != -17 {
-		t.Fatal("SendTransaction unexpected ErrorCode return")
+	if sendresult.ErrorCode != -26 || sendresult.ErrorMessage != "18: bad-txns-inputs-spent" {
+		t.Fatal("SendTransaction unexpected response", sendresult)
 	}
-	if sendresult.ErrorMessage != "some error" {
-		t.Fatal("SendTransaction unexpected ErrorMessage return")
+
+	// Transactions that verusd would reject aren't sent to it.
+	rawtx = walletrpc.RawTransaction{Data: []byte{7}}
+	_, err = lwd.SendTransaction(context.Background(), &rawtx)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatal("SendTransaction unexpected error", err)
+	}
+	rawtx = walletrpc.RawTransaction{Data: rawTxData[0]}
+	_, err = lwd.SendTransaction(context.Background(), &rawtx)
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatal("SendTransaction unexpected error", err)
+	}
+	if step != 2 {
+		t.Fatal("unexpected number of sendrawtransaction calls", step)
 	}
 	step = 0
 }
@@ -540,3 +842,66 @@ func TestNewZRPCFromConf(t *testing.T) {
 		t.Fatal("NewZRPCFromClient unexpected success")
 	}
 }
+
+func TestNewStreamRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "testlightwduser" || pass != "testlightwdpassword" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var request struct {
+			Method string
+			Params []json.RawMessage
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Method != "getblock" {
+			t.Error("unexpected request ", request, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"result":"%s","error":null,"id":1}`, request.Params[0])
+	}))
+	defer server.Close()
+	host, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	opts := &common.Options{RPCHost: host, RPCPort: port, RPCUser: "testlightwduser", RPCPassword: "testlightwdpassword"}
+
+	body, err := NewStreamRequestFromFlags(opts)("getblock", []json.RawMessage{[]byte("1234")})
+	if err != nil {
+		t.Fatal("stream request failed ", err)
+	}
+	reply, _ := ioutil.ReadAll(body)
+	body.Close()
+	if string(reply) != `{"result":"1234","error":null,"id":1}` {
+		t.Fatal("unexpected reply ", string(reply))
+	}
+	opts.RPCPassword = "wrong"
+	if _, err := NewStreamRequestFromFlags(opts)("getblock", []json.RawMessage{[]byte("1234")}); err == nil {
+		t.Fatal("expected an authentication error")
+	}
+	if _, err := NewStreamRequestFromConf(10); err == nil {
+		t.Fatal("NewStreamRequestFromConf unexpected success")
+	}
+}
+
+func TestStatusInterceptors(t *testing.T) {
+	_, err := StatusUnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.New("-5: No information available about transaction")
+		})
+	if status.Code(err) != codes.NotFound {
+		t.Fatal("unexpected error", err)
+	}
+	err = StatusStreamInterceptor(nil, nil, &grpc.StreamServerInfo{},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			return errors.New("-28: Loading block index...")
+		})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatal("unexpected error", err)
+	}
+	err = StatusStreamInterceptor(nil, nil, &grpc.StreamServerInfo{},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+}
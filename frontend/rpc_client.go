@@ -5,9 +5,17 @@
 package frontend
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
 
 	"github.com/asherda/lightwalletd/common"
+	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/pkg/errors"
 	ini "gopkg.in/ini.v1"
@@ -24,15 +32,74 @@ func NewZRPCFromConf(confPath interface{}) (*rpcclient.Client, error) {
 
 // NewZRPCFromFlags gets zcashd rpc connection information from provided flags.
 func NewZRPCFromFlags(opts *common.Options) (*rpcclient.Client, error) {
+	return rpcclient.New(connFromFlags(opts), nil)
+}
+
+// NewStreamRequestFromConf returns a common.StreamRequest function for the
+// zcashd given by the configuration file.
+func NewStreamRequestFromConf(confPath interface{}) (func(string, []json.RawMessage) (io.ReadCloser, error), error) {
+	connCfg, err := connFromConf(confPath)
+	if err != nil {
+		return nil, err
+	}
+	return newStreamRequest(connCfg), nil
+}
+
+// NewStreamRequestFromFlags returns a common.StreamRequest function for the
+// zcashd given by the provided flags.
+func NewStreamRequestFromFlags(opts *common.Options) func(string, []json.RawMessage) (io.ReadCloser, error) {
+	return newStreamRequest(connFromFlags(opts))
+}
+
+func connFromFlags(opts *common.Options) *rpcclient.ConnConfig {
 	// Connect to local Zcash RPC server using HTTP POST mode.
-	connCfg := &rpcclient.ConnConfig{
+	return &rpcclient.ConnConfig{
 		Host:         net.JoinHostPort(opts.RPCHost, opts.RPCPort),
 		User:         opts.RPCUser,
 		Pass:         opts.RPCPassword,
 		HTTPPostMode: true, // Zcash only supports HTTP POST mode
 		DisableTLS:   true, // Zcash does not provide TLS by default
 	}
-	return rpcclient.New(connCfg, nil)
+}
+
+// newStreamRequest returns a function that posts an RPC request to zcashd,
+// as rpcclient does, but returns the response body unread, for the caller
+// to read (and close) as it arrives.
+func newStreamRequest(connCfg *rpcclient.ConnConfig) func(string, []json.RawMessage) (io.ReadCloser, error) {
+	url := "http://" + connCfg.Host
+	if !connCfg.DisableTLS {
+		url = "https://" + connCfg.Host
+	}
+	var id uint64
+	return func(method string, params []json.RawMessage) (io.ReadCloser, error) {
+		request, err := json.Marshal(&btcjson.Request{
+			Jsonrpc: btcjson.RpcVersion1,
+			Method:  method,
+			Params:  params,
+			ID:      atomic.AddUint64(&id, 1),
+		})
+		if err != nil {
+			return nil, err
+		}
+		httpReq, err := http.NewRequest("POST", url, bytes.NewReader(request))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Close = true
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.SetBasicAuth(connCfg.User, connCfg.Pass)
+		httpResponse, err := http.DefaultClient.Do(httpReq)
+		if err != nil {
+			return nil, err
+		}
+		// Errors are JSON-RPC responses too (with status 404 or 500), but
+		// not authentication failures.
+		if !strings.HasPrefix(httpResponse.Header.Get("Content-Type"), "application/json") {
+			httpResponse.Body.Close()
+			return nil, fmt.Errorf("status code: %d", httpResponse.StatusCode)
+		}
+		return httpResponse.Body, nil
+	}
 }
 
 // If passed a string, interpret as a path, open and read; if passed
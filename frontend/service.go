@@ -9,7 +9,6 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"io"
 	"regexp"
 	"strconv"
@@ -20,6 +19,11 @@ import (
 	"github.com/asherda/lightwalletd/common"
 	"github.com/asherda/lightwalletd/parser"
 	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type lwdStreamer struct {
@@ -49,42 +53,150 @@ func NewDarksideStreamer(cache *common.BlockCache) (walletrpc.DarksideStreamerSe
 func checkTaddress(taddr string) error {
 	match, err := regexp.Match("\\AR[a-zA-Z0-9]{33}\\z", []byte(taddr))
 	if err != nil || !match {
-		return errors.New("Invalid address")
+		return common.InvalidArgumentError("address", "Invalid address")
 	}
 	return nil
 }
 
+// Test to make sure Address is a single VerusID, a friendly name (name@)
+// or an i-address
+func isIdentity(addr string) bool {
+	if len(addr) > 1 && strings.HasSuffix(addr, "@") && !strings.ContainsAny(addr, " \t\r\n") {
+		return true
+	}
+	match, err := regexp.Match("\\Ai[a-zA-Z0-9]{33}\\z", []byte(addr))
+	return err == nil && match
+}
+
+// maxIdentities is the most VerusIDs a request may name; each one costs a
+// getidentity call to verusd.
+const maxIdentities = 20
+
+// ResolutionsTrailer is the trailer metadata key under which the streaming
+// address endpoints return the resolutions of the VerusIDs among the
+// requested addresses, each a serialized AddressResolution.
+const ResolutionsTrailer = "address-resolutions-bin"
+
+// resolveAddresses checks the given addresses, replacing each VerusID with
+// its identity address and primary addresses (without duplicates). It also
+// returns the resolutions, and the identity each address was resolved from.
+// VerusIDs aren't accepted when the address endpoints are served from
+// lightwalletd's address index, which doesn't have the smart transaction
+// outputs that pay identities.
+func (s *lwdStreamer) resolveAddresses(addresses []string) ([]string, []*walletrpc.AddressResolution, map[string]string, error) {
+	resolved := make([]string, 0, len(addresses))
+	resolutions := make([]*walletrpc.AddressResolution, 0)
+	identities := make(map[string]string)
+	seen := make(map[string]bool)
+	n := 0
+	for _, addr := range addresses {
+		if isIdentity(addr) {
+			n++
+		}
+	}
+	if n > maxIdentities {
+		return nil, nil, nil, common.InvalidArgumentError("addresses",
+			"At most "+strconv.Itoa(maxIdentities)+" VerusIDs may be requested")
+	}
+	add := func(addr string) {
+		if !seen[addr] {
+			seen[addr] = true
+			resolved = append(resolved, addr)
+		}
+	}
+	for _, addr := range addresses {
+		if !isIdentity(addr) {
+			if err := checkTaddress(addr); err != nil {
+				return nil, nil, nil, err
+			}
+			add(addr)
+			continue
+		}
+		if s.cache.AddressIndexEnabled() {
+			return nil, nil, nil, status.Error(codes.FailedPrecondition,
+				"VerusIDs aren't supported with --address-index, which doesn't index smart transaction outputs")
+		}
+		info, err := common.GetIdentity(s.cache, addr, 0)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		resolution := &walletrpc.AddressResolution{
+			Address:         addr,
+			IdentityAddress: info.Identity.IdentityAddress,
+			FriendlyName:    info.FriendlyName,
+			Addresses:       append([]string{info.Identity.IdentityAddress}, info.Identity.PrimaryAddresses...),
+		}
+		for _, a := range resolution.Addresses {
+			add(a)
+			if _, ok := identities[a]; !ok {
+				identities[a] = addr
+			}
+		}
+		resolutions = append(resolutions, resolution)
+	}
+	return resolved, resolutions, identities, nil
+}
+
+// setResolutionsTrailer returns the resolutions to a streaming endpoint's
+// caller in the stream's trailer (see ResolutionsTrailer).
+func setResolutionsTrailer(stream grpc.ServerStream, resolutions []*walletrpc.AddressResolution) error {
+	if len(resolutions) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(resolutions))
+	for _, resolution := range resolutions {
+		data, err := proto.Marshal(resolution)
+		if err != nil {
+			return err
+		}
+		values = append(values, string(data))
+	}
+	stream.SetTrailer(metadata.MD{ResolutionsTrailer: values})
+	return nil
+}
+
 // GetLatestBlock returns the height of the best chain, according to zcashd.
 func (s *lwdStreamer) GetLatestBlock(ctx context.Context, placeholder *walletrpc.ChainSpec) (*walletrpc.BlockID, error) {
 	latestBlock := s.cache.GetLatestHeight()
 	latestHash := s.cache.GetLatestHash()
 
 	if latestBlock == -1 {
-		return nil, errors.New("Cache is empty. Server is probably not yet ready")
+		return nil, common.NotReadyError("Cache is empty. Server is probably not yet ready")
 	}
 
 	return &walletrpc.BlockID{Height: uint64(latestBlock), Hash: latestHash}, nil
 }
 
 // GetTaddressTxids is a streaming RPC that returns transaction IDs that have
-// the given transparent address (taddr) as either an input or output.
+// the given transparent address (taddr), or any of a VerusID's addresses, as
+// either an input or output.
 func (s *lwdStreamer) GetTaddressTxids(addressBlockFilter *walletrpc.TransparentAddressBlockFilter, resp walletrpc.CompactTxStreamer_GetTaddressTxidsServer) error {
-	if err := checkTaddress(addressBlockFilter.Address); err != nil {
-		return err
-	}
-
 	if addressBlockFilter.Range == nil {
-		return errors.New("Must specify block range")
+		return common.InvalidArgumentError("range", "Must specify block range")
 	}
 	if addressBlockFilter.Range.Start == nil {
-		return errors.New("Must specify a start block height")
+		return common.InvalidArgumentError("range.start", "Must specify a start block height")
 	}
 	if addressBlockFilter.Range.End == nil {
-		return errors.New("Must specify an end block height")
+		return common.InvalidArgumentError("range.end", "Must specify an end block height")
+	}
+	addresses, resolutions, _, err := s.resolveAddresses([]string{addressBlockFilter.Address})
+	if err != nil {
+		return err
+	}
+	if err = setResolutionsTrailer(resp, resolutions); err != nil {
+		return err
+	}
+	if s.cache.AddressIndexEnabled() {
+		return common.GetIndexedTransactions(s.cache, addresses,
+			addressBlockFilter.Range.Start.Height, addressBlockFilter.Range.End.Height,
+			func(tx *walletrpc.RawTransaction) error {
+				return resp.Send(tx)
+			})
 	}
 	params := make([]json.RawMessage, 1)
 	request := &common.ZcashdRpcRequestGetaddresstxids{
-		Addresses: []string{addressBlockFilter.Address},
+		Addresses: addresses,
 		Start:     addressBlockFilter.Range.Start.Height,
 		End:       addressBlockFilter.Range.End.Height,
 	}
@@ -124,38 +236,70 @@ func (s *lwdStreamer) GetTaddressTxids(addressBlockFilter *walletrpc.Transparent
 	return nil
 }
 
+// GetTaddressHistory is a streaming RPC that returns a page of the
+// transactions involving the given taddrs or VerusIDs, in the requested
+// order, with each one's net change to the addresses' balances.
+func (s *lwdStreamer) GetTaddressHistory(arg *walletrpc.GetTaddressHistoryArg, resp walletrpc.CompactTxStreamer_GetTaddressHistoryServer) error {
+	if len(arg.Addresses) == 0 {
+		return common.InvalidArgumentError("addresses", "Must specify at least one address")
+	}
+	addresses, resolutions, identities, err := s.resolveAddresses(arg.Addresses)
+	if err != nil {
+		return err
+	}
+	if err = setResolutionsTrailer(resp, resolutions); err != nil {
+		return err
+	}
+	return common.GetTaddressHistory(resp.Context(), s.cache, addresses, arg,
+		func(tx *walletrpc.TaddressTransaction) error {
+			for _, delta := range tx.Deltas {
+				delta.Identity = identities[delta.Address]
+			}
+			return resp.Send(tx)
+		})
+}
+
 // GetBlock returns the compact block at the requested height. Requesting a
 // block by hash is not yet supported.
 func (s *lwdStreamer) GetBlock(ctx context.Context, id *walletrpc.BlockID) (*walletrpc.CompactBlock, error) {
 	if id.Height == 0 && id.Hash == nil {
-		return nil, errors.New("request for unspecified identifier")
+		return nil, common.InvalidArgumentError("height", "request for unspecified identifier")
 	}
 
 	// Precedence: a hash is more specific than a height. If we have it, use it first.
 	if id.Hash != nil {
 		// TODO: Get block by hash
-		return nil, errors.New("GetBlock by Hash is not yet implemented")
+		return nil, status.Error(codes.Unimplemented, "GetBlock by Hash is not yet implemented")
 	}
 	cBlock, err := common.GetBlock(s.cache, int(id.Height))
 
 	if err != nil {
 		return nil, err
 	}
+	cBlock.Final = common.IsFinal(cBlock.Height)
 
 	return cBlock, err
 }
 
 // GetBlockRange is a streaming RPC that returns blocks, in compact form,
 // (as also returned by GetBlock) from the block height 'start' to height
-// 'end' inclusively.
+// 'end' inclusively. If asked, the transactions' transparent inputs and
+// outputs are included, as are transactions that are only transparent; the
+// blocks can be limited to some parts of their transactions, and leave out
+// transactions the client already has.
 func (s *lwdStreamer) GetBlockRange(span *walletrpc.BlockRange, resp walletrpc.CompactTxStreamer_GetBlockRangeServer) error {
 	blockChan := make(chan *walletrpc.CompactBlock)
 	errChan := make(chan error)
 	if span.Start == nil || span.End == nil {
-		return errors.New("Must specify start and end heights")
+		return common.InvalidArgumentError("start", "Must specify start and end heights")
 	}
 
-	go common.GetBlockRange(s.cache, blockChan, errChan, int(span.Start.Height), int(span.End.Height))
+	opts := common.BlockRangeOptions{
+		Transparent: span.Transparent,
+		Content:     span.Content,
+		Exclude:     span.Exclude,
+	}
+	go common.GetBlockRange(s.cache, blockChan, errChan, int(span.Start.Height), int(span.End.Height), opts)
 
 	for {
 		select {
@@ -163,6 +307,7 @@ func (s *lwdStreamer) GetBlockRange(span *walletrpc.BlockRange, resp walletrpc.C
 			// this will also catch context.DeadlineExceeded from the timeout
 			return err
 		case cBlock := <-blockChan:
+			cBlock.Final = common.IsFinal(cBlock.Height)
 			err := resp.Send(cBlock)
 			if err != nil {
 				return err
@@ -171,80 +316,168 @@ func (s *lwdStreamer) GetBlockRange(span *walletrpc.BlockRange, resp walletrpc.C
 	}
 }
 
+// GetBlockFilters is a streaming RPC that returns the filters of the blocks
+// from height 'start' to height 'end' inclusively, with their headers.
+func (s *lwdStreamer) GetBlockFilters(span *walletrpc.BlockRange, resp walletrpc.CompactTxStreamer_GetBlockFiltersServer) error {
+	if span.Start == nil || span.End == nil {
+		return common.InvalidArgumentError("start", "Must specify start and end heights")
+	}
+	return common.GetBlockFilters(s.cache, int(span.Start.Height), int(span.End.Height), resp.Send)
+}
+
+// SubscribeBlocks is a streaming RPC that sends each block as it's added to
+// the cache, and a reorg marker when the cache is rewound, until the client
+// cancels it.
+func (s *lwdStreamer) SubscribeBlocks(arg *walletrpc.SubscribeBlocksArg, resp walletrpc.CompactTxStreamer_SubscribeBlocksServer) error {
+	sub := s.cache.Subscribe()
+	defer sub.Close()
+	for {
+		select {
+		case <-resp.Context().Done():
+			return resp.Context().Err()
+		case update, ok := <-sub.C:
+			if !ok {
+				return common.SlowClientError("SubscribeBlocks client is too slow, dropped")
+			}
+			// The update is shared with the other subscribers, so it's
+			// copied rather than changed.
+			if update.CompactBlock != nil {
+				if !arg.FullBlocks {
+					update = &walletrpc.BlockUpdate{Block: update.Block, Reorg: update.Reorg}
+				} else {
+					block := update.CompactBlock
+					update = &walletrpc.BlockUpdate{
+						Block: update.Block,
+						CompactBlock: &walletrpc.CompactBlock{
+							ProtoVersion: block.ProtoVersion,
+							Height:       block.Height,
+							Hash:         block.Hash,
+							PrevHash:     block.PrevHash,
+							Time:         block.Time,
+							Header:       block.Header,
+							Vtx:          block.Vtx,
+							Production:   block.Production,
+							Final:        common.IsFinal(block.Height),
+						},
+						Reorg: update.Reorg,
+					}
+				}
+			}
+			if err := resp.Send(update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // GetTreeState returns the note commitment tree state corresponding to the given block.
 // See section 3.7 of the Zcash protocol specification. It returns several other useful
 // values also (even though they can be obtained using GetBlock).
 // The block can be specified by either height or hash.
 func (s *lwdStreamer) GetTreeState(ctx context.Context, id *walletrpc.BlockID) (*walletrpc.TreeState, error) {
 	if id.Height == 0 && id.Hash == nil {
-		return nil, errors.New("request for unspecified identifier")
+		return nil, common.InvalidArgumentError("height", "request for unspecified identifier")
 	}
-	// The Zcash z_gettreestate rpc accepts either a block height or block hash
-	params := make([]json.RawMessage, 1)
-	var hashJSON []byte
+	// Tree states after cached blocks are maintained by the cache.
 	if id.Height > 0 {
-		heightJSON, err := json.Marshal(strconv.Itoa(int(id.Height)))
-		if err != nil {
-			return nil, err
+		if treeState := s.cache.GetTreeState(int(id.Height)); treeState != nil {
+			treeState.Network = s.chainName
+			return treeState, nil
 		}
-		params[0] = heightJSON
+	}
+	// The Zcash z_gettreestate rpc accepts either a block height or block hash
+	var idJSON []byte
+	var err error
+	if id.Height > 0 {
+		idJSON, err = json.Marshal(strconv.Itoa(int(id.Height)))
 	} else {
 		// id.Hash is big-endian, keep in big-endian for the rpc
-		hashJSON, err := json.Marshal(hex.EncodeToString(id.Hash))
-		if err != nil {
-			return nil, err
-		}
-		params[0] = hashJSON
+		idJSON, err = json.Marshal(hex.EncodeToString(id.Hash))
 	}
-	var gettreestateReply common.ZcashdRpcReplyGettreestate
-	for {
-		result, rpcErr := common.RawRequest("z_gettreestate", params)
-		if rpcErr != nil {
-			return nil, rpcErr
-		}
-		err := json.Unmarshal(result, &gettreestateReply)
-		if err != nil {
-			return nil, err
-		}
-		if gettreestateReply.Sapling.Commitments.FinalState != "" {
-			break
-		}
-		if gettreestateReply.Sapling.SkipHash == "" {
-			break
-		}
-		hashJSON, err = json.Marshal(gettreestateReply.Sapling.SkipHash)
-		if err != nil {
-			return nil, err
-		}
-		params[0] = hashJSON
+	if err != nil {
+		return nil, err
 	}
-	if gettreestateReply.Sapling.Commitments.FinalState == "" {
-		return nil, errors.New("zcashd did not return treestate")
+	treeState, err := common.GetTreeStateFromRPC(idJSON)
+	if err != nil {
+		return nil, err
 	}
-	return &walletrpc.TreeState{
-		Network: s.chainName,
-		Height:  uint64(gettreestateReply.Height),
-		Hash:    gettreestateReply.Hash,
-		Time:    gettreestateReply.Time,
-		Tree:    gettreestateReply.Sapling.Commitments.FinalState,
-	}, nil
+	treeState.Network = s.chainName
+	return treeState, nil
 }
 
 func (s *lwdStreamer) GetLatestTreeState(ctx context.Context, in *walletrpc.Empty) (*walletrpc.TreeState, error) {
 	latestHeight := s.cache.GetLatestHeight()
 
 	if latestHeight == -1 {
-		return nil, errors.New("Cache is empty. Server is probably not yet ready")
+		return nil, common.NotReadyError("Cache is empty. Server is probably not yet ready")
 	}
 	return s.GetTreeState(ctx, &walletrpc.BlockID{Height: uint64(latestHeight)})
 }
 
+// GetFinalityStatus returns whether the block at the given height (or the
+// latest block) is notarized and confirmed, and the latest such block.
+func (s *lwdStreamer) GetFinalityStatus(ctx context.Context, id *walletrpc.BlockID) (*walletrpc.FinalityStatus, error) {
+	if id.Hash != nil {
+		return nil, status.Error(codes.Unimplemented, "GetFinalityStatus by Hash is not yet implemented")
+	}
+	return common.GetFinalityStatus(s.cache, id.Height)
+}
+
+// GetSubtreeRoots returns the roots of the complete 2^16-leaf subtrees of the
+// Sapling note commitment tree, starting at the given index.
+func (s *lwdStreamer) GetSubtreeRoots(arg *walletrpc.GetSubtreeRootsArg, resp walletrpc.CompactTxStreamer_GetSubtreeRootsServer) error {
+	if arg.ShieldedProtocol != walletrpc.ShieldedProtocol_sapling {
+		return status.Error(codes.Unimplemented, "only sapling subtree roots are supported")
+	}
+	for i := arg.StartIndex; arg.MaxEntries == 0 || i-arg.StartIndex < arg.MaxEntries; i++ {
+		root := s.cache.GetSubtreeRoot(int(i))
+		if root == nil {
+			if !s.cache.SubtreeRootsComplete() {
+				return status.Error(codes.FailedPrecondition,
+					"Sapling subtree roots are incomplete, lightwalletd must be restarted with --redownload")
+			}
+			break
+		}
+		if err := resp.Send(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetIdentity returns the given VerusID (friendly name or i-address) as of
+// the given block height, or the latest block if the height is zero.
+func (s *lwdStreamer) GetIdentity(ctx context.Context, arg *walletrpc.GetIdentityArg) (*walletrpc.IdentityInfo, error) {
+	return common.GetIdentity(s.cache, arg.Identity, arg.Height)
+}
+
+// GetIdentityHistory is a streaming RPC that returns the revisions of the
+// given VerusID within the given block range, oldest first.
+func (s *lwdStreamer) GetIdentityHistory(arg *walletrpc.GetIdentityHistoryArg, resp walletrpc.CompactTxStreamer_GetIdentityHistoryServer) error {
+	return common.GetIdentityHistory(s.cache, arg.Identity, arg.StartHeight, arg.EndHeight,
+		func(update *walletrpc.IdentityUpdate) error {
+			return resp.Send(update)
+		})
+}
+
+// GetCurrency returns the definition and latest state of the given currency
+// (name or i-address).
+func (s *lwdStreamer) GetCurrency(ctx context.Context, arg *walletrpc.GetCurrencyArg) (*walletrpc.Currency, error) {
+	return common.GetCurrency(s.cache, arg.Currency)
+}
+
+// EstimateConversion returns verusd's estimate of the result of converting
+// between currencies at the latest block.
+func (s *lwdStreamer) EstimateConversion(ctx context.Context, arg *walletrpc.EstimateConversionArg) (*walletrpc.ConversionEstimate, error) {
+	return common.EstimateConversion(s.cache, arg)
+}
+
 // GetTransaction returns the raw transaction bytes that are returned
 // by the zcashd 'getrawtransaction' RPC.
 func (s *lwdStreamer) GetTransaction(ctx context.Context, txf *walletrpc.TxFilter) (*walletrpc.RawTransaction, error) {
 	if txf.Hash != nil {
 		if len(txf.Hash) != 32 {
-			return nil, errors.New("Transaction ID has invalid length")
+			return nil, common.InvalidArgumentError("hash", "Transaction ID has invalid length")
 		}
 		leHashStringJSON, err := json.Marshal(hex.EncodeToString(parser.Reverse(txf.Hash)))
 		if err != nil {
@@ -277,9 +510,9 @@ func (s *lwdStreamer) GetTransaction(ctx context.Context, txf *walletrpc.TxFilte
 	}
 
 	if txf.Block != nil && txf.Block.Hash != nil {
-		return nil, errors.New("Can't GetTransaction with a blockhash+num. Please call GetTransaction with txid")
+		return nil, common.InvalidArgumentError("hash", "Can't GetTransaction with a blockhash+num. Please call GetTransaction with txid")
 	}
-	return nil, errors.New("Please call GetTransaction with txid")
+	return nil, common.InvalidArgumentError("hash", "Please call GetTransaction with txid")
 }
 
 // GetLightdInfo gets the LightWalletD (this server) info, and includes information
@@ -299,7 +532,11 @@ func (s *lwdStreamer) SendTransaction(ctx context.Context, rawtx *walletrpc.RawT
 
 	// Verify rawtx
 	if rawtx == nil || rawtx.Data == nil {
-		return nil, errors.New("Bad transaction data")
+		return nil, common.InvalidArgumentError("data", "Bad transaction data")
+	}
+	// Reject what zcashd certainly would without asking it.
+	if err := common.CheckSendTransaction(s.cache, rawtx.Data); err != nil {
+		return nil, err
 	}
 
 	// Construct raw JSON-RPC params
@@ -314,36 +551,66 @@ func (s *lwdStreamer) SendTransaction(ctx context.Context, rawtx *walletrpc.RawT
 	var errCode int64
 	var errMsg string
 
-	// For some reason, the error responses are not JSON
 	if rpcErr != nil {
-		errParts := strings.SplitN(rpcErr.Error(), ":", 2)
-		if len(errParts) < 2 {
-			return nil, errors.New("SendTransaction couldn't parse error code")
-		}
-		errMsg = strings.TrimSpace(errParts[1])
-		errCode, err = strconv.ParseInt(errParts[0], 10, 32)
-		if err != nil {
-			// This should never happen. We can't panic here, but it's that class of error.
-			// This is why we need integration testing to work better than regtest currently does. TODO.
-			return nil, errors.New("SendTransaction couldn't parse error code")
+		var ok bool
+		errCode, errMsg, ok = common.ParseRPCError(rpcErr)
+		if !ok {
+			// Not an error from verusd; it probably couldn't be reached.
+			return nil, common.StatusError(rpcErr)
 		}
+		common.TrackSentTransaction(s.cache, rawtx.Data, errCode, errMsg)
 	} else {
 		errMsg = string(result)
+		common.TrackSentTransaction(s.cache, rawtx.Data, 0, "")
 	}
 
-	// TODO these are called Error but they aren't at the moment.
-	// A success will return code 0 and message txhash.
+	// A rejection by verusd is returned in the response, as it always has
+	// been, with verusd's error code; a success returns code 0 and message
+	// txhash.
 	return &walletrpc.SendResponse{
 		ErrorCode:    int32(errCode),
 		ErrorMessage: errMsg,
 	}, nil
 }
 
-func getTaddressBalanceZcashdRpc(addressList []string) (*walletrpc.Balance, error) {
-	for _, addr := range addressList {
-		if err := checkTaddress(addr); err != nil {
+// GetTransactionStatus returns what's known about the transaction with the
+// given txid, such as one submitted by SendTransaction.
+func (s *lwdStreamer) GetTransactionStatus(ctx context.Context, txf *walletrpc.TxFilter) (*walletrpc.TransactionStatus, error) {
+	if txf.Hash == nil {
+		return nil, common.InvalidArgumentError("hash", "Please call GetTransactionStatus with txid")
+	}
+	return common.GetTransactionStatus(s.cache, txf.Hash)
+}
+
+// GetTransactionStatusStream sends the status of the transaction with the
+// given txid, then again each time it changes, until the client cancels it.
+func (s *lwdStreamer) GetTransactionStatusStream(txf *walletrpc.TxFilter, resp walletrpc.CompactTxStreamer_GetTransactionStatusStreamServer) error {
+	if txf.Hash == nil {
+		return common.InvalidArgumentError("hash", "Please call GetTransactionStatusStream with txid")
+	}
+	if len(txf.Hash) != 32 {
+		return common.InvalidArgumentError("hash", "Transaction ID has invalid length")
+	}
+	return common.WatchTransactionStatus(resp.Context().Done(), s.cache, txf.Hash, resp.Send)
+}
+
+// GetFeeEstimate suggests fees for the given confirmation targets (in blocks),
+// from zcashd's estimate and the fee rates of recent blocks and the mempool.
+func (s *lwdStreamer) GetFeeEstimate(ctx context.Context, arg *walletrpc.GetFeeEstimateArg) (*walletrpc.FeeEstimateReply, error) {
+	return common.GetFeeEstimate(s.cache, arg.Targets)
+}
+
+func (s *lwdStreamer) getTaddressBalanceZcashdRpc(addressList []string) (*walletrpc.Balance, error) {
+	addressList, resolutions, _, err := s.resolveAddresses(addressList)
+	if err != nil {
+		return &walletrpc.Balance{}, err
+	}
+	if s.cache.AddressIndexEnabled() {
+		balance, err := common.GetIndexedBalance(s.cache, addressList)
+		if err != nil {
 			return &walletrpc.Balance{}, err
 		}
+		return &walletrpc.Balance{ValueZat: balance, Resolutions: resolutions}, nil
 	}
 	params := make([]json.RawMessage, 1)
 	addrList := &common.ZcashdRpcRequestGetaddressbalance{
@@ -364,12 +631,20 @@ func getTaddressBalanceZcashdRpc(addressList []string) (*walletrpc.Balance, erro
 	if err != nil {
 		return &walletrpc.Balance{}, err
 	}
-	return &walletrpc.Balance{ValueZat: balanceReply.Balance}, nil
+	currencyValues, err := common.CurrencyValues(balanceReply.CurrencyBalance)
+	if err != nil {
+		return &walletrpc.Balance{}, err
+	}
+	return &walletrpc.Balance{
+		ValueZat:       balanceReply.Balance,
+		Resolutions:    resolutions,
+		CurrencyValues: currencyValues,
+	}, nil
 }
 
 // GetTaddressBalance returns the total balance for a list of taddrs
 func (s *lwdStreamer) GetTaddressBalance(ctx context.Context, addresses *walletrpc.AddressList) (*walletrpc.Balance, error) {
-	return getTaddressBalanceZcashdRpc(addresses.Addresses)
+	return s.getTaddressBalanceZcashdRpc(addresses.Addresses)
 }
 
 // GetTaddressBalanceStream returns the total balance for a list of taddrs
@@ -385,7 +660,7 @@ func (s *lwdStreamer) GetTaddressBalanceStream(addresses walletrpc.CompactTxStre
 		}
 		addressList = append(addressList, addr.Address)
 	}
-	balance, err := getTaddressBalanceZcashdRpc(addressList)
+	balance, err := s.getTaddressBalanceZcashdRpc(addressList)
 	if err != nil {
 		return err
 	}
@@ -394,35 +669,76 @@ func (s *lwdStreamer) GetTaddressBalanceStream(addresses walletrpc.CompactTxStre
 }
 
 func (s *lwdStreamer) GetMempoolStream(_empty *walletrpc.Empty, resp walletrpc.CompactTxStreamer_GetMempoolStreamServer) error {
-	err := common.GetMempool(func(tx *walletrpc.RawTransaction) error {
+	err := common.GetMempool(resp.Context().Done(), func(tx *walletrpc.RawTransaction) error {
 		return resp.Send(tx)
 	})
 	return err
 }
 
-func getAddressUtxos(arg *walletrpc.GetAddressUtxosArg, f func(*walletrpc.GetAddressUtxosReply) error) error {
-	for _, a := range arg.Addresses {
-		if err := checkTaddress(a); err != nil {
+// GetMempoolTx returns the compact form of the current mempool transactions,
+// except those the client has excluded (by txid prefix).
+func (s *lwdStreamer) GetMempoolTx(exclude *walletrpc.Exclude, resp walletrpc.CompactTxStreamer_GetMempoolTxServer) error {
+	return common.GetMempoolTx(exclude.Txid, func(tx *walletrpc.CompactTx) error {
+		return resp.Send(tx)
+	})
+}
+
+// GetMempoolEvents returns each transaction now in the mempool, then each
+// change to the mempool as it happens, until the client cancels the stream.
+func (s *lwdStreamer) GetMempoolEvents(_empty *walletrpc.Empty, resp walletrpc.CompactTxStreamer_GetMempoolEventsServer) error {
+	txs, sub := common.SubscribeMempool()
+	defer sub.Close()
+	for _, event := range txs {
+		if err := resp.Send(event.TxEvent()); err != nil {
 			return err
 		}
 	}
-	params := make([]json.RawMessage, 1)
-	addrList := &common.ZcashdRpcRequestGetaddressutxos{
-		Addresses: arg.Addresses,
+	for {
+		select {
+		case <-resp.Context().Done():
+			return resp.Context().Err()
+		case event, ok := <-sub.C:
+			if !ok {
+				return common.SlowClientError("GetMempoolEvents client is too slow, dropped")
+			}
+			if txEvent := event.TxEvent(); txEvent != nil {
+				if err := resp.Send(txEvent); err != nil {
+					return err
+				}
+			}
+		}
 	}
-	param, err := json.Marshal(addrList)
+}
+
+func (s *lwdStreamer) getAddressUtxos(arg *walletrpc.GetAddressUtxosArg, f func(*walletrpc.GetAddressUtxosReply) error) ([]*walletrpc.AddressResolution, error) {
+	addresses, resolutions, identities, err := s.resolveAddresses(arg.Addresses)
 	if err != nil {
-		return err
-	}
-	params[0] = param
-	result, rpcErr := common.RawRequest("getaddressutxos", params)
-	if rpcErr != nil {
-		return rpcErr
+		return nil, err
 	}
 	var utxosReply []common.ZcashdRpcReplyGetaddressutxos
-	err = json.Unmarshal(result, &utxosReply)
-	if err != nil {
-		return err
+	if s.cache.AddressIndexEnabled() {
+		utxosReply, err = common.GetIndexedUtxos(s.cache, addresses)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		params := make([]json.RawMessage, 1)
+		addrList := &common.ZcashdRpcRequestGetaddressutxos{
+			Addresses: addresses,
+		}
+		param, err := json.Marshal(addrList)
+		if err != nil {
+			return nil, err
+		}
+		params[0] = param
+		result, rpcErr := common.RawRequest("getaddressutxos", params)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		err = json.Unmarshal(result, &utxosReply)
+		if err != nil {
+			return nil, err
+		}
 	}
 	n := 0
 	for _, utxo := range utxosReply {
@@ -435,47 +751,58 @@ func getAddressUtxos(arg *walletrpc.GetAddressUtxosArg, f func(*walletrpc.GetAdd
 		}
 		txidBytes, err := hex.DecodeString(utxo.Txid)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		scriptBytes, err := hex.DecodeString(utxo.Script)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		// A daemon without the currency-aware index doesn't report the
+		// reserve currency values, but they can be decoded from the script.
+		currencyValues, err := common.CurrencyValues(utxo.CurrencyValues)
+		if err != nil {
+			return nil, err
+		}
+		if currencyValues == nil {
+			currencyValues = parser.ScriptCurrencyValues(scriptBytes)
 		}
 		err = f(&walletrpc.GetAddressUtxosReply{
-			Address:  utxo.Address,
-			Txid:     parser.Reverse(txidBytes),
-			Index:    int32(utxo.OutputIndex),
-			Script:   scriptBytes,
-			ValueZat: int64(utxo.Satoshis),
-			Height:   uint64(utxo.Height),
+			Address:        utxo.Address,
+			Txid:           parser.Reverse(txidBytes),
+			Index:          int32(utxo.OutputIndex),
+			Script:         scriptBytes,
+			ValueZat:       int64(utxo.Satoshis),
+			Height:         uint64(utxo.Height),
+			Identity:       identities[utxo.Address],
+			CurrencyValues: currencyValues,
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return resolutions, nil
 }
 
 func (s *lwdStreamer) GetAddressUtxos(ctx context.Context, arg *walletrpc.GetAddressUtxosArg) (*walletrpc.GetAddressUtxosReplyList, error) {
 	addressUtxos := make([]*walletrpc.GetAddressUtxosReply, 0)
-	err := getAddressUtxos(arg, func(utxo *walletrpc.GetAddressUtxosReply) error {
+	resolutions, err := s.getAddressUtxos(arg, func(utxo *walletrpc.GetAddressUtxosReply) error {
 		addressUtxos = append(addressUtxos, utxo)
 		return nil
 	})
 	if err != nil {
 		return &walletrpc.GetAddressUtxosReplyList{}, err
 	}
-	return &walletrpc.GetAddressUtxosReplyList{AddressUtxos: addressUtxos}, nil
+	return &walletrpc.GetAddressUtxosReplyList{AddressUtxos: addressUtxos, Resolutions: resolutions}, nil
 }
 
 func (s *lwdStreamer) GetAddressUtxosStream(arg *walletrpc.GetAddressUtxosArg, resp walletrpc.CompactTxStreamer_GetAddressUtxosStreamServer) error {
-	err := getAddressUtxos(arg, func(utxo *walletrpc.GetAddressUtxosReply) error {
+	resolutions, err := s.getAddressUtxos(arg, func(utxo *walletrpc.GetAddressUtxosReply) error {
 		return resp.Send(utxo)
 	})
 	if err != nil {
 		return err
 	}
-	return nil
+	return setResolutionsTrailer(resp, resolutions)
 }
 
 // This rpc is used only for testing.
@@ -486,7 +813,7 @@ func (s *lwdStreamer) Ping(ctx context.Context, in *walletrpc.Duration) (*wallet
 	// concurrent threads, which could run the server out of resources,
 	// so only allow if explicitly enabled.
 	if !s.pingEnable {
-		return nil, errors.New("Ping not enabled, start lightwalletd with --ping-very-insecure")
+		return nil, status.Error(codes.FailedPrecondition, "Ping not enabled, start lightwalletd with --ping-very-insecure")
 	}
 	var response walletrpc.PingResponse
 	response.Entry = atomic.AddInt64(&concurrent, 1)
@@ -499,12 +826,12 @@ func (s *lwdStreamer) Ping(ctx context.Context, in *walletrpc.Duration) (*wallet
 func (s *DarksideStreamer) Reset(ctx context.Context, ms *walletrpc.DarksideMetaState) (*walletrpc.Empty, error) {
 	match, err := regexp.Match("\\A[a-fA-F0-9]+\\z", []byte(ms.BranchID))
 	if err != nil || !match {
-		return nil, errors.New("Invalid branch ID")
+		return nil, common.InvalidArgumentError("branchID", "Invalid branch ID")
 	}
 
 	match, err = regexp.Match("\\A[a-zA-Z0-9]+\\z", []byte(ms.ChainName))
 	if err != nil || !match {
-		return nil, errors.New("Invalid chain name")
+		return nil, common.InvalidArgumentError("chainName", "Invalid chain name")
 	}
 	err = common.DarksideReset(int(ms.SaplingActivation), ms.BranchID, ms.ChainName)
 	if err != nil {
@@ -607,6 +934,12 @@ func (s *DarksideStreamer) AddAddressUtxo(ctx context.Context, arg *walletrpc.Ge
 		Satoshis:    uint64(arg.ValueZat),
 		Height:      int(arg.Height),
 	}
+	if len(arg.CurrencyValues) > 0 {
+		utxosReply.CurrencyValues = make(map[string]json.Number)
+		for currency, value := range arg.CurrencyValues {
+			utxosReply.CurrencyValues[currency] = common.FormatAmount(value)
+		}
+	}
 	err := common.DarksideAddAddressUtxo(utxosReply)
 	return &walletrpc.Empty{}, err
 }
@@ -616,3 +949,39 @@ func (s *DarksideStreamer) ClearAddressUtxo(ctx context.Context, arg *walletrpc.
 	err := common.DarksideClearAddressUtxos()
 	return &walletrpc.Empty{}, err
 }
+
+// AddIdentity adds a revision of an identity which will be returned by
+// GetIdentity() and GetIdentityHistory() (above)
+func (s *DarksideStreamer) AddIdentity(ctx context.Context, arg *walletrpc.IdentityUpdate) (*walletrpc.Empty, error) {
+	if arg.Identity == nil {
+		return nil, common.InvalidArgumentError("identity", "Must specify an identity")
+	}
+	update := common.ZcashdRpcIdentityUpdate{
+		Identity:  common.IdentityToRPC(arg.Identity),
+		BlockHash: hex.EncodeToString(parser.Reverse(arg.BlockHash)),
+		Height:    int(arg.Height),
+	}
+	update.Output.Txid = hex.EncodeToString(parser.Reverse(arg.Txid))
+	update.Output.VoutNum = arg.Vout
+	err := common.DarksideAddIdentity(update)
+	return &walletrpc.Empty{}, err
+}
+
+// ClearIdentities removes the list of identity revisions
+func (s *DarksideStreamer) ClearIdentities(ctx context.Context, arg *walletrpc.Empty) (*walletrpc.Empty, error) {
+	err := common.DarksideClearIdentities()
+	return &walletrpc.Empty{}, err
+}
+
+// AddCurrency adds a currency which will be returned by GetCurrency() and
+// used by EstimateConversion() (above)
+func (s *DarksideStreamer) AddCurrency(ctx context.Context, arg *walletrpc.Currency) (*walletrpc.Empty, error) {
+	err := common.DarksideAddCurrency(common.CurrencyToRPC(arg))
+	return &walletrpc.Empty{}, err
+}
+
+// ClearCurrencies removes the list of currencies
+func (s *DarksideStreamer) ClearCurrencies(ctx context.Context, arg *walletrpc.Empty) (*walletrpc.Empty, error) {
+	err := common.DarksideClearCurrencies()
+	return &walletrpc.Empty{}, err
+}
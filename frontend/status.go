@@ -0,0 +1,35 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/asherda/lightwalletd/common"
+	"google.golang.org/grpc"
+)
+
+// StatusUnaryInterceptor converts the error returned by a unary handler,
+// such as one from zcashd, to a gRPC status (see common.StatusError).
+func StatusUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	return resp, common.StatusError(err)
+}
+
+// StatusStreamInterceptor converts the error returned by a streaming
+// handler to a gRPC status (see common.StatusError).
+func StatusStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return common.StatusError(handler(srv, ss))
+}
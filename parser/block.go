@@ -10,7 +10,6 @@ import (
 
 	"github.com/asherda/lightwalletd/parser/internal/bytestring"
 	"github.com/asherda/lightwalletd/walletrpc"
-	"github.com/pkg/errors"
 )
 
 // Block represents a full block (not a compact block).
@@ -78,7 +77,20 @@ func (b *Block) GetHeight() int {
 	if b.height != -1 {
 		return b.height
 	}
-	coinbaseScript := bytestring.String(b.vtx[0].transparentInputs[0].ScriptSig)
+	if len(b.vtx) == 0 {
+		return -1
+	}
+	b.height = coinbaseHeight(b.vtx[0])
+	return b.height
+}
+
+// coinbaseHeight returns the height encoded in a coinbase transaction's
+// scriptSig, or -1 if there isn't one.
+func coinbaseHeight(coinbase *Transaction) int {
+	if len(coinbase.transparentInputs) == 0 {
+		return -1
+	}
+	coinbaseScript := bytestring.String(coinbase.transparentInputs[0].ScriptSig)
 	var heightNum int64
 	if !coinbaseScript.ReadScriptInt64(&heightNum) {
 		return -1
@@ -96,7 +108,6 @@ func (b *Block) GetHeight() int {
 		blockHeight = 0
 	}
 
-	b.height = int(blockHeight)
 	return int(blockHeight)
 }
 
@@ -107,13 +118,9 @@ func (b *Block) GetPrevHash() []byte {
 
 // ToCompact returns the compact representation of the full block.
 func (b *Block) ToCompact() *walletrpc.CompactBlock {
-	compactBlock := &walletrpc.CompactBlock{
-		//TODO ProtoVersion: 1,
-		Height:   uint64(b.GetHeight()),
-		PrevHash: b.hdr.HashPrevBlock,
-		Hash:     b.GetEncodableHash(),
-		Time:     b.hdr.Time,
-	}
+	compactBlock := newCompactBlock(b.hdr)
+	compactBlock.Height = uint64(b.GetHeight())
+	compactBlock.Production = b.Production()
 
 	// Only Sapling transactions have a meaningful compact encoding
 	saplingTxns := make([]*walletrpc.CompactTx, 0, len(b.vtx))
@@ -126,36 +133,50 @@ func (b *Block) ToCompact() *walletrpc.CompactBlock {
 	return compactBlock
 }
 
+// newCompactBlock returns a compact block with the fields taken from the
+// header filled in.
+func newCompactBlock(hdr *BlockHeader) *walletrpc.CompactBlock {
+	return &walletrpc.CompactBlock{
+		//TODO ProtoVersion: 1,
+		PrevHash: hdr.HashPrevBlock,
+		Hash:     hdr.GetEncodableHash(),
+		Time:     hdr.Time,
+	}
+}
+
 // ParseFromSlice deserializes a block from the given data stream
 // and returns a slice to the remaining data. The caller should verify
 // there is no remaining data if none is expected.
-func (b *Block) ParseFromSlice(data []byte) (rest []byte, err error) {
+func (b *Block) ParseFromSlice(in []byte) (rest []byte, err error) {
 	hdr := NewBlockHeader()
-	data, err = hdr.ParseFromSlice(data)
+	data, err := hdr.ParseFromSlice(in)
 	if err != nil {
-		return nil, errors.Wrap(err, "parsing block header")
+		return nil, wrapParseError(err, "header", 0)
 	}
 
 	s := bytestring.String(data)
 	var txCount int
-	if !s.ReadCompactSize(&txCount) {
-		return nil, errors.New("could not read tx_count")
+	if err := s.ReadCompactSize(&txCount); err != nil {
+		return nil, compactParseError(in, s, "tx_count", err)
 	}
 	data = []byte(s)
 
-	vtx := make([]*Transaction, 0, txCount)
-	var i int
-	for i = 0; i < txCount && len(data) > 0; i++ {
+	// txCount is untrusted; a transaction is at least minTxSize bytes, so
+	// don't preallocate room for more than the remaining data can hold.
+	capacity := txCount
+	if capacity > len(data)/minTxSize {
+		capacity = len(data) / minTxSize
+	}
+	vtx := make([]*Transaction, 0, capacity)
+	for i := 0; i < txCount; i++ {
 		tx := NewTransaction()
+		offset := len(in) - len(data)
 		data, err = tx.ParseFromSlice(data)
 		if err != nil {
-			return nil, errors.Wrap(err, fmt.Sprintf("parsing transaction %d", i))
+			return nil, wrapParseError(err, fmt.Sprintf("vtx[%d]", i), offset)
 		}
 		vtx = append(vtx, tx)
 	}
-	if i < txCount {
-		return nil, errors.New("parsing block transactions: not enough data")
-	}
 	b.hdr = hdr
 	b.vtx = vtx
 	return data, nil
@@ -11,7 +11,6 @@ import (
 	"github.com/asherda/lightwalletd/parser/internal/bytestring"
 	"github.com/asherda/lightwalletd/parser/verushash"
 
-	"github.com/pkg/errors"
 	"math/big"
 )
 
@@ -61,10 +60,12 @@ type RawBlockHeader struct {
 	Solution []byte
 }
 
-// BlockHeader extends RawBlockHeader by adding a cache for the block hash.
+// BlockHeader extends RawBlockHeader by adding a cache for the block hash
+// and decoded solution.
 type BlockHeader struct {
 	*RawBlockHeader
-	cachedHash []byte
+	cachedHash     []byte
+	cachedSolution *Solution
 }
 
 // CompactLengthPrefixedLen calculates the total number of bytes needed to
@@ -138,35 +139,35 @@ func (hdr *BlockHeader) ParseFromSlice(in []byte) (rest []byte, err error) {
 	// Primary parsing layer: sort the bytes into things
 
 	if !s.ReadInt32(&hdr.Version) {
-		return in, errors.New("could not read header version")
+		return in, newParseError(in, s, "Version", 4)
 	}
 
 	if !s.ReadBytes(&hdr.HashPrevBlock, 32) {
-		return in, errors.New("could not read HashPrevBlock")
+		return in, newParseError(in, s, "HashPrevBlock", 32)
 	}
 
 	if !s.ReadBytes(&hdr.HashMerkleRoot, 32) {
-		return in, errors.New("could not read HashMerkleRoot")
+		return in, newParseError(in, s, "HashMerkleRoot", 32)
 	}
 
 	if !s.ReadBytes(&hdr.HashFinalSaplingRoot, 32) {
-		return in, errors.New("could not read HashFinalSaplingRoot")
+		return in, newParseError(in, s, "HashFinalSaplingRoot", 32)
 	}
 
 	if !s.ReadUint32(&hdr.Time) {
-		return in, errors.New("could not read timestamp")
+		return in, newParseError(in, s, "Time", 4)
 	}
 
 	if !s.ReadBytes(&hdr.NBitsBytes, 4) {
-		return in, errors.New("could not read NBits bytes")
+		return in, newParseError(in, s, "NBitsBytes", 4)
 	}
 
 	if !s.ReadBytes(&hdr.Nonce, 32) {
-		return in, errors.New("could not read Nonce bytes")
+		return in, newParseError(in, s, "Nonce", 32)
 	}
 
-	if !s.ReadCompactLengthPrefixed((*bytestring.String)(&hdr.Solution)) {
-		return in, errors.New("could not read CompactSize-prefixed Equihash solution")
+	if err := s.ReadCompactLengthPrefixed((*bytestring.String)(&hdr.Solution)); err != nil {
+		return in, compactParseError(in, s, "Solution", err)
 	}
 
 	// TODO: interpret the bytes
@@ -210,7 +211,7 @@ func (hdr *BlockHeader) GetDisplayHash() []byte {
 	//digest = sha256.Sum256(digest[:])
 
 	// VerusHash
-	vh := hashHeader(serializedHeader)
+	vh := hdr.hash(serializedHeader)
 	// Convert to big-endian
 	hdr.cachedHash = Reverse(vh)
 	return hdr.cachedHash
@@ -229,7 +230,7 @@ func (hdr *BlockHeader) GetEncodableHash() []byte {
 	//digest = sha256.Sum256(digest[:])
 
 	// Verushash
-	vh := hashHeader(serializedHeader)
+	vh := hdr.hash(serializedHeader)
 	return vh
 }
 
@@ -238,20 +239,49 @@ func (hdr *BlockHeader) GetDisplayPrevHash() []byte {
 	return Reverse(hdr.HashPrevBlock)
 }
 
-func hashHeader(serializedHeader []byte) []byte {
-	length := len(serializedHeader)
-	if serializedHeader[0] == 4 && serializedHeader[2] >= 1 {
-		if length < 144 || serializedHeader[143] < 3 {
-			return verushash.VerusHash_V2B(serializedHeader)
-		} else {
-			if serializedHeader[143] < 4 {
-				return verushash.VerusHash_V2B1(serializedHeader)
-			} else {
-				//		fmt.Println(hex.EncodeToString(Reverse(verushash.VerusHash_V2B2(serializedHeader))))
-				return verushash.VerusHash_V2B2(serializedHeader)
-			}
-		}
-	} else {
+// IsVerusV2 reports whether the header has version VERUS_V2 (or later), and
+// so a versioned Verus solution rather than an opaque one.
+func (hdr *BlockHeader) IsVerusV2() bool {
+	return hdr.Version&0xff == verusV2&0xff && (hdr.Version>>16)&0xff >= verusV2>>16
+}
+
+// SolutionVersion returns the version of the block's Verus solution, or
+// SolutionVerusV1 if the header doesn't have a versioned solution.
+func (hdr *BlockHeader) SolutionVersion() uint32 {
+	if !hdr.IsVerusV2() {
+		return SolutionVerusV1
+	}
+	return solutionVersion(hdr.Solution)
+}
+
+// GetSolution returns the decoded block solution. Headers that aren't
+// VERUS_V2 have an empty (version 0) solution.
+func (hdr *BlockHeader) GetSolution() (*Solution, error) {
+	if hdr.cachedSolution != nil {
+		return hdr.cachedSolution, nil
+	}
+	if !hdr.IsVerusV2() {
+		return &Solution{}, nil
+	}
+	sol, err := ParseSolution(hdr.Solution)
+	if err != nil {
+		return nil, wrapParseError(err, "Solution", serBlockHeaderMinusEquihashSize+
+			CompactLengthPrefixedLen(len(hdr.Solution))-len(hdr.Solution))
+	}
+	hdr.cachedSolution = sol
+	return sol, nil
+}
+
+func (hdr *BlockHeader) hash(serializedHeader []byte) []byte {
+	if !hdr.IsVerusV2() {
 		return verushash.VerusHash(serializedHeader)
 	}
+	switch v := hdr.SolutionVersion(); {
+	case v < SolutionVerusV4:
+		return verushash.VerusHash_V2B(serializedHeader)
+	case v < SolutionVerusV5:
+		return verushash.VerusHash_V2B1(serializedHeader)
+	default:
+		return verushash.VerusHash_V2B2(serializedHeader)
+	}
 }
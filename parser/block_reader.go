@@ -0,0 +1,287 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+	"github.com/asherda/lightwalletd/walletrpc"
+	"github.com/pkg/errors"
+)
+
+// ErrTrailingData is returned by BlockReader when the input continues past
+// the block's last transaction.
+var ErrTrailingData = errors.New("data remains after the last transaction")
+
+// blockReaderChunk is the smallest amount BlockReader asks its reader for.
+const blockReaderChunk = 64 * 1024
+
+// BlockReader parses a block from an io.Reader one transaction at a time, so
+// that each transaction can be handled as soon as it has arrived, and only
+// the transaction being parsed (not the whole block) needs to be buffered.
+type BlockReader struct {
+	r       io.Reader
+	buf     []byte // data read but not yet parsed
+	offset  int    // position of buf[0] within the block
+	eof     bool   // r has no more data
+	hdr     *BlockHeader
+	txCount int
+	next    int             // index of the next transaction
+	fees    []TxFee         // of the transactions after the coinbase, by ReadCompact
+	tx      []TransparentTx // of the transactions with transparent parts, by ReadCompact
+	err     error           // once set, returned by every later call
+}
+
+// TransparentTx is the transparent part of one of a block's transactions:
+// the outputs it spends and the outputs it creates.
+type TransparentTx struct {
+	Index   int    // the transaction's position in the block
+	Txid    []byte // little-endian
+	Spends  []Outpoint
+	Outputs []TxOutput
+}
+
+// ToCompact returns the compact representation of the transaction's
+// transparent part, which has no shielded spends or outputs (or fee).
+func (t TransparentTx) ToCompact() *walletrpc.CompactTx {
+	ctx := &walletrpc.CompactTx{
+		Index: uint64(t.Index),
+		Hash:  t.Txid,
+		Vin:   make([]*walletrpc.CompactTxIn, len(t.Spends)),
+		Vout:  make([]*walletrpc.TxOut, len(t.Outputs)),
+	}
+	for i, spend := range t.Spends {
+		ctx.Vin[i] = &walletrpc.CompactTxIn{PrevoutTxid: spend.Hash, PrevoutIndex: spend.Index}
+	}
+	for i, output := range t.Outputs {
+		ctx.Vout[i] = &walletrpc.TxOut{Value: output.Value, ScriptPubKey: output.Script}
+	}
+	return ctx
+}
+
+// NewBlockReader returns a BlockReader that reads a serialized block from r.
+func NewBlockReader(r io.Reader) *BlockReader {
+	return &BlockReader{r: r}
+}
+
+// fill reads at least n more bytes into buf, or as many as remain. New data
+// only ever goes past the end of buf, never over bytes that have been parsed,
+// since transactions already returned still refer to those.
+func (br *BlockReader) fill(n int) error {
+	if cap(br.buf)-len(br.buf) < n {
+		size := len(br.buf) + n
+		if size < 2*len(br.buf) {
+			size = 2 * len(br.buf)
+		}
+		if size < blockReaderChunk {
+			size = blockReaderChunk
+		}
+		buf := make([]byte, len(br.buf), size)
+		copy(buf, br.buf)
+		br.buf = buf
+	}
+	m, err := io.ReadAtLeast(br.r, br.buf[len(br.buf):cap(br.buf)], n)
+	br.buf = br.buf[:len(br.buf)+m]
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		br.eof = true
+		return nil
+	}
+	return err
+}
+
+// parse runs parseFromSlice on the buffered data, reading more and retrying
+// for as long as it fails only because the data ends too soon. On success
+// the parsed bytes are consumed.
+func (br *BlockReader) parse(parseFromSlice func([]byte) ([]byte, error)) error {
+	for {
+		rest, err := parseFromSlice(br.buf)
+		if err == nil {
+			n := len(br.buf) - len(rest)
+			br.buf = br.buf[n:]
+			br.offset += n
+			return nil
+		}
+		pe, ok := err.(*ParseError)
+		if !ok || !pe.Truncated() || br.eof {
+			return err
+		}
+		if err := br.fill(pe.Expected - pe.Actual); err != nil {
+			return err
+		}
+	}
+}
+
+// Close closes the reader that BlockReader was created with, if it's an
+// io.Closer (such as an HTTP response body), for when the rest of the block
+// isn't wanted. Data already parsed remains available.
+func (br *BlockReader) Close() error {
+	if c, ok := br.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Header reads the block header and transaction count, if that hasn't been
+// done already, and returns the header.
+func (br *BlockReader) Header() (*BlockHeader, error) {
+	if br.err != nil || br.hdr != nil {
+		return br.hdr, br.err
+	}
+	hdr := NewBlockHeader()
+	if err := br.parse(hdr.ParseFromSlice); err != nil {
+		br.err = wrapParseError(err, "header", br.offset)
+		return nil, br.err
+	}
+	err := br.parse(func(data []byte) ([]byte, error) {
+		s := bytestring.String(data)
+		if err := s.ReadCompactSize(&br.txCount); err != nil {
+			return nil, compactParseError(data, s, "tx_count", err)
+		}
+		return []byte(s), nil
+	})
+	if err != nil {
+		br.err = wrapParseError(err, "", br.offset)
+		return nil, br.err
+	}
+	br.hdr = hdr
+	return hdr, nil
+}
+
+// TxCount returns the number of transactions the block declares, or -1 if
+// the header hasn't been read.
+func (br *BlockReader) TxCount() int {
+	if br.hdr == nil {
+		return -1
+	}
+	return br.txCount
+}
+
+// Next returns the block's next transaction, reading the header first if
+// necessary. After the last transaction it returns io.EOF, or ErrTrailingData
+// if the input doesn't end there.
+func (br *BlockReader) Next() (*Transaction, error) {
+	if _, err := br.Header(); err != nil {
+		return nil, err
+	}
+	if br.next == br.txCount {
+		if len(br.buf) == 0 && !br.eof {
+			if err := br.fill(1); err != nil {
+				br.err = err
+				return nil, err
+			}
+		}
+		if len(br.buf) > 0 {
+			br.err = wrapParseError(ErrTrailingData, "", br.offset)
+			return nil, br.err
+		}
+		return nil, io.EOF
+	}
+	tx := NewTransaction()
+	if err := br.parse(tx.ParseFromSlice); err != nil {
+		br.err = wrapParseError(err, fmt.Sprintf("vtx[%d]", br.next), br.offset)
+		return nil, br.err
+	}
+	br.next++
+	return tx, nil
+}
+
+// ReadCompact reads the whole block and returns its compact representation,
+// the same as Block.ToCompact() would. Each transaction is converted as it
+// is parsed and then discarded, except for the first and last, which are
+// needed for the block's Production.
+func (br *BlockReader) ReadCompact() (*walletrpc.CompactBlock, error) {
+	hdr, err := br.Header()
+	if err != nil {
+		return nil, err
+	}
+	compactBlock := newCompactBlock(hdr)
+	height := -1
+	var coinbase, last *Transaction
+	for {
+		tx, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if br.next == 1 {
+			coinbase = tx
+			height = coinbaseHeight(tx)
+		}
+		last = tx
+		if br.next > 1 {
+			fee, known := tx.Fee()
+			br.fees = append(br.fees, TxFee{Fee: fee, Known: known, Size: len(tx.Bytes())})
+		}
+		// Copied, so that what's kept doesn't hold on to the read buffers.
+		spends, outputs := tx.SpentOutpoints(), tx.Outputs()
+		for i := range spends {
+			spends[i].Hash = append([]byte(nil), spends[i].Hash...)
+		}
+		for i := range outputs {
+			outputs[i].Script = append([]byte(nil), outputs[i].Script...)
+		}
+		if len(spends) > 0 || len(outputs) > 0 {
+			br.tx = append(br.tx, TransparentTx{
+				Index:   br.next - 1,
+				Txid:    tx.GetEncodableHash(),
+				Spends:  spends,
+				Outputs: outputs,
+			})
+		}
+		if tx.HasSaplingElements() {
+			compactBlock.Vtx = append(compactBlock.Vtx, tx.ToCompact(br.next-1))
+		}
+	}
+	compactBlock.Height = uint64(height)
+	compactBlock.Production = newBlockProduction(hdr, coinbase, last, br.txCount)
+	return compactBlock, nil
+}
+
+// Fees returns the fees and sizes of the block's transactions (other than
+// the coinbase), once ReadCompact has read them.
+func (br *BlockReader) Fees() []TxFee {
+	return br.fees
+}
+
+// Transparent returns the transparent parts of the block's transactions,
+// once ReadCompact has read them. Transactions that are only shielded are
+// left out.
+func (br *BlockReader) Transparent() []TransparentTx {
+	return br.tx
+}
+
+// ParseFromReader deserializes a block from r, which must contain exactly one
+// block. Unlike ParseFromSlice, only one transaction at a time is buffered
+// beyond what the parsed block itself retains.
+func (b *Block) ParseFromReader(r io.Reader) error {
+	br := NewBlockReader(r)
+	hdr, err := br.Header()
+	if err != nil {
+		return err
+	}
+	// As in ParseFromSlice, don't trust the declared count for allocation.
+	capacity := br.TxCount()
+	if capacity > blockReaderChunk/minTxSize {
+		capacity = blockReaderChunk / minTxSize
+	}
+	vtx := make([]*Transaction, 0, capacity)
+	for {
+		tx, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		vtx = append(vtx, tx)
+	}
+	b.hdr = hdr
+	b.vtx = vtx
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"os"
+	"testing"
+	"testing/iotest"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+func readTestBlocks(t *testing.T) [][]byte {
+	testBlocks, err := os.Open("../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+
+	var blocks [][]byte
+	scan := bufio.NewScanner(testBlocks)
+	for scan.Scan() {
+		blockData, err := hex.DecodeString(scan.Text())
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, blockData)
+	}
+	return blocks
+}
+
+func TestBlockReader(t *testing.T) {
+	for i, blockData := range readTestBlocks(t) {
+		block := NewBlock()
+		if _, err := block.ParseFromSlice(blockData); err != nil {
+			t.Fatal(err)
+		}
+
+		// A reader that returns one byte at a time forces a refill at
+		// every point in the block.
+		streamed := NewBlock()
+		err := streamed.ParseFromReader(iotest.OneByteReader(bytes.NewReader(blockData)))
+		if err != nil {
+			t.Fatalf("block %d: %v", i, err)
+		}
+		if streamed.GetTxCount() != block.GetTxCount() {
+			t.Fatalf("block %d: tx count %d, want %d", i, streamed.GetTxCount(), block.GetTxCount())
+		}
+		if streamed.GetHeight() != block.GetHeight() {
+			t.Errorf("block %d: height %d, want %d", i, streamed.GetHeight(), block.GetHeight())
+		}
+		for j, tx := range streamed.Transactions() {
+			if !bytes.Equal(tx.Bytes(), block.Transactions()[j].Bytes()) {
+				t.Errorf("block %d tx %d: raw bytes differ", i, j)
+			}
+		}
+
+		reader := NewBlockReader(bytes.NewReader(blockData))
+		compact, err := reader.ReadCompact()
+		if err != nil {
+			t.Fatalf("block %d: %v", i, err)
+		}
+		if !protobuf.Equal(compact, block.ToCompact()) {
+			t.Errorf("block %d: compact block differs from ToCompact()", i)
+		}
+
+		// The transparent parts of every transaction that has them.
+		transparent := reader.Transparent()
+		k := 0
+		for j, tx := range block.Transactions() {
+			if len(tx.transparentInputs) == 0 && len(tx.transparentOutputs) == 0 {
+				continue
+			}
+			if k == len(transparent) {
+				t.Fatalf("block %d: missing transparent tx %d", i, j)
+			}
+			ttx := transparent[k]
+			if ttx.Index != j || !bytes.Equal(ttx.Txid, tx.GetEncodableHash()) ||
+				len(ttx.Spends) != len(tx.SpentOutpoints()) || len(ttx.Outputs) != len(tx.transparentOutputs) {
+				t.Fatalf("block %d: unexpected transparent tx %d: %v", i, j, ttx)
+			}
+			for n, out := range tx.transparentOutputs {
+				if ttx.Outputs[n].Value != out.Value || !bytes.Equal(ttx.Outputs[n].Script, out.Script) {
+					t.Fatalf("block %d tx %d: unexpected output %d", i, j, n)
+				}
+			}
+			ctx := ttx.ToCompact()
+			if ctx.Index != uint64(j) || len(ctx.Vin) != len(ttx.Spends) || len(ctx.Vout) != len(ttx.Outputs) ||
+				len(ctx.Spends) != 0 || len(ctx.Outputs) != 0 {
+				t.Fatalf("block %d: unexpected compact transparent tx %d: %v", i, j, ctx)
+			}
+			for n, in := range ttx.Spends {
+				if !bytes.Equal(ctx.Vin[n].PrevoutTxid, in.Hash) || ctx.Vin[n].PrevoutIndex != in.Index {
+					t.Fatalf("block %d tx %d: unexpected compact input %d", i, j, n)
+				}
+			}
+			k++
+		}
+		if k != len(transparent) || k == 0 {
+			t.Fatalf("block %d: %d transparent txs, want %d", i, len(transparent), k)
+		}
+	}
+}
+
+func TestBlockReaderNext(t *testing.T) {
+	blockData := readTestBlocks(t)[2]
+	br := NewBlockReader(bytes.NewReader(blockData))
+	if br.TxCount() != -1 {
+		t.Fatal("unexpected TxCount before reading the header")
+	}
+	if _, err := br.Header(); err != nil {
+		t.Fatal(err)
+	}
+	if br.TxCount() != int(blockData[1487]) {
+		t.Fatal("unexpected TxCount: ", br.TxCount())
+	}
+	n := 0
+	for {
+		_, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != br.TxCount() {
+		t.Fatalf("read %d transactions, expected %d", n, br.TxCount())
+	}
+	// Once finished, it stays finished.
+	if _, err := br.Next(); err != io.EOF {
+		t.Fatal("expected io.EOF, got ", err)
+	}
+}
+
+func TestBlockReaderErrors(t *testing.T) {
+	blockData := readTestBlocks(t)[3]
+
+	// Truncated input gives the same error as ParseFromSlice.
+	for _, cut := range []int{0, 100, 1487, 1488, 1600, len(blockData) - 1} {
+		_, want := NewBlock().ParseFromSlice(blockData[:cut])
+		err := NewBlock().ParseFromReader(iotest.HalfReader(bytes.NewReader(blockData[:cut])))
+		pe, ok := err.(*ParseError)
+		if !ok || !pe.Truncated() {
+			t.Fatalf("cut %d: expected a truncation ParseError, got %v", cut, err)
+		}
+		if err.Error() != want.Error() {
+			t.Errorf("cut %d: got %q, want %q", cut, err, want)
+		}
+	}
+
+	// Data after the last transaction is an error.
+	overlong := append(append([]byte{}, blockData...), 0)
+	err := NewBlock().ParseFromReader(bytes.NewReader(overlong))
+	if !errors.Is(err, ErrTrailingData) {
+		t.Fatal("expected ErrTrailingData, got ", err)
+	}
+	if err.(*ParseError).Offset != len(blockData) {
+		t.Fatal("unexpected offset: ", err)
+	}
+
+	// Errors from the reader itself are passed on.
+	readErr := errors.New("read failed")
+	r := io.MultiReader(bytes.NewReader(blockData[:1600]), iotest.ErrReader(readErr))
+	_, err = NewBlockReader(r).ReadCompact()
+	if !errors.Is(err, readErr) {
+		t.Fatal("expected the read error, got ", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"fmt"
+
+	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+)
+
+// ParseError locates a parsing failure within the input. Nested parsers
+// prefix Path and add their own starting position to Offset as the error
+// propagates, so the error returned from Block.ParseFromSlice() names the
+// field as, for example, vtx[3].shieldedOutputs[1].encCiphertext, and
+// Offset counts from the start of the block.
+type ParseError struct {
+	Path     string // field that could not be read
+	Offset   int    // byte offset of that field within the parsed data
+	Expected int    // bytes the field needs, if known
+	Actual   int    // bytes that remained at Offset
+	Err      error  // underlying cause, if the data was present but invalid
+}
+
+func (e *ParseError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "input"
+	}
+	msg := fmt.Sprintf("could not read %s at offset %d", path, e.Offset)
+	if e.Expected > e.Actual {
+		msg += fmt.Sprintf(": need %d bytes, have %d", e.Expected, e.Actual)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap allows errors.Is() and errors.As() to see the underlying cause,
+// such as a *LimitError.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Truncated reports whether the input ended before the field was complete,
+// as opposed to containing invalid data; more input may let parsing succeed.
+func (e *ParseError) Truncated() bool {
+	return e.Err == nil && e.Expected > e.Actual
+}
+
+// newParseError reports that field, which needs expected bytes, could not be
+// read from s, the unread remainder of data.
+func newParseError(data []byte, s bytestring.String, field string, expected int) error {
+	return &ParseError{
+		Path:     field,
+		Offset:   len(data) - len(s),
+		Expected: expected,
+		Actual:   len(s),
+	}
+}
+
+// compactParseError attributes err, returned by reading a CompactSize or
+// CompactSize-prefixed field from s, the unread remainder of data, to field.
+func compactParseError(data []byte, s bytestring.String, field string, err error) error {
+	e := &ParseError{
+		Path:   field,
+		Offset: len(data) - len(s),
+		Err:    err,
+	}
+	if ce, ok := err.(*bytestring.CompactSizeError); ok {
+		// ParseError counts from the start of the field.
+		e.Expected = ce.Offset + ce.Expected
+		e.Actual = ce.Offset + ce.Actual
+		e.Err = ce.Err
+	}
+	return e
+}
+
+// wrapParseError attributes err, returned while parsing field from data
+// starting at offset, to that field, so that the resulting ParseError is
+// relative to the enclosing structure.
+func wrapParseError(err error, field string, offset int) error {
+	if pe, ok := err.(*ParseError); ok {
+		if pe.Path == "" {
+			pe.Path = field
+		} else if field != "" {
+			pe.Path = field + "." + pe.Path
+		}
+		pe.Offset += offset
+		return pe
+	}
+	return &ParseError{Path: field, Offset: offset, Err: err}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package parser
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseErrorTruncated(t *testing.T) {
+	testBlocks, err := os.Open("../testdata/blocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testBlocks.Close()
+
+	scan := bufio.NewScanner(testBlocks)
+	scan.Buffer(make([]byte, 0, 1024*1024), 10*1024*1024)
+	if !scan.Scan() {
+		t.Fatal("no test blocks")
+	}
+	blockData, err := hex.DecodeString(scan.Text())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every proper prefix of a valid block fails with a truncation error
+	// whose field lies within the prefix and extends beyond its end.
+	for cut := 0; cut < len(blockData); cut++ {
+		_, err := NewBlock().ParseFromSlice(blockData[:cut])
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Fatalf("cut %d: expected a ParseError, got %v", cut, err)
+		}
+		if !pe.Truncated() {
+			t.Fatalf("cut %d: expected truncation: %v", cut, pe)
+		}
+		if pe.Offset+pe.Actual != cut {
+			t.Fatalf("cut %d: offset %d plus actual %d doesn't reach the end", cut, pe.Offset, pe.Actual)
+		}
+	}
+
+	for _, tt := range []struct {
+		cut    int
+		path   string
+		offset int
+	}{
+		{0, "header.Version", 0},
+		{90, "header.HashFinalSaplingRoot", 68},
+		{140, "header.Solution", 140},
+		{1487, "tx_count", 1487},
+		{1488, "vtx[0].header", 1488},
+		{1490, "vtx[0].header", 1488},
+	} {
+		_, err := NewBlock().ParseFromSlice(blockData[:tt.cut])
+		pe, ok := err.(*ParseError)
+		if !ok {
+			t.Fatalf("cut %d: expected a ParseError, got %v", tt.cut, err)
+		}
+		if pe.Path != tt.path || pe.Offset != tt.offset {
+			t.Errorf("cut %d: got %s at %d, want %s at %d", tt.cut, pe.Path, pe.Offset, tt.path, tt.offset)
+		}
+	}
+}
+
+func TestParseErrorNested(t *testing.T) {
+	testData, err := os.Open("../testdata/zip243_raw_tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testData.Close()
+
+	scan := bufio.NewScanner(testData)
+	var rawTx []byte
+	for scan.Scan() {
+		if !strings.HasPrefix(scan.Text(), "#") {
+			rawTx, _ = hex.DecodeString(scan.Text())
+			break
+		}
+	}
+
+	// Cut the transaction in the middle of the last Sapling output's
+	// encCiphertext, found by parsing it in full first.
+	tx := NewTransaction()
+	if _, err := tx.ParseFromSlice(rawTx); err != nil {
+		t.Fatal(err)
+	}
+	n := len(tx.shieldedOutputs)
+	if n == 0 {
+		t.Fatal("test vector has no shielded outputs")
+	}
+	last := tx.shieldedOutputs[n-1]
+	start := len(rawTx) - cap(last.encCiphertext)
+	_, err = NewTransaction().ParseFromSlice(rawTx[:start+100])
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatal("expected a ParseError, got ", err)
+	}
+	want := fmt.Sprintf("shieldedOutputs[%d].encCiphertext", n-1)
+	if pe.Path != want || pe.Offset != start || pe.Expected != 580 || pe.Actual != 100 {
+		t.Fatalf("unexpected error: %v", pe)
+	}
+
+	// A count that isn't a valid CompactSize is bad data, not truncation.
+	bad := []byte{1, 0, 0, 0, 0xfd, 1, 0}
+	_, err = NewTransaction().ParseFromSlice(bad)
+	pe, ok = err.(*ParseError)
+	if !ok || pe.Path != "tx_in_count" || pe.Offset != 4 || pe.Truncated() {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Limit errors are located too, and still match their sentinel.
+	limits := DefaultLimits
+	limits.MaxShieldedOutputs = n - 1
+	_, err = NewTransactionWithLimits(limits).ParseFromSlice(rawTx)
+	pe, ok = err.(*ParseError)
+	if !ok || pe.Path != "nShieldedOutput" || !errors.Is(err, ErrTooManyShieldedOutputs) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
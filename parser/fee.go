@@ -0,0 +1,90 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+// Values above this can't be valid amounts (MAX_MONEY is far smaller), so a
+// fee isn't calculated from them.
+const maxValue = 1 << 62
+
+// Fee returns the transaction's fee, which can only be calculated (without
+// reference to prior transactions) if it has no transparent inputs:
+// valueBalance + sum(vpubNew) - sum(vpubOld) - sum(tOut).
+func (tx *Transaction) Fee() (uint64, bool) {
+	if len(tx.transparentInputs) > 0 {
+		return 0, false
+	}
+	if tx.valueBalance > maxValue || tx.valueBalance < -maxValue {
+		return 0, false
+	}
+	fee := tx.valueBalance
+	for _, js := range tx.joinSplits {
+		if js.vpubNew > maxValue || js.vpubOld > maxValue {
+			return 0, false
+		}
+		fee += int64(js.vpubNew) - int64(js.vpubOld)
+		if fee > maxValue || fee < -maxValue {
+			return 0, false
+		}
+	}
+	for _, out := range tx.transparentOutputs {
+		if out.Value > maxValue {
+			return 0, false
+		}
+		fee -= int64(out.Value)
+		if fee < -maxValue {
+			return 0, false
+		}
+	}
+	if fee < 0 {
+		return 0, false
+	}
+	return uint64(fee), true
+}
+
+// TxFee is a (non-coinbase) transaction's fee, if known, and its size, from
+// which fee rates are estimated.
+type TxFee struct {
+	Fee   uint64
+	Known bool // false if the transaction has transparent inputs
+	Size  int
+}
+
+// Serialized sizes of typical transparent inputs and outputs, for
+// EstimateTxSize: a P2PKH input (a 72-byte signature and compressed public
+// key) and a P2PKH output.
+const (
+	p2pkhInputSize  = 32 + 4 + 1 + (1 + 72 + 1 + 33) + 4
+	p2pkhOutputSize = 8 + 1 + 25
+)
+
+// compactSizeLen is the length of n encoded as a CompactSize.
+func compactSizeLen(n int) int {
+	switch {
+	case n < 253:
+		return 1
+	case n <= 0xffff:
+		return 3
+	case n <= 0xffffffff:
+		return 5
+	}
+	return 9
+}
+
+// EstimateTxSize returns the size of a Sapling (version 4) transaction with
+// the given numbers of P2PKH inputs and outputs and Sapling spends and
+// outputs.
+func EstimateTxSize(transparentInputs, transparentOutputs, saplingSpends, saplingOutputs int) int {
+	size := 4 + 4 + // header, nVersionGroupId
+		compactSizeLen(transparentInputs) + transparentInputs*p2pkhInputSize +
+		compactSizeLen(transparentOutputs) + transparentOutputs*p2pkhOutputSize +
+		4 + 4 + 8 + // nLockTime, nExpiryHeight, valueBalance
+		compactSizeLen(saplingSpends) + saplingSpends*spendSize +
+		compactSizeLen(saplingOutputs) + saplingOutputs*outputSize +
+		1 // no JoinSplits
+	if saplingSpends+saplingOutputs > 0 {
+		size += 64 // bindingSig
+	}
+	return size
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"bufio"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFee(t *testing.T) {
+	testData, err := os.Open("../testdata/zip243_raw_tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testData.Close()
+	var rawTxData [][]byte
+	scan := bufio.NewScanner(testData)
+	for scan.Scan() {
+		dataLine := scan.Text()
+		if strings.HasPrefix(dataLine, "#") {
+			continue
+		}
+		txData, _ := hex.DecodeString(dataLine)
+		rawTxData = append(rawTxData, txData)
+	}
+	parse := func(i int) *Transaction {
+		tx := NewTransaction()
+		if _, err := tx.ParseFromSlice(rawTxData[i]); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	// Test vector 1 has transparent inputs, so its fee can't be known.
+	if fee, ok := parse(1).Fee(); ok {
+		t.Fatal("unexpected fee", fee)
+	}
+
+	// Test vector 0 has two joinsplits and two transparent outputs.
+	tests := []struct {
+		modify func(tx *Transaction)
+		fee    uint64
+		ok     bool
+	}{
+		{func(tx *Transaction) {
+			tx.valueBalance = 30000
+			tx.joinSplits[0].vpubOld, tx.joinSplits[0].vpubNew = 0, 5000
+			tx.joinSplits[1].vpubOld, tx.joinSplits[1].vpubNew = 2000, 0
+			tx.transparentOutputs[0].Value = 20000
+			tx.transparentOutputs[1].Value = 3000
+		}, 10000, true},
+		{func(tx *Transaction) {
+			tx.valueBalance = 10000
+			tx.joinSplits = nil
+			tx.transparentOutputs = nil
+		}, 10000, true},
+		{func(tx *Transaction) {
+			tx.valueBalance = 10000
+			tx.joinSplits = nil
+			tx.transparentOutputs[0].Value = 10000
+			tx.transparentOutputs[1].Value = 1
+		}, 0, false},
+		{func(tx *Transaction) {
+			tx.valueBalance = -maxValue - 1
+		}, 0, false},
+		{func(tx *Transaction) {
+			tx.valueBalance = 0
+			tx.joinSplits = nil
+			tx.transparentOutputs[0].Value = maxValue + 1
+		}, 0, false},
+	}
+	for i, tt := range tests {
+		tx := parse(0)
+		tt.modify(tx)
+		fee, ok := tx.Fee()
+		if fee != tt.fee || ok != tt.ok {
+			t.Errorf("Test %d: expected %d %v, got %d %v", i, tt.fee, tt.ok, fee, ok)
+		}
+	}
+}
+
+func TestEstimateTxSize(t *testing.T) {
+	tests := []struct {
+		tIn, tOut, spends, outputs int
+		size                       int
+	}{
+		{1, 2, 0, 0, 245},
+		{0, 0, 1, 2, 2373},
+		{1, 0, 0, 1, 1189},
+		{0, 300, 0, 0, 300*p2pkhOutputSize + 31},
+	}
+	for i, tt := range tests {
+		if size := EstimateTxSize(tt.tIn, tt.tOut, tt.spends, tt.outputs); size != tt.size {
+			t.Errorf("Test %d: expected size %d, got %d", i, tt.size, size)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package parser
+
+import (
+	"bufio"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Seed with the (hex-encoded) blocks in testdata/corpus; run with
+// go test -fuzz=FuzzBlockParseFromSlice ./parser
+func FuzzBlockParseFromSlice(f *testing.F) {
+	seeds, err := filepath.Glob("../testdata/corpus/*")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, name := range seeds {
+		blockHex, err := ioutil.ReadFile(name)
+		if err != nil {
+			f.Fatal(err)
+		}
+		blockData, err := hex.DecodeString(strings.TrimSpace(string(blockHex)))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(blockData)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		block := NewBlock()
+		rest, err := block.ParseFromSlice(data)
+		if err != nil {
+			return
+		}
+		if len(rest) > len(data) {
+			t.Fatal("rest is longer than the input")
+		}
+		for _, tx := range block.Transactions() {
+			checkWithinLimits(t, tx, DefaultLimits)
+		}
+		block.GetHeight()
+		block.ToCompact()
+	})
+}
+
+// Seed with the ZIP 243 test vectors; run with
+// go test -fuzz=FuzzTransactionParseFromSlice ./parser
+func FuzzTransactionParseFromSlice(f *testing.F) {
+	testData, err := os.Open("../testdata/zip243_raw_tx")
+	if err != nil {
+		f.Fatal(err)
+	}
+	defer testData.Close()
+	scan := bufio.NewScanner(testData)
+	for scan.Scan() {
+		dataLine := scan.Text()
+		if strings.HasPrefix(dataLine, "#") {
+			continue
+		}
+		txData, err := hex.DecodeString(dataLine)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(txData)
+	}
+	// Small enough that the fuzzer can reach every limit.
+	limits := Limits{
+		MaxTxSize:          4000,
+		MaxInputs:          3,
+		MaxOutputs:         3,
+		MaxSpends:          2,
+		MaxShieldedOutputs: 2,
+		MaxJoinSplits:      1,
+		MaxScriptSize:      20,
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tx := NewTransactionWithLimits(limits)
+		_, err := tx.ParseFromSlice(data)
+		if err != nil {
+			return
+		}
+		checkWithinLimits(t, tx, limits)
+		tx.ToCompact(0)
+	})
+}
+
+func checkWithinLimits(t *testing.T, tx *Transaction, limits Limits) {
+	if len(tx.Bytes()) > limits.MaxTxSize {
+		t.Fatal("transaction exceeds MaxTxSize")
+	}
+	if len(tx.transparentInputs) > limits.MaxInputs {
+		t.Fatal("transaction exceeds MaxInputs")
+	}
+	if len(tx.transparentOutputs) > limits.MaxOutputs {
+		t.Fatal("transaction exceeds MaxOutputs")
+	}
+	if len(tx.shieldedSpends) > limits.MaxSpends {
+		t.Fatal("transaction exceeds MaxSpends")
+	}
+	if len(tx.shieldedOutputs) > limits.MaxShieldedOutputs {
+		t.Fatal("transaction exceeds MaxShieldedOutputs")
+	}
+	if len(tx.joinSplits) > limits.MaxJoinSplits {
+		t.Fatal("transaction exceeds MaxJoinSplits")
+	}
+	for _, in := range tx.transparentInputs {
+		if len(in.ScriptSig) > limits.MaxScriptSize {
+			t.Fatal("transaction exceeds MaxScriptSize")
+		}
+	}
+	for _, out := range tx.transparentOutputs {
+		if len(out.Script) > limits.MaxScriptSize {
+			t.Fatal("transaction exceeds MaxScriptSize")
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+// Package gcs implements the Golomb-coded set filters of BIP 158, with the
+// parameters of its basic filter type, so that wallets can test whether a
+// block may contain one of their items without revealing which items those
+// are. Unlike in BIP 158, what's put in a block's filter is up to the caller.
+package gcs
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+	"sort"
+
+	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+	"github.com/pkg/errors"
+)
+
+// The Golomb-Rice parameter and the inverse false positive rate, as in BIP
+// 158's basic filter.
+const (
+	P = 19
+	M = 784931
+)
+
+// KeySize is the size of the SipHash key that a filter's items are hashed with.
+const KeySize = 16
+
+// Key returns a block's filter key: the first 16 bytes of its hash, in
+// little-endian (wire format) byte order.
+func Key(blockHash []byte) [KeySize]byte {
+	var key [KeySize]byte
+	copy(key[:], blockHash)
+	return key
+}
+
+// hashToRange maps an item to [0, n*M), using the item's SipHash.
+func hashToRange(key [KeySize]byte, item []byte, n uint64) uint64 {
+	hi, _ := bits.Mul64(sipHash(key, item), n*M)
+	return hi
+}
+
+// bitWriter appends bits, most significant first, to a byte slice.
+type bitWriter struct {
+	data  []byte
+	nbits uint // bits used in the last byte
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if w.nbits%8 == 0 {
+		w.data = append(w.data, 0)
+		w.nbits = 0
+	}
+	if bit {
+		w.data[len(w.data)-1] |= 0x80 >> w.nbits
+	}
+	w.nbits++
+}
+
+func (w *bitWriter) writeBits(value uint64, n uint) {
+	for i := n; i > 0; i-- {
+		w.writeBit(value&(1<<(i-1)) != 0)
+	}
+}
+
+// bitReader reads bits, most significant first, from a byte slice.
+type bitReader struct {
+	data []byte
+	pos  uint // in bits
+}
+
+func (r *bitReader) readBit() (bool, bool) {
+	if r.pos >= uint(len(r.data))*8 {
+		return false, false
+	}
+	bit := r.data[r.pos/8]&(0x80>>(r.pos%8)) != 0
+	r.pos++
+	return bit, true
+}
+
+func (r *bitReader) readBits(n uint) (uint64, bool) {
+	var value uint64
+	for i := uint(0); i < n; i++ {
+		bit, ok := r.readBit()
+		if !ok {
+			return 0, false
+		}
+		value <<= 1
+		if bit {
+			value |= 1
+		}
+	}
+	return value, true
+}
+
+// readDelta reads a Golomb-Rice coded difference between successive values.
+func (r *bitReader) readDelta() (uint64, bool) {
+	var quotient uint64
+	for {
+		bit, ok := r.readBit()
+		if !ok {
+			return 0, false
+		}
+		if !bit {
+			break
+		}
+		quotient++
+	}
+	remainder, ok := r.readBits(P)
+	return quotient<<P | remainder, ok
+}
+
+// appendCompactSize appends n in the CompactSize encoding.
+func appendCompactSize(data []byte, n uint64) []byte {
+	switch {
+	case n < 253:
+		return append(data, byte(n))
+	case n <= 0xffff:
+		return binary.LittleEndian.AppendUint16(append(data, 253), uint16(n))
+	case n <= 0xffffffff:
+		return binary.LittleEndian.AppendUint32(append(data, 254), uint32(n))
+	}
+	return binary.LittleEndian.AppendUint64(append(data, 255), n)
+}
+
+// Build returns the serialized filter of the set of items (duplicates and
+// empty items are left out): the number of items, as a CompactSize, then
+// the Golomb-Rice coded differences between their sorted hashed values.
+func Build(key [KeySize]byte, items [][]byte) []byte {
+	unique := make(map[string]bool, len(items))
+	for _, item := range items {
+		if len(item) > 0 {
+			unique[string(item)] = true
+		}
+	}
+	n := uint64(len(unique))
+	values := make([]uint64, 0, n)
+	for item := range unique {
+		values = append(values, hashToRange(key, []byte(item), n))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	w := &bitWriter{data: appendCompactSize(nil, n)}
+	w.nbits = 8
+	var last uint64
+	for _, value := range values {
+		delta := value - last
+		for q := delta >> P; q > 0; q-- {
+			w.writeBit(true)
+		}
+		w.writeBit(false)
+		w.writeBits(delta, P)
+		last = value
+	}
+	return w.data
+}
+
+// MatchAny returns true if any of the items may be in the filter; false
+// positives occur for about 1 in M items.
+func MatchAny(filter []byte, key [KeySize]byte, items [][]byte) (bool, error) {
+	s := bytestring.String(filter)
+	var count int
+	if err := s.ReadCompactSize(&count); err != nil {
+		return false, errors.Wrap(err, "could not read filter item count")
+	}
+	n := uint64(count)
+	if n == 0 || len(items) == 0 {
+		return false, nil
+	}
+	targets := make([]uint64, 0, len(items))
+	for _, item := range items {
+		targets = append(targets, hashToRange(key, item, n))
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+
+	r := &bitReader{data: []byte(s)}
+	var value uint64
+	for i := uint64(0); i < n; i++ {
+		delta, ok := r.readDelta()
+		if !ok {
+			return false, errors.New("filter is truncated")
+		}
+		value += delta
+		for len(targets) > 0 && targets[0] < value {
+			targets = targets[1:]
+		}
+		if len(targets) == 0 {
+			return false, nil
+		}
+		if targets[0] == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Match returns true if the item may be in the filter.
+func Match(filter []byte, key [KeySize]byte, item []byte) (bool, error) {
+	return MatchAny(filter, key, [][]byte{item})
+}
+
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+// Header returns a filter's header, which commits to it and (through the
+// previous block's filter header) to the filters of all the blocks before.
+// Hashes are in little-endian (wire format) byte order.
+func Header(filter []byte, prevHeader []byte) []byte {
+	return doubleSHA256(append(doubleSHA256(filter), prevHeader...))
+}
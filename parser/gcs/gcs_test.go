@@ -0,0 +1,103 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package gcs
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+)
+
+func TestSipHash(t *testing.T) {
+	// From the SipHash paper's test vectors.
+	var key [KeySize]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	data := make([]byte, 15)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	if h := sipHash(key, nil); h != 0x726fdb47dd0e0e31 {
+		t.Fatalf("unexpected hash of empty message %x", h)
+	}
+	if h := sipHash(key, data); h != 0xa129ca6149be45e5 {
+		t.Fatalf("unexpected hash %x", h)
+	}
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func TestGenesisFilter(t *testing.T) {
+	// The Bitcoin testnet genesis block, from BIP 158's test vectors; its
+	// only item is its coinbase output script.
+	hash, _ := hex.DecodeString("000000000933ea01ad0ee984209779baaec3ced90fa3f408719526f8d77f4943")
+	script, _ := hex.DecodeString("4104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac")
+	key := Key(reverse(hash))
+	filter := Build(key, [][]byte{script})
+	if hex.EncodeToString(filter) != "019dfca8" {
+		t.Fatal("unexpected filter", hex.EncodeToString(filter))
+	}
+	header := Header(filter, make([]byte, 32))
+	if hex.EncodeToString(reverse(header)) != "21584579b7eb08997773e5aeff3a7f932700042d0ed2a6129012b7d7ae81b750" {
+		t.Fatal("unexpected filter header", hex.EncodeToString(reverse(header)))
+	}
+	if ok, err := Match(filter, key, script); !ok || err != nil {
+		t.Fatal("filter doesn't match its item", err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	key := Key([]byte("0123456789abcdef0123456789abcdef"))
+	item := func(i int) []byte {
+		return binary.LittleEndian.AppendUint32([]byte("item"), uint32(i))
+	}
+	items := [][]byte{nil}
+	for i := 0; i < 1000; i++ {
+		items = append(items, item(i), item(i))
+	}
+	filter := Build(key, items)
+	if filter[0] != 253 || binary.LittleEndian.Uint16(filter[1:]) != 1000 {
+		t.Fatal("unexpected filter item count", hex.EncodeToString(filter[:3]))
+	}
+	for i := 0; i < 1000; i++ {
+		if ok, err := Match(filter, key, item(i)); !ok || err != nil {
+			t.Fatal("filter doesn't match item", i, err)
+		}
+	}
+	falsePositives := 0
+	for i := 1000; i < 11000; i++ {
+		if ok, _ := Match(filter, key, item(i)); ok {
+			falsePositives++
+		}
+	}
+	if falsePositives > 2 {
+		t.Fatal("too many false positives", falsePositives)
+	}
+	if ok, err := MatchAny(filter, key, [][]byte{item(5000), item(20000), item(999)}); !ok || err != nil {
+		t.Fatal("filter doesn't match any of the items", err)
+	}
+	// A different key gives a different filter.
+	if ok, _ := Match(filter, Key([]byte("fedcba9876543210")), item(0)); ok {
+		t.Fatal("filter matched with the wrong key")
+	}
+
+	empty := Build(key, nil)
+	if hex.EncodeToString(empty) != "00" {
+		t.Fatal("unexpected empty filter", hex.EncodeToString(empty))
+	}
+	if ok, err := Match(empty, key, item(0)); ok || err != nil {
+		t.Fatal("empty filter matched", err)
+	}
+	if _, err := Match(filter[:len(filter)/2], key, item(999)); err == nil {
+		t.Fatal("expected an error matching a truncated filter")
+	}
+}
@@ -7,7 +7,10 @@
 package bytestring
 
 import (
+	"fmt"
 	"io"
+
+	"github.com/pkg/errors"
 )
 
 const maxCompactSize uint64 = 0x02000000
@@ -88,14 +91,36 @@ func (s *String) ReadBytes(out *[]byte, n int) bool {
 	return true
 }
 
+// ErrBadCompactSize is the cause given by a CompactSizeError when the
+// CompactSize was present but not in its canonical form, or too large.
+var ErrBadCompactSize = errors.New("non-canonical or oversized CompactSize")
+
+// CompactSizeError reports why a CompactSize, or the data it prefixes, could
+// not be read. Offset counts from the start of the CompactSize.
+type CompactSizeError struct {
+	Offset   int   // where the read failed
+	Expected int   // bytes needed from Offset
+	Actual   int   // bytes that remained at Offset
+	Err      error // ErrBadCompactSize if the data was present but invalid
+}
+
+func (e *CompactSizeError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("offset %d: %v", e.Offset, e.Err)
+	}
+	return fmt.Sprintf("offset %d: need %d bytes, have %d", e.Offset, e.Expected, e.Actual)
+}
+
 // ReadCompactSize reads and interprets a Bitcoin-custom compact integer
-// encoding used for length-prefixing and count values. If the values fall
-// outside the expected canonical ranges, it returns false.
-func (s *String) ReadCompactSize(size *int) bool {
+// encoding used for length-prefixing and count values. If there isn't enough
+// data, or the values fall outside the expected canonical ranges, it returns
+// a *CompactSizeError and leaves the string unchanged.
+func (s *String) ReadCompactSize(size *int) error {
 	*size = 0
-	lenBytes := s.read(1)
+	t := *s
+	lenBytes := t.read(1)
 	if lenBytes == nil {
-		return false
+		return &CompactSizeError{Expected: 1}
 	}
 	lenByte := lenBytes[0]
 
@@ -112,17 +137,16 @@ func (s *String) ReadCompactSize(size *int) bool {
 		lenLen = 4
 		minSize = 0x10000
 	case lenByte == 255:
-		// this case is not currently usable, beyond maxCompactSize;
-		// also, this is not possible if sizeof(int) is 4 bytes
-		//     lenLen = 8; minSize = 0x100000000
-		return false
+		// always beyond maxCompactSize, so rejected below
+		lenLen = 8
+		minSize = 0x100000000
 	}
 
 	if lenLen > 0 {
 		// expect little endian uint of varying size
-		lenBytes := s.read(lenLen)
-		if len(lenBytes) < lenLen {
-			return false
+		lenBytes := t.read(lenLen)
+		if lenBytes == nil {
+			return &CompactSizeError{Expected: 1 + lenLen, Actual: len(*s)}
 		}
 		for i := lenLen - 1; i >= 0; i-- {
 			length <<= 8
@@ -131,27 +155,32 @@ func (s *String) ReadCompactSize(size *int) bool {
 	}
 
 	if length > maxCompactSize || length < minSize {
-		return false
+		return &CompactSizeError{Expected: 1 + lenLen, Actual: len(*s), Err: ErrBadCompactSize}
 	}
 	*size = int(length)
-	return true
+	*s = t
+	return nil
 }
 
 // ReadCompactLengthPrefixed reads data prefixed by a CompactSize-encoded
-// length field into out. It reports whether the read was successful.
-func (s *String) ReadCompactLengthPrefixed(out *String) bool {
+// length field into out. If it can't, it returns a *CompactSizeError and
+// leaves the string unchanged; the error's Offset is past the length field
+// if only the data is short.
+func (s *String) ReadCompactLengthPrefixed(out *String) error {
+	t := *s
 	var length int
-	if !s.ReadCompactSize(&length) {
-		return false
+	if err := t.ReadCompactSize(&length); err != nil {
+		return err
 	}
 
-	v := s.read(length)
+	v := t.read(length)
 	if v == nil {
-		return false
+		return &CompactSizeError{Offset: len(*s) - len(t), Expected: length, Actual: len(t)}
 	}
 
+	*s = t
 	*out = v
-	return true
+	return nil
 }
 
 // ReadInt32 decodes a little-endian 32-bit value into out, treating it as
@@ -212,13 +212,42 @@ var readCompactSizeTests = []struct {
 func TestString_ReadCompactSize(t *testing.T) {
 	for i, tt := range readCompactSizeTests {
 		var expected int
-		ok := tt.s.ReadCompactSize(&expected)
-		if ok != tt.ok {
-			t.Errorf("ReadCompactSize case %d: want: %v, have: %v", i, tt.ok, ok)
+		before := len(tt.s)
+		err := tt.s.ReadCompactSize(&expected)
+		if (err == nil) != tt.ok {
+			t.Errorf("ReadCompactSize case %d: want: %v, have: %v", i, tt.ok, err)
 		}
 		if expected != tt.expected {
 			t.Errorf("ReadCompactSize case %d: want: %v, have: %v", i, tt.expected, expected)
 		}
+		if err != nil && len(tt.s) != before {
+			t.Errorf("ReadCompactSize case %d: string advanced on failure", i)
+		}
+	}
+
+	// Errors say whether the data was short or invalid.
+	for i, tt := range []struct {
+		s        String
+		expected int
+		bad      bool
+	}{
+		{String{}, 1, false},
+		{String{253, 254}, 3, false},
+		{String{254, 0, 0}, 5, false},
+		{String{255, 0, 0, 0, 2}, 9, false},
+		{String{253, 1, 0}, 3, true},
+		{String{254, 1, 0, 0, 2, 7}, 5, true},
+		{String{255, 0, 0, 0, 2, 0, 0, 0, 0}, 9, true},
+	} {
+		var size int
+		err := tt.s.ReadCompactSize(&size)
+		ce, ok := err.(*CompactSizeError)
+		if !ok {
+			t.Fatalf("error case %d: expected a CompactSizeError, got %v", i, err)
+		}
+		if ce.Offset != 0 || ce.Expected != tt.expected || ce.Actual != len(tt.s) || (ce.Err == ErrBadCompactSize) != tt.bad {
+			t.Errorf("error case %d: unexpected error %+v", i, ce)
+		}
 	}
 }
 
@@ -228,8 +257,8 @@ func TestString_ReadCompactLengthPrefixed(t *testing.T) {
 	v := String{}
 
 	// read the 3 and thus the following 3 bytes
-	if !s.ReadCompactLengthPrefixed(&v) {
-		t.Fatalf("ReadCompactLengthPrefix failed")
+	if err := s.ReadCompactLengthPrefixed(&v); err != nil {
+		t.Fatalf("ReadCompactLengthPrefix failed: %v", err)
 	}
 	if len(v) != 3 {
 		t.Fatalf("ReadCompactLengthPrefix incorrect length")
@@ -239,8 +268,8 @@ func TestString_ReadCompactLengthPrefixed(t *testing.T) {
 	}
 
 	// read the 2 and then two bytes
-	if !s.ReadCompactLengthPrefixed(&v) {
-		t.Fatalf("ReadCompactLengthPrefix failed")
+	if err := s.ReadCompactLengthPrefixed(&v); err != nil {
+		t.Fatalf("ReadCompactLengthPrefix failed: %v", err)
 	}
 	if len(v) != 2 {
 		t.Fatalf("ReadCompactLengthPrefix incorrect length")
@@ -249,15 +278,21 @@ func TestString_ReadCompactLengthPrefixed(t *testing.T) {
 		t.Fatalf("ReadCompactLengthPrefix unexpected return")
 	}
 
-	// at the end of the String, another read should return false
-	if s.ReadCompactLengthPrefixed(&v) {
-		t.Fatalf("ReadCompactLengthPrefix unexpected success")
+	// at the end of the String, another read should fail
+	err := s.ReadCompactLengthPrefixed(&v)
+	if ce, ok := err.(*CompactSizeError); !ok || ce.Offset != 0 || ce.Expected != 1 || ce.Actual != 0 {
+		t.Fatalf("ReadCompactLengthPrefix unexpected result: %v", err)
 	}
 
 	// this string is too short (less than 2 bytes of data)
 	s = String{3, 55, 66}
-	if s.ReadCompactLengthPrefixed(&v) {
-		t.Fatalf("ReadCompactLengthPrefix unexpected success")
+	err = s.ReadCompactLengthPrefixed(&v)
+	if ce, ok := err.(*CompactSizeError); !ok || ce.Offset != 1 || ce.Expected != 3 || ce.Actual != 2 || ce.Err != nil {
+		t.Fatalf("ReadCompactLengthPrefix unexpected result: %v", err)
+	}
+	// a failed read leaves the string where it was
+	if len(s) != 3 {
+		t.Fatalf("ReadCompactLengthPrefix advanced on failure")
 	}
 }
 
@@ -0,0 +1,116 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Limits bounds the resources a single transaction may claim while it is
+// being parsed. Element counts come from attacker-controlled CompactSize
+// fields (SendTransaction and darkside staging pass client bytes straight
+// to the parser), so they are checked before anything is allocated.
+type Limits struct {
+	MaxTxSize          int // serialized size of the whole transaction
+	MaxInputs          int // transparent inputs
+	MaxOutputs         int // transparent outputs
+	MaxSpends          int // Sapling Spend descriptions
+	MaxShieldedOutputs int // Sapling Output descriptions
+	MaxJoinSplits      int // JoinSplit descriptions
+	MaxScriptSize      int // a single scriptSig or output script
+}
+
+// MaxTxSize is the largest transaction consensus allows
+// (MAX_TX_SIZE_AFTER_SAPLING in zcashd / verusd).
+const MaxTxSize = 2000000
+
+// Minimum serialized sizes, used to derive the default element limits: a
+// transaction of MaxTxSize bytes can't contain more elements than this.
+const (
+	minTxSize        = 4 + 1 + 1 + 4 // header, empty vin and vout, nLockTime
+	minTxInSize      = 32 + 4 + 1 + 4
+	minTxOutSize     = 8 + 1
+	spendSize        = 384
+	outputSize       = 948
+	minJoinSplitSize = 8 + 8 + 32 + 2*32 + 2*32 + 32 + 32 + 2*32 + 192 + 2*601
+)
+
+// DefaultLimits are the limits applied by NewTransaction() unless SetLimits()
+// says otherwise. They never reject a transaction that consensus allows.
+var DefaultLimits = Limits{
+	MaxTxSize:          MaxTxSize,
+	MaxInputs:          MaxTxSize / minTxInSize,
+	MaxOutputs:         MaxTxSize / minTxOutSize,
+	MaxSpends:          MaxTxSize / spendSize,
+	MaxShieldedOutputs: MaxTxSize / outputSize,
+	MaxJoinSplits:      MaxTxSize / minJoinSplitSize,
+	MaxScriptSize:      MaxTxSize,
+}
+
+// limits are the limits applied by NewTransaction().
+var limits = DefaultLimits
+
+// SetLimits sets the limits that NewTransaction(), and so block parsing,
+// applies from then on; a zero field keeps its default. It's meant to be
+// called once, at startup, before any parsing. Limits tighter than the
+// transactions in the chain make those blocks unparseable.
+func SetLimits(l Limits) {
+	set := func(field *int, value int) {
+		if value > 0 {
+			*field = value
+		}
+	}
+	limits = DefaultLimits
+	set(&limits.MaxTxSize, l.MaxTxSize)
+	set(&limits.MaxInputs, l.MaxInputs)
+	set(&limits.MaxOutputs, l.MaxOutputs)
+	set(&limits.MaxSpends, l.MaxSpends)
+	set(&limits.MaxShieldedOutputs, l.MaxShieldedOutputs)
+	set(&limits.MaxJoinSplits, l.MaxJoinSplits)
+	set(&limits.MaxScriptSize, l.MaxScriptSize)
+}
+
+// GetLimits returns the limits that NewTransaction() applies.
+func GetLimits() Limits {
+	return limits
+}
+
+// These identify which limit was exceeded; test for them with errors.Is().
+var (
+	ErrTooManyInputs          = errors.New("too many transparent inputs")
+	ErrTooManyOutputs         = errors.New("too many transparent outputs")
+	ErrTooManySpends          = errors.New("too many shielded spends")
+	ErrTooManyShieldedOutputs = errors.New("too many shielded outputs")
+	ErrTooManyJoinSplits      = errors.New("too many joinsplits")
+	ErrScriptTooLarge         = errors.New("script too large")
+	ErrTxTooLarge             = errors.New("transaction too large")
+)
+
+// LimitError is returned when a transaction declares more of something
+// than its limits allow.
+type LimitError struct {
+	Kind  error // one of the Err* values above
+	Count int   // declared count, or size in bytes
+	Limit int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v: %d exceeds limit %d", e.Kind, e.Count, e.Limit)
+}
+
+// Unwrap allows errors.Is(err, ErrTooManyInputs) and friends.
+func (e *LimitError) Unwrap() error {
+	return e.Kind
+}
+
+// checkLimit returns a *LimitError if count is over limit.
+func checkLimit(kind error, count, limit int) error {
+	if count > limit {
+		return &LimitError{Kind: kind, Count: count, Limit: limit}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/asherda/lightwalletd/parser/verushash"
+	"github.com/asherda/lightwalletd/walletrpc"
+)
+
+// isPOSNonce reports whether the nonce has the form verusd gives to
+// proof-of-stake blocks (CPOSNonce::IsPOSNonce): its high 128 bits are a
+// VerusHash of its low 128 bits.
+func (hdr *BlockHeader) isPOSNonce() bool {
+	if len(hdr.Nonce) != 32 {
+		return false
+	}
+	low := make([]byte, 32)
+	copy(low, hdr.Nonce[:16])
+	var digest []byte
+	if hdr.IsVerusV2() {
+		digest = verushash.VerusHash_V2(low)
+	} else {
+		digest = verushash.VerusHash(low)
+	}
+	return bytes.Equal(hdr.Nonce[16:], digest[:16])
+}
+
+// IsStake reports whether the header is that of a proof-of-stake block. From
+// SolutionVerusV6 the solution says so directly; before that, the nonce
+// carries the stake target (CBlockHeader::IsVerusPOSBlock).
+func (hdr *BlockHeader) IsStake() bool {
+	if sol, err := hdr.GetSolution(); err == nil && sol.IsAdvanced() {
+		return !sol.IsPoW()
+	}
+	return hdr.isPOSNonce() && binary.LittleEndian.Uint32(hdr.Nonce) != 0
+}
+
+// IsStake reports whether the block is proof-of-stake rather than mined.
+func (b *Block) IsStake() bool {
+	return b.hdr.IsStake()
+}
+
+// Production returns how the block was produced and what its coinbase paid.
+func (b *Block) Production() *walletrpc.BlockProduction {
+	var coinbase, last *Transaction
+	if len(b.vtx) > 0 {
+		coinbase = b.vtx[0]
+		last = b.vtx[len(b.vtx)-1]
+	}
+	return newBlockProduction(b.hdr, coinbase, last, len(b.vtx))
+}
+
+// newBlockProduction builds the BlockProduction for a block of txCount
+// transactions from its header and first and last transactions.
+func newBlockProduction(hdr *BlockHeader, coinbase, last *Transaction, txCount int) *walletrpc.BlockProduction {
+	p := &walletrpc.BlockProduction{
+		Staked:          hdr.IsStake(),
+		SolutionVersion: hdr.SolutionVersion(),
+	}
+	if coinbase != nil {
+		for _, out := range coinbase.transparentOutputs {
+			p.Reward += int64(out.Value)
+			if p.Destination == "" && out.Value > 0 {
+				p.Destination = ScriptAddress(out.Script)
+			}
+		}
+	}
+	// A staked block ends with the stake transaction, which spends the
+	// staked output (a single input) back to the staker.
+	if p.Staked && txCount > 1 && len(last.transparentInputs) == 1 && len(last.transparentOutputs) > 0 {
+		p.StakeTxHash = last.GetEncodableHash()
+		for _, out := range last.transparentOutputs {
+			p.StakeValue += int64(out.Value)
+		}
+	}
+	return p
+}
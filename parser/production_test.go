@@ -0,0 +1,91 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package parser
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBlockProduction(t *testing.T) {
+	blockData := readTestBlocks(t)[2]
+	block := NewBlock()
+	if _, err := block.ParseFromSlice(blockData); err != nil {
+		t.Fatal(err)
+	}
+
+	// An ordinary (mined) block.
+	p := block.Production()
+	if p.Staked || block.IsStake() {
+		t.Fatal("unexpected staked block")
+	}
+	var reward int64
+	for _, out := range block.vtx[0].transparentOutputs {
+		reward += int64(out.Value)
+	}
+	if p.Reward != reward || reward == 0 {
+		t.Fatalf("unexpected reward %d, want %d", p.Reward, reward)
+	}
+	if p.Destination == "" || p.Destination[0] != 'R' {
+		t.Fatal("unexpected destination: ", p.Destination)
+	}
+	if p.StakeTxHash != nil || p.StakeValue != 0 {
+		t.Fatal("unexpected stake for a mined block")
+	}
+	if compact := block.ToCompact(); compact.Production.Reward != reward {
+		t.Fatal("ToCompact() missing production")
+	}
+
+	// From solution V6 the descriptor says how the block was produced.
+	stakeTx := block.vtx[0]
+	block.vtx = []*Transaction{block.vtx[0], stakeTx}
+	block.hdr.Version = verusV2
+	block.hdr.Solution = makeSolution(SolutionVerusV6, 0, nil, nil)
+	block.hdr.cachedSolution = nil
+	p = block.Production()
+	if !p.Staked || p.SolutionVersion != SolutionVerusV6 {
+		t.Fatal("expected a staked V6 block: ", p)
+	}
+	if !bytes.Equal(p.StakeTxHash, stakeTx.GetEncodableHash()) || p.StakeValue != reward {
+		t.Fatal("unexpected stake: ", p)
+	}
+
+	block.hdr.Solution = makeSolution(SolutionVerusV6, solutionPoW, nil, nil)
+	block.hdr.cachedSolution = nil
+	if block.IsStake() {
+		t.Fatal("expected a mined V6 block")
+	}
+
+	// Before V6, a mined block's nonce isn't a PoS nonce.
+	block.hdr.Solution = makeSolution(SolutionVerusV5, 0, nil, nil)
+	block.hdr.cachedSolution = nil
+	if block.IsStake() {
+		t.Fatal("unexpected staked V5 block")
+	}
+}
+
+func TestBlockProductionPOSNonce(t *testing.T) {
+	hdr := &BlockHeader{RawBlockHeader: &RawBlockHeader{
+		Version:  verusV2,
+		Solution: makeSolution(SolutionVerusV5, 0, nil, nil),
+	}}
+	for _, tt := range []struct {
+		nonce string
+		stake bool
+	}{
+		// The stake target 0x1d00ffff, then the VerusHash V2 of the low
+		// 128 bits (zero padded to 32 bytes), from verusd's hashing code.
+		{"ffff001d0405060708090a0b0c0d0e0f8bdabfc297e3c0bcc114db1dffb6ade1", true},
+		// A PoS nonce needs a target.
+		{"000000000405060708090a0b0c0d0e0f200487ed4dda2359fd23a983f015a1df", false},
+		// The hash doesn't match.
+		{"ffff001d0405060708090a0b0c0d0e0f200487ed4dda2359fd23a983f015a1df", false},
+	} {
+		hdr.Nonce, _ = hex.DecodeString(tt.nonce)
+		if hdr.IsStake() != tt.stake {
+			t.Errorf("nonce %s: IsStake() is %v, want %v", tt.nonce, !tt.stake, tt.stake)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package sapling
+
+import (
+	"math/big"
+)
+
+// Jubjub is the twisted Edwards curve -u^2 + v^2 = 1 + d.u^2.v^2 over the
+// scalar field of BLS12-381 (section 5.4.9.3 of the Zcash protocol
+// specification). Only what the Pedersen hash needs is implemented, using
+// math/big; this isn't constant time, but nothing here is secret.
+
+var (
+	fieldModulus, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+	// d = -(10240/10241)
+	edwardsD = func() *big.Int {
+		d := new(big.Int).ModInverse(big.NewInt(10241), fieldModulus)
+		d.Mul(d, big.NewInt(-10240))
+		return d.Mod(d, fieldModulus)
+	}()
+	edwardsD2 = fmod(new(big.Int).Lsh(edwardsD, 1))
+)
+
+func fmod(x *big.Int) *big.Int {
+	return x.Mod(x, fieldModulus)
+}
+
+func fmul(x, y *big.Int) *big.Int {
+	return fmod(new(big.Int).Mul(x, y))
+}
+
+func fadd(x, y *big.Int) *big.Int {
+	return fmod(new(big.Int).Add(x, y))
+}
+
+func fsub(x, y *big.Int) *big.Int {
+	return fmod(new(big.Int).Sub(x, y))
+}
+
+// point is a Jubjub point in extended coordinates: u = U/Z, v = V/Z, T = U.V/Z.
+type point struct {
+	u, v, z, t *big.Int
+}
+
+func newIdentity() *point {
+	return &point{big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)}
+}
+
+// add returns p + q (the "add-2008-hwcd-3" formulas, complete on Jubjub).
+func (p *point) add(q *point) *point {
+	a := fmul(fsub(p.v, p.u), fsub(q.v, q.u))
+	b := fmul(fadd(p.v, p.u), fadd(q.v, q.u))
+	c := fmul(fmul(p.t, edwardsD2), q.t)
+	d := fmod(new(big.Int).Lsh(fmul(p.z, q.z), 1))
+	return combineExtended(a, b, c, d)
+}
+
+// addNiels returns p + q where q is in the form precomputed by niels.
+func (p *point) addNiels(q *nielsPoint) *point {
+	a := fmul(fsub(p.v, p.u), q.vMinusU)
+	b := fmul(fadd(p.v, p.u), q.vPlusU)
+	c := fmul(p.t, q.t2d)
+	d := fmod(new(big.Int).Lsh(p.z, 1))
+	return combineExtended(a, b, c, d)
+}
+
+func combineExtended(a, b, c, d *big.Int) *point {
+	e := fsub(b, a)
+	f := fsub(d, c)
+	g := fadd(d, c)
+	h := fadd(b, a)
+	return &point{fmul(e, f), fmul(g, h), fmul(f, g), fmul(e, h)}
+}
+
+func (p *point) double() *point {
+	return p.add(p)
+}
+
+// affine returns the (u, v) coordinates of p.
+func (p *point) affine() (u, v *big.Int) {
+	zinv := new(big.Int).ModInverse(p.z, fieldModulus)
+	return fmul(p.u, zinv), fmul(p.v, zinv)
+}
+
+func (p *point) isIdentity() bool {
+	u, v := p.affine()
+	return u.Sign() == 0 && v.Cmp(big.NewInt(1)) == 0
+}
+
+// nielsPoint is an affine point precomputed for addNiels.
+type nielsPoint struct {
+	vMinusU, vPlusU, t2d *big.Int
+}
+
+func (p *point) niels() *nielsPoint {
+	u, v := p.affine()
+	return &nielsPoint{fsub(v, u), fadd(v, u), fmul(fmul(u, v), edwardsD2)}
+}
+
+// neg returns -q; negating a point negates its u coordinate.
+func (q *nielsPoint) neg() *nielsPoint {
+	return &nielsPoint{q.vPlusU, q.vMinusU, fsub(big.NewInt(0), q.t2d)}
+}
+
+// decodePoint decodes the 32-byte encoding of a Jubjub point (repr_J): the
+// little-endian v coordinate, with the sign of u in the top bit. It returns
+// nil if the encoding isn't that of a point on the curve.
+func decodePoint(b [32]byte) *point {
+	sign := uint(b[31] >> 7)
+	b[31] &= 0x7f
+	v := leBytesToInt(b[:])
+	if v.Cmp(fieldModulus) >= 0 {
+		return nil
+	}
+	// u^2 = (v^2 - 1) / (d.v^2 + 1)
+	v2 := fmul(v, v)
+	den := new(big.Int).ModInverse(fadd(fmul(edwardsD, v2), big.NewInt(1)), fieldModulus)
+	if den == nil {
+		return nil
+	}
+	u := new(big.Int).ModSqrt(fmul(fsub(v2, big.NewInt(1)), den), fieldModulus)
+	if u == nil {
+		return nil
+	}
+	if u.Bit(0) != sign {
+		if u.Sign() == 0 {
+			return nil
+		}
+		u.Sub(fieldModulus, u)
+	}
+	return &point{u, v, big.NewInt(1), fmul(u, v)}
+}
+
+func leBytesToInt(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// intToLEBytes returns the 32-byte little-endian encoding of a field element.
+func intToLEBytes(x *big.Int) (out [32]byte) {
+	be := x.Bytes()
+	for i := range be {
+		out[i] = be[len(be)-1-i]
+	}
+	return out
+}
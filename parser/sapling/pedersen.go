@@ -0,0 +1,108 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package sapling
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+// The Sapling Pedersen hash (section 5.4.1.7 of the Zcash protocol
+// specification), restricted to the Merkle tree hash MerkleCRH^Sapling.
+
+const (
+	pedersenPersonalization = "Zcash_PH"
+	// The first block hashed by GroupHash: the "URS" of section 5.9.
+	groupHashFirstBlock = "096b36a5804bfacef1691e173c366a47ff5ba84a44f26ddd7e8d9f79d5b42df0"
+
+	chunksPerGenerator = 63
+	// The Merkle hash input is 6 bits of layer plus two 255-bit nodes,
+	// which is 172 3-bit chunks, so it uses three generators.
+	merkleHashBits       = 6 + 2*255
+	merkleHashChunks     = merkleHashBits / 3
+	merkleHashGenerators = (merkleHashChunks + chunksPerGenerator - 1) / chunksPerGenerator
+)
+
+var (
+	pedersenOnce sync.Once
+	// pedersenTable[i][j][k] is (k+1).2^(4j) times generator i.
+	pedersenTable [merkleHashGenerators][chunksPerGenerator][4]*nielsPoint
+)
+
+// groupHash is GroupHash^J(r)_URS(personalization, tag); it returns nil
+// if the hash isn't a point of prime order.
+func groupHash(personalization string, tag []byte) *point {
+	h := blake2s256(personalization, append([]byte(groupHashFirstBlock), tag...))
+	p := decodePoint(h)
+	if p == nil {
+		return nil
+	}
+	// Multiply by the cofactor, 8.
+	p = p.double().double().double()
+	if p.isIdentity() {
+		return nil
+	}
+	return p
+}
+
+// findGroupHash returns the first successful groupHash of tag followed by a
+// counter byte.
+func findGroupHash(personalization string, tag []byte) *point {
+	tag = append(tag, 0)
+	for i := 0; i < 256; i++ {
+		tag[len(tag)-1] = byte(i)
+		if p := groupHash(personalization, tag); p != nil {
+			return p
+		}
+	}
+	panic("sapling: no group hash found")
+}
+
+func initPedersen() {
+	for i := range pedersenTable {
+		tag := make([]byte, 4)
+		binary.LittleEndian.PutUint32(tag, uint32(i))
+		base := findGroupHash(pedersenPersonalization, tag)
+		for j := range pedersenTable[i] {
+			multiple := base
+			for k := range pedersenTable[i][j] {
+				pedersenTable[i][j][k] = multiple.niels()
+				multiple = multiple.add(base)
+			}
+			base = base.double().double().double().double()
+		}
+	}
+}
+
+// MerkleHash returns MerkleCRH^Sapling of two adjacent nodes at the given
+// layer, counting up from the leaves (layer 0), as zcashd's
+// SaplingMerkleTree does.
+func MerkleHash(layer int, left, right *Node) Node {
+	pedersenOnce.Do(initPedersen)
+
+	bit := func(i int) uint {
+		switch {
+		case i < 6:
+			return uint(layer>>i) & 1
+		case i < 6+255:
+			i -= 6
+			return uint(left[i/8]>>(i%8)) & 1
+		default:
+			i -= 6 + 255
+			return uint(right[i/8]>>(i%8)) & 1
+		}
+	}
+	acc := newIdentity()
+	for chunk := 0; chunk < merkleHashChunks; chunk++ {
+		a, b, c := bit(3*chunk), bit(3*chunk+1), bit(3*chunk+2)
+		q := pedersenTable[chunk/chunksPerGenerator][chunk%chunksPerGenerator][a+2*b]
+		if c == 1 {
+			q = q.neg()
+		}
+		acc = acc.addNiels(q)
+	}
+	u, _ := acc.affine()
+	return intToLEBytes(u)
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+// Package sapling maintains the Sapling note commitment tree, so that tree
+// states can be computed from compact blocks rather than requested from
+// zcashd.
+package sapling
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Depth is the depth of the Sapling note commitment tree.
+	Depth = 32
+	// SubtreeHeight is the height of the subtrees whose roots are served
+	// by GetSubtreeRoots (each has 2^16 leaves).
+	SubtreeHeight = 16
+)
+
+// Node is a node of the tree: a note commitment (cmu) at the leaves, or a
+// MerkleHash. Both are little-endian encodings of field elements.
+type Node [32]byte
+
+var (
+	emptyRootsOnce sync.Once
+	emptyRoots     [Depth + 1]Node
+)
+
+// EmptyRoot returns the root of an empty subtree of the given height; an
+// empty leaf is the field element 1 (Uncommitted^Sapling).
+func EmptyRoot(height int) *Node {
+	emptyRootsOnce.Do(func() {
+		emptyRoots[0][0] = 1
+		for i := 1; i <= Depth; i++ {
+			emptyRoots[i] = MerkleHash(i-1, &emptyRoots[i-1], &emptyRoots[i-1])
+		}
+	})
+	return &emptyRoots[height]
+}
+
+// Tree is the frontier of an incremental Merkle tree of note commitments,
+// which is enough to append to it and compute its root; it's the same as
+// zcashd's SaplingMerkleTree (IncrementalMerkleTree), and has the same
+// serialization, which is what z_gettreestate returns as the finalState.
+type Tree struct {
+	left, right *Node
+	// parents[i], if not nil, is the root of a complete subtree of
+	// height i+1 to the left of left and right.
+	parents []*Node
+	root    *Node // cached; nil if it must be recomputed
+}
+
+// NewTree returns an empty tree.
+func NewTree() *Tree {
+	return &Tree{}
+}
+
+// Append adds a note commitment to the tree.
+func (t *Tree) Append(cmu []byte) error {
+	if len(cmu) != len(Node{}) {
+		return errors.New("bad note commitment length")
+	}
+	leaf := new(Node)
+	copy(leaf[:], cmu)
+	switch {
+	case t.left == nil:
+		t.left = leaf
+	case t.right == nil:
+		t.right = leaf
+	default:
+		if t.full() {
+			return errors.New("tree is full")
+		}
+		combined := MerkleHash(0, t.left, t.right)
+		t.left, t.right = leaf, nil
+		// Carry the new complete subtree up through the full levels.
+		i := 0
+		for ; i < len(t.parents) && t.parents[i] != nil; i++ {
+			combined = MerkleHash(i+1, t.parents[i], &combined)
+			t.parents[i] = nil
+		}
+		if i < len(t.parents) {
+			t.parents[i] = &combined
+		} else {
+			t.parents = append(t.parents, &combined)
+		}
+	}
+	t.root = nil
+	return nil
+}
+
+func (t *Tree) full() bool {
+	if t.left == nil || t.right == nil || len(t.parents) != Depth-1 {
+		return false
+	}
+	for _, p := range t.parents {
+		if p == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the number of note commitments in the tree.
+func (t *Tree) Size() uint64 {
+	var size uint64
+	if t.left != nil {
+		size++
+	}
+	if t.right != nil {
+		size++
+	}
+	for i, p := range t.parents {
+		if p != nil {
+			size += 1 << uint(i+1)
+		}
+	}
+	return size
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() Node {
+	if t.root != nil {
+		return *t.root
+	}
+	left, right := t.left, t.right
+	if left == nil {
+		left = EmptyRoot(0)
+	}
+	if right == nil {
+		right = EmptyRoot(0)
+	}
+	root := MerkleHash(0, left, right)
+	for d := 1; d < Depth; d++ {
+		if d <= len(t.parents) && t.parents[d-1] != nil {
+			root = MerkleHash(d, t.parents[d-1], &root)
+		} else {
+			root = MerkleHash(d, &root, EmptyRoot(d))
+		}
+	}
+	t.root = &root
+	return root
+}
+
+// CompletedSubtreeRoot returns the root of the complete subtree of the given
+// height whose last leaf is the most recently appended note commitment, and
+// true; or false if the most recent commitment didn't complete a subtree of
+// that height.
+func (t *Tree) CompletedSubtreeRoot(height int) (Node, bool) {
+	size := t.Size()
+	if height < 1 || height > Depth || size == 0 || size%(1<<uint(height)) != 0 {
+		return Node{}, false
+	}
+	// The size is a multiple of 2^height, so both leaves and the first
+	// height-1 parents are present.
+	root := MerkleHash(0, t.left, t.right)
+	for d := 1; d < height; d++ {
+		root = MerkleHash(d, t.parents[d-1], &root)
+	}
+	return root, true
+}
+
+// Clone returns a copy of the tree.
+func (t *Tree) Clone() *Tree {
+	c := *t
+	c.parents = append([]*Node(nil), t.parents...)
+	return &c
+}
+
+// MarshalBinary returns the tree in zcashd's serialization: left and right
+// then the vector of parents, each an optional node (a byte 0, or a byte 1
+// followed by the node).
+func (t *Tree) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	writeOptional := func(n *Node) {
+		if n == nil {
+			buf.WriteByte(0)
+			return
+		}
+		buf.WriteByte(1)
+		buf.Write(n[:])
+	}
+	writeOptional(t.left)
+	writeOptional(t.right)
+	// There are fewer than Depth parents, so the CompactSize is one byte.
+	buf.WriteByte(byte(len(t.parents)))
+	for _, p := range t.parents {
+		writeOptional(p)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary sets the tree from zcashd's serialization (see
+// MarshalBinary).
+func (t *Tree) UnmarshalBinary(data []byte) error {
+	s := bytestring.String(data)
+	readOptional := func(n **Node) bool {
+		var present byte
+		if !s.ReadByte(&present) || present > 1 {
+			return false
+		}
+		if present == 0 {
+			*n = nil
+			return true
+		}
+		*n = new(Node)
+		var b []byte
+		if !s.ReadBytes(&b, len(Node{})) {
+			return false
+		}
+		copy((*n)[:], b)
+		return true
+	}
+	var tree Tree
+	if !readOptional(&tree.left) || !readOptional(&tree.right) {
+		return errors.New("could not read tree leaves")
+	}
+	var count int
+	if s.ReadCompactSize(&count) != nil || count >= Depth {
+		return errors.New("could not read tree parents count")
+	}
+	tree.parents = make([]*Node, count)
+	for i := range tree.parents {
+		if !readOptional(&tree.parents[i]) {
+			return errors.Errorf("could not read tree parent %d", i)
+		}
+	}
+	if !s.Empty() {
+		return errors.New("trailing data after tree")
+	}
+	// The same well-formedness checks as zcashd's wfcheck().
+	if count > 0 && tree.parents[count-1] == nil {
+		return errors.New("tree has a trailing empty parent")
+	}
+	if tree.left == nil && tree.right != nil {
+		return errors.New("tree has a right leaf without a left leaf")
+	}
+	*t = tree
+	return nil
+}
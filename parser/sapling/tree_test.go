@@ -0,0 +1,166 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package sapling
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBlake2s(t *testing.T) {
+	// With no personalization this is plain BLAKE2s-256 (RFC 7693).
+	for _, test := range []struct {
+		data, digest string
+	}{
+		{"", "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9"},
+		{"abc", "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"},
+	} {
+		digest := blake2s256("\x00\x00\x00\x00\x00\x00\x00\x00", []byte(test.data))
+		if hex.EncodeToString(digest[:]) != test.digest {
+			t.Fatalf("BLAKE2s(%q) mismatch", test.data)
+		}
+	}
+}
+
+func TestEmptyRoots(t *testing.T) {
+	// From zcashd (SaplingMerkleTree::empty_root() is the last one, shown
+	// byte-reversed as 3e49b5f9...).
+	for _, test := range []struct {
+		height int
+		root   string
+	}{
+		{0, "0100000000000000000000000000000000000000000000000000000000000000"},
+		{1, "817de36ab2d57feb077634bca77819c8e0bd298c04f6fed0e6a83cc1356ca155"},
+		{Depth, "fbc2f4300c01f0b7820d00e3347c8da4ee614674376cbc45359daa54f9b5493e"},
+	} {
+		if got := hex.EncodeToString(EmptyRoot(test.height)[:]); got != test.root {
+			t.Fatalf("empty root %d is %s, want %s", test.height, got, test.root)
+		}
+	}
+	if NewTree().Root() != *EmptyRoot(Depth) {
+		t.Fatal("unexpected root of the empty tree")
+	}
+}
+
+// naiveRoot computes the root of the tree of the given leaves directly.
+func naiveRoot(leaves []Node) Node {
+	level := append([]Node(nil), leaves...)
+	for d := 0; d < Depth; d++ {
+		if len(level)%2 == 1 {
+			level = append(level, *EmptyRoot(d))
+		}
+		if len(level) == 0 {
+			return *EmptyRoot(Depth)
+		}
+		var next []Node
+		for i := 0; i < len(level); i += 2 {
+			next = append(next, MerkleHash(d, &level[i], &level[i+1]))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+func TestTreeAppend(t *testing.T) {
+	tree := NewTree()
+	var leaves []Node
+	for i := 0; i < 11; i++ {
+		var leaf Node
+		leaf[0] = byte(i + 2)
+		leaf[31] = byte(i)
+		if err := tree.Append(leaf[:]); err != nil {
+			t.Fatal(err)
+		}
+		leaves = append(leaves, leaf)
+		if tree.Size() != uint64(len(leaves)) {
+			t.Fatal("unexpected size ", tree.Size())
+		}
+		if tree.Root() != naiveRoot(leaves) {
+			t.Fatal("root mismatch with ", len(leaves), " leaves")
+		}
+
+		// Serialization round trip.
+		data, err := tree.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree2 := NewTree()
+		if err := tree2.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		data2, _ := tree2.MarshalBinary()
+		if !bytes.Equal(data, data2) || tree2.Root() != tree.Root() {
+			t.Fatal("serialization round trip mismatch")
+		}
+	}
+	// Subtrees of height 2 (4 leaves) completed at leaves 4 and 8, and of
+	// height 3 at leaf 8.
+	for _, test := range []struct {
+		height, size int
+	}{
+		{2, 4}, {2, 8}, {3, 8}, {1, 10},
+	} {
+		tree := NewTree()
+		for _, leaf := range leaves[:test.size] {
+			tree.Append(leaf[:])
+		}
+		root, ok := tree.CompletedSubtreeRoot(test.height)
+		if !ok {
+			t.Fatalf("no subtree of height %d completed at size %d", test.height, test.size)
+		}
+		n := 1 << uint(test.height)
+		want := leaves[test.size-n : test.size]
+		for d := 0; d < test.height; d++ {
+			var next []Node
+			for i := 0; i < len(want); i += 2 {
+				next = append(next, MerkleHash(d, &want[i], &want[i+1]))
+			}
+			want = next
+		}
+		if root != want[0] {
+			t.Fatalf("subtree root mismatch, height %d, size %d", test.height, test.size)
+		}
+	}
+	if _, ok := tree.CompletedSubtreeRoot(2); ok {
+		t.Fatal("unexpected completed subtree at size 11")
+	}
+
+	// A clone is independent of the original.
+	clone := tree.Clone()
+	if err := clone.Append(leaves[0][:]); err != nil {
+		t.Fatal(err)
+	}
+	if tree.Size() != 11 || clone.Size() != 12 || tree.Root() != naiveRoot(leaves) {
+		t.Fatal("clone isn't independent")
+	}
+	if tree.Append([]byte{1, 2, 3}) == nil {
+		t.Fatal("expected a bad length error")
+	}
+}
+
+func TestTreeUnmarshal(t *testing.T) {
+	// The empty tree serializes as two absent leaves and no parents.
+	data, _ := NewTree().MarshalBinary()
+	if hex.EncodeToString(data) != "000000" {
+		t.Fatal("unexpected empty tree serialization ", hex.EncodeToString(data))
+	}
+	for _, bad := range []string{
+		"",
+		"0000",
+		"00000000",                // trailing data
+		"020000",                  // bad optional
+		"0001" + zeros + "00",     // right without left
+		"01" + zeros + "000100",   // trailing empty parent
+		"01" + zeros + "0020",     // too many parents
+		"01" + zeros + "000101ab", // short parent
+	} {
+		data, _ := hex.DecodeString(bad)
+		if NewTree().UnmarshalBinary(data) == nil {
+			t.Fatal("expected an error for ", bad)
+		}
+	}
+}
+
+var zeros = hex.EncodeToString(make([]byte, 32))
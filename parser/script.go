@@ -0,0 +1,240 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// Verus base58check address version bytes (the same on mainnet and testnet).
+const (
+	PubKeyHashAddressVersion = 60  // R-addresses
+	ScriptHashAddressVersion = 85  // b-addresses
+	IdentityAddressVersion   = 102 // i-addresses (VerusIDs)
+)
+
+const (
+	opPushData1            = 0x4c
+	opPushData2            = 0x4d
+	opPushData4            = 0x4e
+	opDrop                 = 0x75
+	opDup                  = 0x76
+	opEqual                = 0x87
+	opEqualVerify          = 0x88
+	opHash160              = 0xa9
+	opCheckSig             = 0xac
+	opCheckCryptoCondition = 0xcc
+)
+
+// Eval codes of the Verus smart transaction outputs whose data begins with
+// a token output (the reserve currency values the output holds).
+const (
+	evalReserveTransfer = 0x08
+	evalReserveOutput   = 0x09
+)
+
+// ScriptAddress returns the transparent address that a standard output script
+// pays: pay-to-pubkey-hash, pay-to-script-hash or pay-to-pubkey (which is
+// shown as the R-address of the key). It returns "" for other scripts,
+// including Verus smart transaction (crypto-condition) outputs.
+func ScriptAddress(script []byte) string {
+	switch {
+	case len(script) == 25 && script[0] == opDup && script[1] == opHash160 && script[2] == 20 &&
+		script[23] == opEqualVerify && script[24] == opCheckSig:
+		return base58CheckEncode(PubKeyHashAddressVersion, script[3:23])
+	case len(script) == 23 && script[0] == opHash160 && script[1] == 20 && script[22] == opEqual:
+		return base58CheckEncode(ScriptHashAddressVersion, script[2:22])
+	case len(script) == 35 && script[0] == 33 && script[34] == opCheckSig,
+		len(script) == 67 && script[0] == 65 && script[66] == opCheckSig:
+		return base58CheckEncode(PubKeyHashAddressVersion, hash160(script[1:len(script)-1]))
+	}
+	return ""
+}
+
+// ScriptCurrencyValues returns the reserve (non-native) currency amounts
+// held by a Verus smart transaction output, keyed by currency i-address. It
+// returns nil if the script isn't a reserve output or reserve transfer, or
+// can't be decoded.
+func ScriptCurrencyValues(script []byte) map[string]int64 {
+	// <condition> OP_CHECKCRYPTOCONDITION <params> OP_DROP
+	s := bytestring.String(script)
+	var op byte
+	if _, ok := readPush(&s); !ok {
+		return nil
+	}
+	if !s.ReadByte(&op) || op != opCheckCryptoCondition {
+		return nil
+	}
+	params, ok := readPush(&s)
+	if !ok || !s.ReadByte(&op) || op != opDrop || !s.Empty() {
+		return nil
+	}
+	return ccParamsCurrencyValues(params, 1)
+}
+
+// ccParamsCurrencyValues decodes the currency values from serialized
+// crypto-condition parameters: a push of {version, eval code, m, n}, n
+// pushes of keys, then pushes of the output's data. The data of a master
+// (eval code 0) is the parameters of its conditions, which are searched
+// (to the given depth).
+func ccParamsCurrencyValues(params []byte, depth int) map[string]int64 {
+	s := bytestring.String(params)
+	header, ok := readPush(&s)
+	if !ok || len(header) != 4 {
+		return nil
+	}
+	evalCode, nKeys := header[1], int(header[3])
+	for i := 0; i < nKeys; i++ {
+		if _, ok := readPush(&s); !ok {
+			return nil
+		}
+	}
+	var data [][]byte
+	for !s.Empty() {
+		d, ok := readPush(&s)
+		if !ok {
+			return nil
+		}
+		data = append(data, d)
+	}
+	switch {
+	case evalCode == evalReserveOutput || evalCode == evalReserveTransfer:
+		if len(data) > 0 {
+			return tokenOutputValues(data[0])
+		}
+	case evalCode == 0 && depth > 0:
+		for _, d := range data {
+			if values := ccParamsCurrencyValues(d, depth-1); values != nil {
+				return values
+			}
+		}
+	}
+	return nil
+}
+
+// tokenOutputValues decodes a token output: a VARINT version followed by a
+// vector of (20-byte currency ID, 64-bit amount) pairs.
+func tokenOutputValues(data []byte) map[string]int64 {
+	s := bytestring.String(data)
+	var version uint64
+	var count int
+	if !readVarInt(&s, &version) || version == 0 || s.ReadCompactSize(&count) != nil {
+		return nil
+	}
+	values := make(map[string]int64)
+	for i := 0; i < count; i++ {
+		var id []byte
+		var amount int64
+		if !s.ReadBytes(&id, 20) || !s.ReadInt64(&amount) {
+			return nil
+		}
+		values[base58CheckEncode(IdentityAddressVersion, id)] += amount
+	}
+	return values
+}
+
+// readPush reads a script data push (including OP_0), reporting whether
+// the next opcode is one.
+func readPush(s *bytestring.String) ([]byte, bool) {
+	var op byte
+	if !s.ReadByte(&op) {
+		return nil, false
+	}
+	var length int
+	switch {
+	case op < opPushData1:
+		length = int(op)
+	case op == opPushData1:
+		var n uint8
+		if !s.ReadByte(&n) {
+			return nil, false
+		}
+		length = int(n)
+	case op == opPushData2:
+		var n uint16
+		if !s.ReadUint16(&n) {
+			return nil, false
+		}
+		length = int(n)
+	case op == opPushData4:
+		var n uint32
+		if !s.ReadUint32(&n) || n > uint32(len(*s)) {
+			return nil, false
+		}
+		length = int(n)
+	default:
+		return nil, false
+	}
+	var data []byte
+	if !s.ReadBytes(&data, length) {
+		return nil, false
+	}
+	return data, true
+}
+
+// readVarInt reads the variable-length integer (VARINT) used by Verus
+// serializations: big-endian base 128, with one added to each byte but the
+// last.
+func readVarInt(s *bytestring.String, out *uint64) bool {
+	var n uint64
+	for i := 0; i < 10; i++ {
+		var b byte
+		if !s.ReadByte(&b) {
+			return false
+		}
+		n = n<<7 | uint64(b&0x7f)
+		if b&0x80 == 0 {
+			*out = n
+			return true
+		}
+		n++
+	}
+	return false
+}
+
+// hash160 is RIPEMD160(SHA256(data)), as used for key and script IDs.
+func hash160(data []byte) []byte {
+	digest := sha256.Sum256(data)
+	h := ripemd160.New()
+	h.Write(digest[:])
+	return h.Sum(nil)
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58CheckEncode returns the base58check encoding of payload with the
+// given version byte.
+func base58CheckEncode(version byte, payload []byte) string {
+	data := make([]byte, 0, 1+len(payload)+4)
+	data = append(data, version)
+	data = append(data, payload...)
+	digest := sha256.Sum256(data)
+	digest = sha256.Sum256(digest[:])
+	data = append(data, digest[:4]...)
+
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	// Each leading zero byte is encoded as a leading '1'.
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
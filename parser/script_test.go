@@ -0,0 +1,111 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package parser
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBase58CheckEncode(t *testing.T) {
+	// Bitcoin vectors, since the encoding is the same.
+	for _, tt := range []struct {
+		version byte
+		payload string
+		address string
+	}{
+		{0, "010966776006953d5567439e5e39f86a0d273bee", "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM"},
+		{0, "0000000000000000000000000000000000000000", "1111111111111111111114oLvT2"},
+		{5, "8f55563b9a19f321c211e9b9f38cdf686ea07845", "3EktnHQD7RiAE6uzMj2ZifT9YgRrkSgzQX"},
+	} {
+		payload, _ := hex.DecodeString(tt.payload)
+		if a := base58CheckEncode(tt.version, payload); a != tt.address {
+			t.Errorf("got %s, want %s", a, tt.address)
+		}
+	}
+}
+
+func TestScriptAddress(t *testing.T) {
+	pkh := "f54a5851e9372b87810a8e60cdd2e7cfd80b6e31"
+	pubkey := "0250863ad64a87ae8a2fe83c1af1a8403cb53f53e486d8511dad8a04887e5b2352"
+	rAddress := "RXeAh6W4uQFR1wg3CkveGmBz791i4wKqgU"
+	for _, tt := range []struct {
+		script  string
+		address string
+	}{
+		{"76a914" + pkh + "88ac", rAddress},                         // P2PKH
+		{"21" + pubkey + "ac", rAddress},                            // P2PK, same key
+		{"a914" + pkh + "87", "bb6FJoxGeuqKTnACpFFcPtzeqkTHpASQTq"}, // P2SH
+		{"6a0401020304", ""},                                        // OP_RETURN
+		{"76a914" + pkh + "88", ""},                                 // truncated
+		{"", ""},
+	} {
+		script, _ := hex.DecodeString(tt.script)
+		if a := ScriptAddress(script); a != tt.address {
+			t.Errorf("script %s: got %q, want %q", tt.script, a, tt.address)
+		}
+	}
+	// Prefixes are as expected for each kind of address.
+	zero := make([]byte, 20)
+	for version, prefix := range map[byte]byte{
+		PubKeyHashAddressVersion: 'R',
+		ScriptHashAddressVersion: 'b',
+		IdentityAddressVersion:   'i',
+	} {
+		if a := base58CheckEncode(version, zero); a[0] != prefix || len(a) != 34 {
+			t.Errorf("version %d: unexpected address %s", version, a)
+		}
+	}
+}
+
+func TestScriptCurrencyValues(t *testing.T) {
+	// A token output (version 1) holding 1.5 and 2 of two currencies.
+	id1 := "01" + strings.Repeat("00", 19)
+	id2 := "02" + strings.Repeat("00", 19)
+	tokens := "01" + "02" + id1 + "80d1f00800000000" + id2 + "00c2eb0b00000000"
+	key := "14" + strings.Repeat("ab", 20)
+	push := func(data string) string {
+		if len(data)/2 < 0x4c {
+			return fmt.Sprintf("%02x", len(data)/2) + data
+		}
+		return fmt.Sprintf("4c%02x", len(data)/2) + data
+	}
+	params := func(evalCode string, data ...string) string {
+		p := push("03"+evalCode+"0101") + key
+		for _, d := range data {
+			p += push(d)
+		}
+		return p
+	}
+	script := func(params string) string {
+		return push("a1b2c3") + "cc" + push(params) + "75"
+	}
+	id1Bytes, _ := hex.DecodeString(id1)
+	id2Bytes, _ := hex.DecodeString(id2)
+	want := map[string]int64{
+		base58CheckEncode(IdentityAddressVersion, id1Bytes): 150000000,
+		base58CheckEncode(IdentityAddressVersion, id2Bytes): 200000000,
+	}
+	for _, tt := range []struct {
+		script string
+		values map[string]int64
+	}{
+		{script(params("09", tokens)), want},                // reserve output
+		{script(params("00", params("08", tokens))), want},  // within a master
+		{script(params("0e", tokens)), nil},                 // identity
+		{script(params("09", tokens[:len(tokens)-2])), nil}, // truncated amount
+		{script(params("09")), nil},                         // no data
+		{"76a914" + strings.Repeat("00", 20) + "88ac", nil}, // P2PKH
+		{"", nil},
+	} {
+		s, _ := hex.DecodeString(tt.script)
+		values := ScriptCurrencyValues(s)
+		if !reflect.DeepEqual(values, tt.values) {
+			t.Errorf("script %s: got %v, want %v", tt.script, values, tt.values)
+		}
+	}
+}
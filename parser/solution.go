@@ -0,0 +1,149 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+	"github.com/pkg/errors"
+)
+
+// Verus solution versions, as in CActivationHeight (solutiondata.h). Each
+// network upgrade that changes the block solution increments the version.
+const (
+	SolutionVerusV1   = 0
+	SolutionVerusV2   = 1 // VerusHash 2.0
+	SolutionVerusV3   = 2 // extended solution
+	SolutionVerusV4   = 3 // identity, VerusHash 2.1
+	SolutionVerusV5   = 4 // VerusHash 2.2
+	SolutionVerusV5_1 = 5 // PBaaS headers
+	SolutionVerusV6   = 6 // PBaaS, stake header
+)
+
+const (
+	// verusV2 is the block header version (CBlockHeader::VERUS_V2) that
+	// carries a versioned solution.
+	verusV2 = 0x00010004
+
+	solutionDescriptorSize = 4 + 1 + 1 + 2 + 32 + 32 // CPBaaSSolutionDescriptor
+	pbaasHeaderSize        = 20 + 32                 // CPBaaSBlockHeader
+	solutionHeaderBaseSize = 143                     // HEADER_BASESIZE
+
+	solutionPoW = 0x1 // descriptor bit set for proof-of-work blocks
+)
+
+// SolutionDescriptor is the fixed-size start of a Verus block solution
+// (CPBaaSSolutionDescriptor).
+type SolutionDescriptor struct {
+	Version         uint32
+	DescrBits       uint8
+	NumPBaaSHeaders uint8
+	ExtraDataSize   uint16
+
+	// Merkle Mountain Range roots; these are cleared in the canonical
+	// header that is merge-mined.
+	HashPrevMMRRoot  []byte
+	HashBlockMMRRoot []byte
+}
+
+// PBaaSHeader identifies a block of another (PBaaS) chain that this block's
+// solution also commits to, so it can be merge-mined (CPBaaSBlockHeader).
+type PBaaSHeader struct {
+	ChainID       []byte // 20-byte hash of the chain's name
+	HashPreHeader []byte // hash of that chain's block header, less solution
+}
+
+// Solution is a decoded Verus block solution. Solutions before version
+// SolutionVerusV5_1 contain only the descriptor, and only Version is
+// meaningful before SolutionVerusV3.
+type Solution struct {
+	SolutionDescriptor
+	PBaaSHeaders []PBaaSHeader
+	ExtraData    []byte // stake or other data stored after the PBaaS headers
+}
+
+// ParseSolution decodes a Verus block solution (the bytes of
+// RawBlockHeader.Solution, without the CompactSize length).
+func ParseSolution(data []byte) (*Solution, error) {
+	sol := &Solution{}
+	s := bytestring.String(data)
+	if len(data) < solutionDescriptorSize {
+		// As in verusd, a solution too short for a descriptor is version 0.
+		return sol, nil
+	}
+
+	d := &sol.SolutionDescriptor
+	s.ReadUint32(&d.Version)
+	s.ReadByte(&d.DescrBits)
+	s.ReadByte(&d.NumPBaaSHeaders)
+	s.ReadUint16(&d.ExtraDataSize)
+	s.ReadBytes(&d.HashPrevMMRRoot, 32)
+	s.ReadBytes(&d.HashBlockMMRRoot, 32)
+
+	if d.Version < SolutionVerusV5_1 {
+		return sol, nil
+	}
+
+	if d.NumPBaaSHeaders > 0 {
+		sol.PBaaSHeaders = make([]PBaaSHeader, d.NumPBaaSHeaders)
+		for i := range sol.PBaaSHeaders {
+			h := &sol.PBaaSHeaders[i]
+			if !s.ReadBytes(&h.ChainID, 20) {
+				return nil, newParseError(data, s, fmt.Sprintf("PBaaSHeaders[%d].ChainID", i), 20)
+			}
+			if !s.ReadBytes(&h.HashPreHeader, 32) {
+				return nil, newParseError(data, s, fmt.Sprintf("PBaaSHeaders[%d].HashPreHeader", i), 32)
+			}
+		}
+	}
+
+	if d.Version >= SolutionVerusV6 && d.ExtraDataSize > 0 {
+		if int(d.ExtraDataSize) > sol.extraDataLen(len(data)) {
+			return nil, &ParseError{
+				Path:   "ExtraData",
+				Offset: len(data) - len(s),
+				Err: errors.Errorf("extra data size %d exceeds the %d bytes available",
+					d.ExtraDataSize, sol.extraDataLen(len(data))),
+			}
+		}
+		s.ReadBytes(&sol.ExtraData, int(d.ExtraDataSize))
+	}
+	return sol, nil
+}
+
+// solutionVersion returns the version of a solution without decoding the
+// rest of it.
+func solutionVersion(data []byte) uint32 {
+	if len(data) < solutionDescriptorSize {
+		return SolutionVerusV1
+	}
+	return binary.LittleEndian.Uint32(data)
+}
+
+// extraDataLen returns the space available for extra data in a solution of
+// the given size: what's left after the descriptor and PBaaS headers, less
+// the padding and extra nonce at the end (ExtraDataLen in solutiondata.h).
+func (sol *Solution) extraDataLen(size int) int {
+	overhead := solutionDescriptorSize + int(sol.NumPBaaSHeaders)*pbaasHeaderSize
+	n := size - ((solutionHeaderBaseSize+size)%32 + overhead)
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// IsAdvanced reports whether the solution's descriptor bits say how the
+// block was produced, which is the case from SolutionVerusV6 onward.
+func (sol *Solution) IsAdvanced() bool {
+	return sol.Version >= SolutionVerusV6
+}
+
+// IsPoW reports whether an advanced solution is marked as proof-of-work;
+// if not, the block is proof-of-stake. It's meaningless unless IsAdvanced().
+func (sol *Solution) IsPoW() bool {
+	return sol.DescrBits&solutionPoW != 0
+}
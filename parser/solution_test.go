@@ -0,0 +1,143 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// makeSolution builds a 1344-byte Verus solution with the given descriptor,
+// PBaaS headers and extra data, laid out as verusd does.
+func makeSolution(version uint32, descrBits uint8, headers []PBaaSHeader, extra []byte) []byte {
+	sol := make([]byte, 1344)
+	binary.LittleEndian.PutUint32(sol[0:], version)
+	sol[4] = descrBits
+	sol[5] = uint8(len(headers))
+	binary.LittleEndian.PutUint16(sol[6:], uint16(len(extra)))
+	for i := 8; i < solutionDescriptorSize; i++ {
+		sol[i] = byte(i) // MMR roots
+	}
+	off := solutionDescriptorSize
+	for _, h := range headers {
+		off += copy(sol[off:], h.ChainID)
+		off += copy(sol[off:], h.HashPreHeader)
+	}
+	copy(sol[off:], extra)
+	return sol
+}
+
+func TestParseSolution(t *testing.T) {
+	headers := []PBaaSHeader{
+		{bytes.Repeat([]byte{1}, 20), bytes.Repeat([]byte{2}, 32)},
+		{bytes.Repeat([]byte{3}, 20), bytes.Repeat([]byte{4}, 32)},
+	}
+	extra := []byte("stake data")
+
+	sol, err := ParseSolution(makeSolution(SolutionVerusV6, 0, headers, extra))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sol.Version != SolutionVerusV6 || !sol.IsAdvanced() || sol.IsPoW() {
+		t.Fatal("unexpected descriptor: ", sol.SolutionDescriptor)
+	}
+	if len(sol.HashPrevMMRRoot) != 32 || sol.HashPrevMMRRoot[0] != 8 || sol.HashBlockMMRRoot[0] != 40 {
+		t.Fatal("unexpected MMR roots")
+	}
+	if len(sol.PBaaSHeaders) != 2 {
+		t.Fatal("unexpected PBaaS header count: ", len(sol.PBaaSHeaders))
+	}
+	for i, h := range sol.PBaaSHeaders {
+		if !bytes.Equal(h.ChainID, headers[i].ChainID) || !bytes.Equal(h.HashPreHeader, headers[i].HashPreHeader) {
+			t.Fatalf("PBaaS header %d mismatch", i)
+		}
+	}
+	if !bytes.Equal(sol.ExtraData, extra) {
+		t.Fatalf("unexpected extra data %q", sol.ExtraData)
+	}
+
+	sol, err = ParseSolution(makeSolution(SolutionVerusV6, solutionPoW, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sol.IsAdvanced() || !sol.IsPoW() || sol.PBaaSHeaders != nil || sol.ExtraData != nil {
+		t.Fatal("unexpected PoW solution: ", sol)
+	}
+
+	// Before V5_1 there are no PBaaS headers, whatever the descriptor says.
+	sol, err = ParseSolution(makeSolution(SolutionVerusV5, 0, headers, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sol.Version != SolutionVerusV5 || sol.IsAdvanced() || sol.PBaaSHeaders != nil {
+		t.Fatal("unexpected V5 solution: ", sol)
+	}
+
+	// Short solutions are version 0.
+	sol, err = ParseSolution([]byte{6, 0, 0, 0})
+	if err != nil || sol.Version != SolutionVerusV1 {
+		t.Fatal("unexpected short solution: ", sol, err)
+	}
+
+	// Extra data can't run into the padding at the end.
+	bad := makeSolution(SolutionVerusV6, 0, nil, nil)
+	binary.LittleEndian.PutUint16(bad[6:], 1344)
+	_, err = ParseSolution(bad)
+	pe, ok := err.(*ParseError)
+	if !ok || pe.Path != "ExtraData" || pe.Offset != solutionDescriptorSize || pe.Truncated() {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	// More headers than fit is a truncation error.
+	bad = makeSolution(SolutionVerusV6, 0, nil, nil)[:solutionDescriptorSize+30]
+	bad[5] = 1
+	_, err = ParseSolution(bad)
+	pe, ok = err.(*ParseError)
+	if !ok || pe.Path != "PBaaSHeaders[0].HashPreHeader" || !pe.Truncated() {
+		t.Fatal("unexpected error: ", err)
+	}
+}
+
+func TestBlockHeaderSolution(t *testing.T) {
+	hdr := NewBlockHeader()
+	hdr.Version = verusV2
+	hdr.Solution = makeSolution(SolutionVerusV5_1, solutionPoW, nil, nil)
+	if !hdr.IsVerusV2() {
+		t.Fatal("expected a VERUS_V2 header")
+	}
+	if hdr.SolutionVersion() != SolutionVerusV5_1 {
+		t.Fatal("unexpected solution version: ", hdr.SolutionVersion())
+	}
+	sol, err := hdr.GetSolution()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sol.Version != SolutionVerusV5_1 || sol.DescrBits != solutionPoW {
+		t.Fatal("unexpected solution: ", sol)
+	}
+
+	// Errors are located within the serialized header.
+	hdr = NewBlockHeader()
+	hdr.Version = verusV2
+	hdr.Solution = makeSolution(SolutionVerusV6, 0, nil, nil)
+	binary.LittleEndian.PutUint16(hdr.Solution[6:], 1344)
+	_, err = hdr.GetSolution()
+	pe, ok := err.(*ParseError)
+	if !ok || pe.Path != "Solution.ExtraData" || pe.Offset != 143+solutionDescriptorSize {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	// Zcash-style (and Verus V1) headers have an opaque solution.
+	hdr = NewBlockHeader()
+	hdr.Version = 4
+	hdr.Solution = makeSolution(SolutionVerusV6, 0, nil, nil)
+	if hdr.IsVerusV2() || hdr.SolutionVersion() != SolutionVerusV1 {
+		t.Fatal("unexpected versioned solution")
+	}
+	sol, err = hdr.GetSolution()
+	if err != nil || sol.Version != SolutionVerusV1 {
+		t.Fatal("unexpected solution: ", sol, err)
+	}
+}
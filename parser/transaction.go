@@ -6,7 +6,9 @@
 package parser
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"fmt"
 
 	"github.com/asherda/lightwalletd/parser/internal/bytestring"
 	"github.com/asherda/lightwalletd/walletrpc"
@@ -49,19 +51,19 @@ func (tx *txIn) ParseFromSlice(data []byte) ([]byte, error) {
 	s := bytestring.String(data)
 
 	if !s.ReadBytes(&tx.PrevTxHash, 32) {
-		return nil, errors.New("could not read PrevTxHash")
+		return nil, newParseError(data, s, "PrevTxHash", 32)
 	}
 
 	if !s.ReadUint32(&tx.PrevTxOutIndex) {
-		return nil, errors.New("could not read PrevTxOutIndex")
+		return nil, newParseError(data, s, "PrevTxOutIndex", 4)
 	}
 
-	if !s.ReadCompactLengthPrefixed((*bytestring.String)(&tx.ScriptSig)) {
-		return nil, errors.New("could not read ScriptSig")
+	if err := s.ReadCompactLengthPrefixed((*bytestring.String)(&tx.ScriptSig)); err != nil {
+		return nil, compactParseError(data, s, "ScriptSig", err)
 	}
 
 	if !s.ReadUint32(&tx.SequenceNumber) {
-		return nil, errors.New("could not read SequenceNumber")
+		return nil, newParseError(data, s, "SequenceNumber", 4)
 	}
 
 	return []byte(s), nil
@@ -80,11 +82,11 @@ func (tx *txOut) ParseFromSlice(data []byte) ([]byte, error) {
 	s := bytestring.String(data)
 
 	if !s.ReadUint64(&tx.Value) {
-		return nil, errors.New("could not read txOut value")
+		return nil, newParseError(data, s, "Value", 8)
 	}
 
-	if !s.ReadCompactLengthPrefixed((*bytestring.String)(&tx.Script)) {
-		return nil, errors.New("could not read txOut script")
+	if err := s.ReadCompactLengthPrefixed((*bytestring.String)(&tx.Script)); err != nil {
+		return nil, compactParseError(data, s, "Script", err)
 	}
 
 	return []byte(s), nil
@@ -105,27 +107,27 @@ func (p *spend) ParseFromSlice(data []byte) ([]byte, error) {
 	s := bytestring.String(data)
 
 	if !s.ReadBytes(&p.cv, 32) {
-		return nil, errors.New("could not read cv")
+		return nil, newParseError(data, s, "cv", 32)
 	}
 
 	if !s.ReadBytes(&p.anchor, 32) {
-		return nil, errors.New("could not read anchor")
+		return nil, newParseError(data, s, "anchor", 32)
 	}
 
 	if !s.ReadBytes(&p.nullifier, 32) {
-		return nil, errors.New("could not read nullifier")
+		return nil, newParseError(data, s, "nullifier", 32)
 	}
 
 	if !s.ReadBytes(&p.rk, 32) {
-		return nil, errors.New("could not read rk")
+		return nil, newParseError(data, s, "rk", 32)
 	}
 
 	if !s.ReadBytes(&p.zkproof, 192) {
-		return nil, errors.New("could not read zkproof")
+		return nil, newParseError(data, s, "zkproof", 192)
 	}
 
 	if !s.ReadBytes(&p.spendAuthSig, 64) {
-		return nil, errors.New("could not read spendAuthSig")
+		return nil, newParseError(data, s, "spendAuthSig", 64)
 	}
 
 	return []byte(s), nil
@@ -152,27 +154,27 @@ func (p *output) ParseFromSlice(data []byte) ([]byte, error) {
 	s := bytestring.String(data)
 
 	if !s.ReadBytes(&p.cv, 32) {
-		return nil, errors.New("could not read cv")
+		return nil, newParseError(data, s, "cv", 32)
 	}
 
 	if !s.ReadBytes(&p.cmu, 32) {
-		return nil, errors.New("could not read cmu")
+		return nil, newParseError(data, s, "cmu", 32)
 	}
 
 	if !s.ReadBytes(&p.ephemeralKey, 32) {
-		return nil, errors.New("could not read ephemeralKey")
+		return nil, newParseError(data, s, "ephemeralKey", 32)
 	}
 
 	if !s.ReadBytes(&p.encCiphertext, 580) {
-		return nil, errors.New("could not read encCiphertext")
+		return nil, newParseError(data, s, "encCiphertext", 580)
 	}
 
 	if !s.ReadBytes(&p.outCiphertext, 80) {
-		return nil, errors.New("could not read outCiphertext")
+		return nil, newParseError(data, s, "outCiphertext", 80)
 	}
 
 	if !s.ReadBytes(&p.zkproof, 192) {
-		return nil, errors.New("could not read zkproof")
+		return nil, newParseError(data, s, "zkproof", 192)
 	}
 
 	return []byte(s), nil
@@ -210,58 +212,62 @@ func (p *joinSplit) ParseFromSlice(data []byte) ([]byte, error) {
 	s := bytestring.String(data)
 
 	if !s.ReadUint64(&p.vpubOld) {
-		return nil, errors.New("could not read vpubOld")
+		return nil, newParseError(data, s, "vpubOld", 8)
 	}
 
 	if !s.ReadUint64(&p.vpubNew) {
-		return nil, errors.New("could not read vpubNew")
+		return nil, newParseError(data, s, "vpubNew", 8)
 	}
 
 	if !s.ReadBytes(&p.anchor, 32) {
-		return nil, errors.New("could not read anchor")
+		return nil, newParseError(data, s, "anchor", 32)
 	}
 
 	for i := 0; i < 2; i++ {
 		if !s.ReadBytes(&p.nullifiers[i], 32) {
-			return nil, errors.New("could not read a nullifier")
+			return nil, newParseError(data, s, fmt.Sprintf("nullifiers[%d]", i), 32)
 		}
 	}
 
 	for i := 0; i < 2; i++ {
 		if !s.ReadBytes(&p.commitments[i], 32) {
-			return nil, errors.New("could not read a commitment")
+			return nil, newParseError(data, s, fmt.Sprintf("commitments[%d]", i), 32)
 		}
 	}
 
 	if !s.ReadBytes(&p.ephemeralKey, 32) {
-		return nil, errors.New("could not read ephemeralKey")
+		return nil, newParseError(data, s, "ephemeralKey", 32)
 	}
 
 	if !s.ReadBytes(&p.randomSeed, 32) {
-		return nil, errors.New("could not read randomSeed")
+		return nil, newParseError(data, s, "randomSeed", 32)
 	}
 
 	for i := 0; i < 2; i++ {
 		if !s.ReadBytes(&p.vmacs[i], 32) {
-			return nil, errors.New("could not read a vmac")
+			return nil, newParseError(data, s, fmt.Sprintf("vmacs[%d]", i), 32)
 		}
 	}
 
 	if p.version == 2 || p.version == 3 {
 		if !s.ReadBytes(&p.proofPHGR13, 296) {
-			return nil, errors.New("could not read PHGR13 proof")
+			return nil, newParseError(data, s, "proofPHGR13", 296)
 		}
 	} else if p.version >= 4 {
 		if !s.ReadBytes(&p.proofGroth16, 192) {
-			return nil, errors.New("could not read Groth16 proof")
+			return nil, newParseError(data, s, "proofGroth16", 192)
 		}
 	} else {
-		return nil, errors.New("unexpected transaction version")
+		return nil, &ParseError{
+			Path:   "proof",
+			Offset: len(data) - len(s),
+			Err:    errors.Errorf("unexpected transaction version %d", p.version),
+		}
 	}
 
 	for i := 0; i < 2; i++ {
 		if !s.ReadBytes(&p.encCiphertexts[i], 601) {
-			return nil, errors.New("could not read an encCiphertext")
+			return nil, newParseError(data, s, fmt.Sprintf("encCiphertexts[%d]", i), 601)
 		}
 	}
 
@@ -273,6 +279,7 @@ type Transaction struct {
 	*rawTransaction
 	rawBytes   []byte
 	cachedTxID []byte // cached for performance
+	limits     Limits
 }
 
 // GetDisplayHash returns the transaction hash in big-endian display order.
@@ -307,6 +314,154 @@ func (tx *Transaction) HasSaplingElements() bool {
 	return tx.version >= 4 && (len(tx.shieldedSpends)+len(tx.shieldedOutputs)) > 0
 }
 
+// ExpiryHeight returns the last height at which the transaction can be
+// mined, or zero if it doesn't expire.
+func (tx *Transaction) ExpiryHeight() uint32 {
+	return tx.nExpiryHeight
+}
+
+// Version returns the transaction version (without the fOverwintered flag).
+func (tx *Transaction) Version() uint32 {
+	return tx.version
+}
+
+// IsOverwintered indicates whether the transaction has the fOverwintered
+// flag set, as is required from Overwinter activation onward.
+func (tx *Transaction) IsOverwintered() bool {
+	return tx.fOverwintered
+}
+
+// VersionGroupID returns nVersionGroupId, or zero if the transaction
+// doesn't have one.
+func (tx *Transaction) VersionGroupID() uint32 {
+	return tx.nVersionGroupID
+}
+
+// The nVersionGroupId of each overwintered transaction version.
+const (
+	OverwinterVersionGroupID = 0x03C48270 // version 3
+	SaplingVersionGroupID    = 0x892F2085 // version 4
+)
+
+// Transactions can't expire at or above this height (nExpiryHeight is
+// compared with nLockTime's block height range).
+const txExpiryHeightThreshold = 500000000
+
+// IsCoinbase indicates whether the transaction is a coinbase transaction.
+func (tx *Transaction) IsCoinbase() bool {
+	return len(tx.transparentInputs) == 1 && tx.transparentInputs[0].PrevTxOutIndex == 0xffffffff &&
+		bytes.Equal(tx.transparentInputs[0].PrevTxHash, make([]byte, 32))
+}
+
+// CheckStructure makes the context-free checks of zcashd's CheckTransaction
+// on the transaction's structure (but not its scripts, signatures or proofs).
+// The error message is zcashd's reject reason.
+func (tx *Transaction) CheckStructure() error {
+	if tx.fOverwintered {
+		switch {
+		case tx.version < 3:
+			return errors.New("bad-tx-overwinter-version-too-low")
+		case tx.version > 4:
+			return errors.New("bad-tx-overwinter-version-too-high")
+		case tx.version == 3 && tx.nVersionGroupID != OverwinterVersionGroupID:
+			return errors.New("bad-overwinter-tx-version-group-id")
+		case tx.version == 4 && tx.nVersionGroupID != SaplingVersionGroupID:
+			return errors.New("bad-sapling-tx-version-group-id")
+		}
+	} else if tx.version < 1 {
+		return errors.New("bad-txns-version-too-low")
+	}
+	if len(tx.transparentInputs) == 0 && len(tx.joinSplits) == 0 && len(tx.shieldedSpends) == 0 {
+		return errors.New("bad-txns-vin-empty")
+	}
+	if len(tx.transparentOutputs) == 0 && len(tx.joinSplits) == 0 && len(tx.shieldedOutputs) == 0 {
+		return errors.New("bad-txns-vout-empty")
+	}
+	if tx.nExpiryHeight >= txExpiryHeightThreshold {
+		return errors.New("bad-tx-expiry-height-too-high")
+	}
+	if tx.valueBalance != 0 && len(tx.shieldedSpends) == 0 && len(tx.shieldedOutputs) == 0 {
+		return errors.New("bad-txns-valuebalance-nonzero")
+	}
+	if tx.IsCoinbase() {
+		return errors.New("coinbase")
+	}
+	outpoints := make(map[string]bool, len(tx.transparentInputs))
+	for _, in := range tx.transparentInputs {
+		key := fmt.Sprintf("%x:%d", in.PrevTxHash, in.PrevTxOutIndex)
+		if outpoints[key] {
+			return errors.New("bad-txns-inputs-duplicate")
+		}
+		outpoints[key] = true
+	}
+	nullifiers := make(map[string]bool, 2*len(tx.joinSplits))
+	for _, js := range tx.joinSplits {
+		for _, nf := range js.nullifiers {
+			if nullifiers[string(nf)] {
+				return errors.New("bad-joinsplits-nullifiers-duplicate")
+			}
+			nullifiers[string(nf)] = true
+		}
+	}
+	nullifiers = make(map[string]bool, len(tx.shieldedSpends))
+	for _, spend := range tx.shieldedSpends {
+		if nullifiers[string(spend.nullifier)] {
+			return errors.New("bad-spend-description-nullifiers-duplicate")
+		}
+		nullifiers[string(spend.nullifier)] = true
+	}
+	return nil
+}
+
+// Outpoint identifies a transparent output: the hash of its transaction (in
+// little-endian wire format order) and its index within that transaction.
+type Outpoint struct {
+	Hash  []byte
+	Index uint32
+}
+
+// SpentOutpoints returns the transparent outputs the transaction spends
+// (none, for a coinbase transaction).
+func (tx *Transaction) SpentOutpoints() []Outpoint {
+	outpoints := make([]Outpoint, 0, len(tx.transparentInputs))
+	for _, in := range tx.transparentInputs {
+		if in.PrevTxOutIndex == 0xffffffff && bytes.Equal(in.PrevTxHash, make([]byte, 32)) {
+			// coinbase
+			continue
+		}
+		outpoints = append(outpoints, Outpoint{Hash: in.PrevTxHash, Index: in.PrevTxOutIndex})
+	}
+	return outpoints
+}
+
+// TxOutput is a transparent output: its value (in zatoshis) and script.
+type TxOutput struct {
+	Value  uint64
+	Script []byte
+}
+
+// Outputs returns the transaction's transparent outputs, in order.
+func (tx *Transaction) Outputs() []TxOutput {
+	outputs := make([]TxOutput, len(tx.transparentOutputs))
+	for i, out := range tx.transparentOutputs {
+		outputs[i] = TxOutput{Value: out.Value, Script: out.Script}
+	}
+	return outputs
+}
+
+// Nullifiers returns the Sapling and Sprout nullifiers the transaction
+// reveals, each of which can only ever be revealed once.
+func (tx *Transaction) Nullifiers() [][]byte {
+	nullifiers := make([][]byte, 0, len(tx.shieldedSpends)+2*len(tx.joinSplits))
+	for _, spend := range tx.shieldedSpends {
+		nullifiers = append(nullifiers, spend.nullifier)
+	}
+	for _, js := range tx.joinSplits {
+		nullifiers = append(nullifiers, js.nullifiers[0], js.nullifiers[1])
+	}
+	return nullifiers
+}
+
 // ToCompact converts the given (full) transaction to compact format.
 func (tx *Transaction) ToCompact(index int) *walletrpc.CompactTx {
 	ctx := &walletrpc.CompactTx{
@@ -334,7 +489,7 @@ func (tx *Transaction) ParseFromSlice(data []byte) ([]byte, error) {
 
 	var header uint32
 	if !s.ReadUint32(&header) {
-		return nil, errors.New("could not read header")
+		return nil, newParseError(data, s, "header", 4)
 	}
 
 	tx.fOverwintered = (header >> 31) == 1
@@ -342,55 +497,70 @@ func (tx *Transaction) ParseFromSlice(data []byte) ([]byte, error) {
 
 	if tx.version >= 3 {
 		if !s.ReadUint32(&tx.nVersionGroupID) {
-			return nil, errors.New("could not read nVersionGroupId")
+			return nil, newParseError(data, s, "nVersionGroupID", 4)
 		}
 	}
 
 	var txInCount int
-	if !s.ReadCompactSize(&txInCount) {
-		return nil, errors.New("could not read tx_in_count")
+	if err := s.ReadCompactSize(&txInCount); err != nil {
+		return nil, compactParseError(data, s, "tx_in_count", err)
 	}
 
-	// TODO: Duplicate/otherwise-too-many transactions are a possible DoS
-	// TODO: vector. At the moment we're assuming trusted input.
-	// See https://nvd.nist.gov/vuln/detail/CVE-2018-17144 for an example.
+	// Counts are checked before allocating, since they can come from
+	// untrusted input. Duplicate inputs are a consensus matter for the node;
+	// see https://nvd.nist.gov/vuln/detail/CVE-2018-17144 for an example.
+	if err = checkLimit(ErrTooManyInputs, txInCount, tx.limits.MaxInputs); err != nil {
+		return nil, wrapParseError(err, "tx_in_count", len(data)-len(s))
+	}
 
 	if txInCount > 0 {
 		tx.transparentInputs = make([]*txIn, txInCount)
 		for i := 0; i < txInCount; i++ {
 			ti := &txIn{}
+			offset := len(data) - len(s)
 			s, err = ti.ParseFromSlice([]byte(s))
 			if err != nil {
-				return nil, errors.Wrap(err, "while parsing transparent input")
+				return nil, wrapParseError(err, fmt.Sprintf("transparentInputs[%d]", i), offset)
+			}
+			if err = checkLimit(ErrScriptTooLarge, len(ti.ScriptSig), tx.limits.MaxScriptSize); err != nil {
+				return nil, wrapParseError(err, fmt.Sprintf("transparentInputs[%d].ScriptSig", i), offset+32+4)
 			}
 			tx.transparentInputs[i] = ti
 		}
 	}
 
 	var txOutCount int
-	if !s.ReadCompactSize(&txOutCount) {
-		return nil, errors.New("could not read tx_out_count")
+	if err := s.ReadCompactSize(&txOutCount); err != nil {
+		return nil, compactParseError(data, s, "tx_out_count", err)
+	}
+
+	if err = checkLimit(ErrTooManyOutputs, txOutCount, tx.limits.MaxOutputs); err != nil {
+		return nil, wrapParseError(err, "tx_out_count", len(data)-len(s))
 	}
 
 	if txOutCount > 0 {
 		tx.transparentOutputs = make([]*txOut, txOutCount)
 		for i := 0; i < txOutCount; i++ {
 			to := &txOut{}
+			offset := len(data) - len(s)
 			s, err = to.ParseFromSlice([]byte(s))
 			if err != nil {
-				return nil, errors.Wrap(err, "while parsing transparent output")
+				return nil, wrapParseError(err, fmt.Sprintf("transparentOutputs[%d]", i), offset)
+			}
+			if err = checkLimit(ErrScriptTooLarge, len(to.Script), tx.limits.MaxScriptSize); err != nil {
+				return nil, wrapParseError(err, fmt.Sprintf("transparentOutputs[%d].Script", i), offset+8)
 			}
 			tx.transparentOutputs[i] = to
 		}
 	}
 
 	if !s.ReadUint32(&tx.nLockTime) {
-		return nil, errors.New("could not read nLockTime")
+		return nil, newParseError(data, s, "nLockTime", 4)
 	}
 
 	if tx.fOverwintered {
 		if !s.ReadUint32(&tx.nExpiryHeight) {
-			return nil, errors.New("could not read nExpiryHeight")
+			return nil, newParseError(data, s, "nExpiryHeight", 4)
 		}
 	}
 
@@ -398,36 +568,46 @@ func (tx *Transaction) ParseFromSlice(data []byte) ([]byte, error) {
 
 	if tx.version >= 4 {
 		if !s.ReadInt64(&tx.valueBalance) {
-			return nil, errors.New("could not read valueBalance")
+			return nil, newParseError(data, s, "valueBalance", 8)
+		}
+
+		if err := s.ReadCompactSize(&spendCount); err != nil {
+			return nil, compactParseError(data, s, "nShieldedSpend", err)
 		}
 
-		if !s.ReadCompactSize(&spendCount) {
-			return nil, errors.New("could not read nShieldedSpend")
+		if err = checkLimit(ErrTooManySpends, spendCount, tx.limits.MaxSpends); err != nil {
+			return nil, wrapParseError(err, "nShieldedSpend", len(data)-len(s))
 		}
 
 		if spendCount > 0 {
 			tx.shieldedSpends = make([]*spend, spendCount)
 			for i := 0; i < spendCount; i++ {
 				newSpend := &spend{}
+				offset := len(data) - len(s)
 				s, err = newSpend.ParseFromSlice([]byte(s))
 				if err != nil {
-					return nil, errors.Wrap(err, "while parsing shielded Spend")
+					return nil, wrapParseError(err, fmt.Sprintf("shieldedSpends[%d]", i), offset)
 				}
 				tx.shieldedSpends[i] = newSpend
 			}
 		}
 
-		if !s.ReadCompactSize(&outputCount) {
-			return nil, errors.New("could not read nShieldedOutput")
+		if err := s.ReadCompactSize(&outputCount); err != nil {
+			return nil, compactParseError(data, s, "nShieldedOutput", err)
+		}
+
+		if err = checkLimit(ErrTooManyShieldedOutputs, outputCount, tx.limits.MaxShieldedOutputs); err != nil {
+			return nil, wrapParseError(err, "nShieldedOutput", len(data)-len(s))
 		}
 
 		if outputCount > 0 {
 			tx.shieldedOutputs = make([]*output, outputCount)
 			for i := 0; i < outputCount; i++ {
 				newOutput := &output{}
+				offset := len(data) - len(s)
 				s, err = newOutput.ParseFromSlice([]byte(s))
 				if err != nil {
-					return nil, errors.Wrap(err, "while parsing shielded Output")
+					return nil, wrapParseError(err, fmt.Sprintf("shieldedOutputs[%d]", i), offset)
 				}
 				tx.shieldedOutputs[i] = newOutput
 			}
@@ -436,47 +616,63 @@ func (tx *Transaction) ParseFromSlice(data []byte) ([]byte, error) {
 
 	if tx.version >= 2 {
 		var joinSplitCount int
-		if !s.ReadCompactSize(&joinSplitCount) {
-			return nil, errors.New("could not read nJoinSplit")
+		if err := s.ReadCompactSize(&joinSplitCount); err != nil {
+			return nil, compactParseError(data, s, "nJoinSplit", err)
+		}
+
+		if err = checkLimit(ErrTooManyJoinSplits, joinSplitCount, tx.limits.MaxJoinSplits); err != nil {
+			return nil, wrapParseError(err, "nJoinSplit", len(data)-len(s))
 		}
 
 		if joinSplitCount > 0 {
 			tx.joinSplits = make([]*joinSplit, joinSplitCount)
 			for i := 0; i < joinSplitCount; i++ {
 				js := &joinSplit{version: tx.version}
+				offset := len(data) - len(s)
 				s, err = js.ParseFromSlice([]byte(s))
 				if err != nil {
-					return nil, errors.Wrap(err, "while parsing JoinSplit")
+					return nil, wrapParseError(err, fmt.Sprintf("joinSplits[%d]", i), offset)
 				}
 				tx.joinSplits[i] = js
 			}
 
 			if !s.ReadBytes(&tx.joinSplitPubKey, 32) {
-				return nil, errors.New("could not read joinSplitPubKey")
+				return nil, newParseError(data, s, "joinSplitPubKey", 32)
 			}
 
 			if !s.ReadBytes(&tx.joinSplitSig, 64) {
-				return nil, errors.New("could not read joinSplitSig")
+				return nil, newParseError(data, s, "joinSplitSig", 64)
 			}
 		}
 	}
 
 	if tx.version >= 4 && (spendCount+outputCount > 0) {
 		if !s.ReadBytes(&tx.bindingSig, 64) {
-			return nil, errors.New("could not read bindingSig")
+			return nil, newParseError(data, s, "bindingSig", 64)
 		}
 	}
 
 	// TODO: implement rawBytes with MarshalBinary() instead
 	txLen := len(data) - len(s)
+	if err = checkLimit(ErrTxTooLarge, txLen, tx.limits.MaxTxSize); err != nil {
+		return nil, wrapParseError(err, "", 0)
+	}
 	tx.rawBytes = data[:txLen]
 
 	return []byte(s), nil
 }
 
-// NewTransaction is the constructor for a full transaction.
+// NewTransaction is the constructor for a full transaction; its parsing is
+// bounded by the limits set by SetLimits().
 func NewTransaction() *Transaction {
+	return NewTransactionWithLimits(limits)
+}
+
+// NewTransactionWithLimits constructs a full transaction whose parsing is
+// bounded by the given limits.
+func NewTransactionWithLimits(limits Limits) *Transaction {
 	return &Transaction{
 		rawTransaction: new(rawTransaction),
+		limits:         limits,
 	}
 }
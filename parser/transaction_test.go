@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/asherda/lightwalletd/parser/internal/bytestring"
+	"github.com/pkg/errors"
 )
 
 // "Human-readable" version of joinSplit struct defined in transaction.go.
@@ -438,9 +439,9 @@ func subTestTransparentInputs(testInputs [][]string, txInputs []*txIn, t *testin
 
 		// Decode scriptSig and correctly consume own CompactSize field
 		testScriptSig, _ := hex.DecodeString(ti[2])
-		ok := (*bytestring.String)(&testScriptSig).ReadCompactLengthPrefixed((*bytestring.String)(&testScriptSig))
-		if !ok {
-			t.Errorf("Test %d, tin %d: couldn't strip size from script", caseNum, idx)
+		err := (*bytestring.String)(&testScriptSig).ReadCompactLengthPrefixed((*bytestring.String)(&testScriptSig))
+		if err != nil {
+			t.Errorf("Test %d, tin %d: couldn't strip size from script: %v", caseNum, idx, err)
 			success = false
 			continue
 		}
@@ -492,9 +493,9 @@ func subTestTransparentOutputs(testOutputs [][]string, txOutputs []*txOut, t *te
 		// Parse script from test
 		testScript, _ := hex.DecodeString(testOutput[1])
 		// Correctly consume own CompactSize field
-		ok := (*bytestring.String)(&testScript).ReadCompactLengthPrefixed((*bytestring.String)(&testScript))
-		if !ok {
-			t.Errorf("Test %d, tout %d: couldn't strip size from script", caseNum, idx)
+		err := (*bytestring.String)(&testScript).ReadCompactLengthPrefixed((*bytestring.String)(&testScript))
+		if err != nil {
+			t.Errorf("Test %d, tout %d: couldn't strip size from script: %v", caseNum, idx, err)
 			success = false
 			continue
 		}
@@ -775,6 +776,42 @@ func TestSaplingTransactionParser(t *testing.T) {
 		if hex.EncodeToString(tx.GetDisplayHash()) != tt.txid {
 			t.Errorf("Test %d: incorrect cached txid", i)
 		}
+
+		// What the mempool tracker uses to detect expiry and conflicts
+		testExpiryHeightBytes, _ := hex.DecodeString(tt.nExpiryHeight)
+		if tx.ExpiryHeight() != binary.LittleEndian.Uint32(testExpiryHeightBytes) {
+			t.Errorf("Test %d: incorrect expiry height %d", i, tx.ExpiryHeight())
+		}
+		outpoints := tx.SpentOutpoints()
+		if len(outpoints) != len(tt.vin) {
+			t.Errorf("Test %d: incorrect number of spent outpoints %d", i, len(outpoints))
+			continue
+		}
+		for j, outpoint := range outpoints {
+			testHash, _ := hex.DecodeString(tt.vin[j][0])
+			testIndexBytes, _ := hex.DecodeString(tt.vin[j][1])
+			if !bytes.Equal(outpoint.Hash, testHash) || outpoint.Index != binary.LittleEndian.Uint32(testIndexBytes) {
+				t.Errorf("Test %d: incorrect spent outpoint %d", i, j)
+			}
+		}
+		var testNullifiers []string
+		for _, spend := range tt.spends {
+			testNullifiers = append(testNullifiers, spend.nullifier)
+		}
+		for _, js := range tt.vJoinSplits {
+			testNullifiers = append(testNullifiers, js.nullifiers...)
+		}
+		nullifiers := tx.Nullifiers()
+		if len(nullifiers) != len(testNullifiers) {
+			t.Errorf("Test %d: incorrect number of nullifiers %d", i, len(nullifiers))
+			continue
+		}
+		for j, nf := range nullifiers {
+			testNullifier, _ := hex.DecodeString(testNullifiers[j])
+			if !bytes.Equal(nf, testNullifier) {
+				t.Errorf("Test %d: incorrect nullifier %d", i, j)
+			}
+		}
 	}
 }
 
@@ -881,3 +918,159 @@ func subTestShieldedOutputs(testOutputs []outputTestVector, txOutputs []*output,
 
 	return success
 }
+
+func TestTransactionLimits(t *testing.T) {
+	// v1 transaction header followed by a tx_in_count of 0x02000000, the
+	// largest CompactSize the parser accepts, and no input data at all.
+	tooManyInputs, _ := hex.DecodeString("01000000fe00000002")
+	tx := NewTransaction()
+	_, err := tx.ParseFromSlice(tooManyInputs)
+	if !errors.Is(err, ErrTooManyInputs) {
+		t.Fatal("unexpected error: ", err)
+	}
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatal("expected a LimitError")
+	}
+	if limitErr.Count != 0x02000000 || limitErr.Limit != DefaultLimits.MaxInputs {
+		t.Fatal("unexpected LimitError values: ", limitErr)
+	}
+	if tx.transparentInputs != nil {
+		t.Fatal("inputs were allocated")
+	}
+
+	testData, err := os.Open("../testdata/zip243_raw_tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testData.Close()
+	var rawTxData [][]byte
+	scan := bufio.NewScanner(testData)
+	for scan.Scan() {
+		dataLine := scan.Text()
+		if strings.HasPrefix(dataLine, "#") {
+			continue
+		}
+		txData, _ := hex.DecodeString(dataLine)
+		rawTxData = append(rawTxData, txData)
+	}
+
+	// Each test vector parses with the defaults, and fails when the
+	// relevant limit is set just below what the vector contains.
+	for i, tt := range zip243tests {
+		tx := NewTransaction()
+		if _, err := tx.ParseFromSlice(rawTxData[i]); err != nil {
+			t.Fatalf("Test %d: %v", i, err)
+		}
+		checks := []struct {
+			kind  error
+			count int
+			set   func(*Limits, int)
+		}{
+			{ErrTooManyInputs, len(tt.vin), func(l *Limits, n int) { l.MaxInputs = n }},
+			{ErrTooManyOutputs, len(tt.vout), func(l *Limits, n int) { l.MaxOutputs = n }},
+			{ErrTooManySpends, len(tt.spends), func(l *Limits, n int) { l.MaxSpends = n }},
+			{ErrTooManyShieldedOutputs, len(tt.outputs), func(l *Limits, n int) { l.MaxShieldedOutputs = n }},
+			{ErrTooManyJoinSplits, len(tt.vJoinSplits), func(l *Limits, n int) { l.MaxJoinSplits = n }},
+			{ErrTxTooLarge, len(rawTxData[i]), func(l *Limits, n int) { l.MaxTxSize = n }},
+		}
+		for _, check := range checks {
+			if check.count == 0 {
+				continue
+			}
+			limits := DefaultLimits
+			check.set(&limits, check.count-1)
+			_, err := NewTransactionWithLimits(limits).ParseFromSlice(rawTxData[i])
+			if !errors.Is(err, check.kind) {
+				t.Errorf("Test %d: expected %v, got %v", i, check.kind, err)
+			}
+		}
+		if len(tt.vout) > 0 {
+			limits := DefaultLimits
+			// The test vector script includes its one-byte length prefix.
+			limits.MaxScriptSize = len(tt.vout[0][1])/2 - 2
+			_, err := NewTransactionWithLimits(limits).ParseFromSlice(rawTxData[i])
+			if !errors.Is(err, ErrScriptTooLarge) {
+				t.Errorf("Test %d: expected %v, got %v", i, ErrScriptTooLarge, err)
+			}
+		}
+	}
+
+	// SetLimits changes what NewTransaction() applies, keeping the defaults
+	// for zero fields.
+	defer SetLimits(Limits{})
+	SetLimits(Limits{MaxTxSize: len(rawTxData[0]) - 1})
+	if l := GetLimits(); l.MaxTxSize != len(rawTxData[0])-1 || l.MaxInputs != DefaultLimits.MaxInputs {
+		t.Fatal("unexpected limits", l)
+	}
+	if _, err := NewTransaction().ParseFromSlice(rawTxData[0]); !errors.Is(err, ErrTxTooLarge) {
+		t.Fatalf("expected %v, got %v", ErrTxTooLarge, err)
+	}
+	SetLimits(Limits{})
+	if GetLimits() != DefaultLimits {
+		t.Fatal("limits not reset")
+	}
+}
+
+func TestCheckStructure(t *testing.T) {
+	testData, err := os.Open("../testdata/zip243_raw_tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testData.Close()
+	var rawTxData [][]byte
+	scan := bufio.NewScanner(testData)
+	for scan.Scan() {
+		dataLine := scan.Text()
+		if strings.HasPrefix(dataLine, "#") {
+			continue
+		}
+		txData, _ := hex.DecodeString(dataLine)
+		rawTxData = append(rawTxData, txData)
+	}
+
+	// Test vector 1 has two transparent inputs and three spends, and
+	// vector 0 has two joinsplits.
+	parse := func(i int) *Transaction {
+		tx := NewTransaction()
+		if _, err := tx.ParseFromSlice(rawTxData[i]); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	for i := range rawTxData {
+		if err := parse(i).CheckStructure(); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i, err)
+		}
+	}
+	tests := []struct {
+		vector int
+		modify func(tx *Transaction)
+		reason string
+	}{
+		{1, func(tx *Transaction) { tx.version = 2 }, "bad-tx-overwinter-version-too-low"},
+		{1, func(tx *Transaction) { tx.version = 5 }, "bad-tx-overwinter-version-too-high"},
+		{1, func(tx *Transaction) { tx.nVersionGroupID = OverwinterVersionGroupID }, "bad-sapling-tx-version-group-id"},
+		{1, func(tx *Transaction) { tx.version = 3 }, "bad-overwinter-tx-version-group-id"},
+		{1, func(tx *Transaction) { tx.fOverwintered = false; tx.version = 0 }, "bad-txns-version-too-low"},
+		{1, func(tx *Transaction) { tx.transparentInputs = nil; tx.shieldedSpends = nil }, "bad-txns-vin-empty"},
+		{1, func(tx *Transaction) { tx.transparentOutputs = nil }, "bad-txns-vout-empty"},
+		{1, func(tx *Transaction) { tx.nExpiryHeight = 500000000 }, "bad-tx-expiry-height-too-high"},
+		{1, func(tx *Transaction) { tx.shieldedSpends = nil; tx.valueBalance = 1 }, "bad-txns-valuebalance-nonzero"},
+		{1, func(tx *Transaction) {
+			tx.transparentInputs = tx.transparentInputs[:1]
+			tx.transparentInputs[0].PrevTxHash = make([]byte, 32)
+			tx.transparentInputs[0].PrevTxOutIndex = 0xffffffff
+		}, "coinbase"},
+		{1, func(tx *Transaction) { tx.transparentInputs[1] = tx.transparentInputs[0] }, "bad-txns-inputs-duplicate"},
+		{1, func(tx *Transaction) { tx.shieldedSpends[2] = tx.shieldedSpends[0] }, "bad-spend-description-nullifiers-duplicate"},
+		{0, func(tx *Transaction) { tx.joinSplits[1] = tx.joinSplits[0] }, "bad-joinsplits-nullifiers-duplicate"},
+	}
+	for i, tt := range tests {
+		tx := parse(tt.vector)
+		tt.modify(tx)
+		if err := tx.CheckStructure(); err == nil || err.Error() != tt.reason {
+			t.Errorf("Test %d: expected %s, got %v", i, tt.reason, err)
+		}
+	}
+}
@@ -15,6 +15,14 @@ func VerusHash(serializedHeader []byte) []byte {
 	return hash
 }
 
+func VerusHash_V2(serializedHeader []byte) []byte {
+	hash := make([]byte, 32)
+	ptrHash := uintptr(unsafe.Pointer(&hash[0]))
+	length := len(serializedHeader)
+	verusHash.Verushash_v2(string(serializedHeader), length, ptrHash)
+	return hash
+}
+
 func VerusHash_V2B(serializedHeader []byte) []byte {
 	hash := make([]byte, 32)
 	ptrHash := uintptr(unsafe.Pointer(&hash[0]))
@@ -33,13 +33,15 @@ type CompactBlock struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProtoVersion uint32       `protobuf:"varint,1,opt,name=protoVersion,proto3" json:"protoVersion,omitempty"` // the version of this wire format, for storage
-	Height       uint64       `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`             // the height of this block
-	Hash         []byte       `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`                  // the ID (hash) of this block, same as in block explorers
-	PrevHash     []byte       `protobuf:"bytes,4,opt,name=prevHash,proto3" json:"prevHash,omitempty"`          // the ID (hash) of this block's predecessor
-	Time         uint32       `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`                 // Unix epoch time when the block was mined
-	Header       []byte       `protobuf:"bytes,6,opt,name=header,proto3" json:"header,omitempty"`              // (hash, prevHash, and time) OR (full header)
-	Vtx          []*CompactTx `protobuf:"bytes,7,rep,name=vtx,proto3" json:"vtx,omitempty"`                    // zero or more compact transactions from this block
+	ProtoVersion uint32           `protobuf:"varint,1,opt,name=protoVersion,proto3" json:"protoVersion,omitempty"` // the version of this wire format, for storage
+	Height       uint64           `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`             // the height of this block
+	Hash         []byte           `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`                  // the ID (hash) of this block, same as in block explorers
+	PrevHash     []byte           `protobuf:"bytes,4,opt,name=prevHash,proto3" json:"prevHash,omitempty"`          // the ID (hash) of this block's predecessor
+	Time         uint32           `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`                 // Unix epoch time when the block was mined
+	Header       []byte           `protobuf:"bytes,6,opt,name=header,proto3" json:"header,omitempty"`              // (hash, prevHash, and time) OR (full header)
+	Vtx          []*CompactTx     `protobuf:"bytes,7,rep,name=vtx,proto3" json:"vtx,omitempty"`                    // zero or more compact transactions from this block
+	Production   *BlockProduction `protobuf:"bytes,8,opt,name=production,proto3" json:"production,omitempty"`      // Verus: how the block was produced, if known
+	Final        bool             `protobuf:"varint,9,opt,name=final,proto3" json:"final,omitempty"`               // Verus: notarized and confirmed, as far as lightwalletd knew when sending it
 }
 
 func (x *CompactBlock) Reset() {
@@ -123,9 +125,113 @@ func (x *CompactBlock) GetVtx() []*CompactTx {
 	return nil
 }
 
+func (x *CompactBlock) GetProduction() *BlockProduction {
+	if x != nil {
+		return x.Production
+	}
+	return nil
+}
+
+func (x *CompactBlock) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+// BlockProduction describes how a (hybrid proof-of-work / proof-of-stake)
+// Verus block was produced, taken from its header and coinbase transaction.
+type BlockProduction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Staked          bool   `protobuf:"varint,1,opt,name=staked,proto3" json:"staked,omitempty"`                   // proof-of-stake if true, otherwise proof-of-work
+	SolutionVersion uint32 `protobuf:"varint,2,opt,name=solutionVersion,proto3" json:"solutionVersion,omitempty"` // Verus block solution version
+	Reward          int64  `protobuf:"varint,3,opt,name=reward,proto3" json:"reward,omitempty"`                   // total value of the coinbase outputs (block reward plus fees)
+	Destination     string `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`          // address paid by the coinbase, if a standard transparent address
+	StakeTxHash     []byte `protobuf:"bytes,5,opt,name=stakeTxHash,proto3" json:"stakeTxHash,omitempty"`          // staked blocks: ID (hash) of the stake transaction
+	StakeValue      int64  `protobuf:"varint,6,opt,name=stakeValue,proto3" json:"stakeValue,omitempty"`           // staked blocks: value of the stake transaction's outputs
+}
+
+func (x *BlockProduction) Reset() {
+	*x = BlockProduction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_compact_formats_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockProduction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockProduction) ProtoMessage() {}
+
+func (x *BlockProduction) ProtoReflect() protoreflect.Message {
+	mi := &file_compact_formats_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockProduction.ProtoReflect.Descriptor instead.
+func (*BlockProduction) Descriptor() ([]byte, []int) {
+	return file_compact_formats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlockProduction) GetStaked() bool {
+	if x != nil {
+		return x.Staked
+	}
+	return false
+}
+
+func (x *BlockProduction) GetSolutionVersion() uint32 {
+	if x != nil {
+		return x.SolutionVersion
+	}
+	return 0
+}
+
+func (x *BlockProduction) GetReward() int64 {
+	if x != nil {
+		return x.Reward
+	}
+	return 0
+}
+
+func (x *BlockProduction) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *BlockProduction) GetStakeTxHash() []byte {
+	if x != nil {
+		return x.StakeTxHash
+	}
+	return nil
+}
+
+func (x *BlockProduction) GetStakeValue() int64 {
+	if x != nil {
+		return x.StakeValue
+	}
+	return 0
+}
+
 // CompactTx contains the minimum information for a wallet to know if this transaction
 // is relevant to it (either pays to it or spends from it) via shielded elements
-// only. This message will not encode a transparent-to-transparent transaction.
+// only. This message will not encode a transparent-to-transparent transaction,
+// unless the transparent parts were requested (BlockRange.transparent).
 type CompactTx struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -141,12 +247,15 @@ type CompactTx struct {
 	Fee     uint32           `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`
 	Spends  []*CompactSpend  `protobuf:"bytes,4,rep,name=spends,proto3" json:"spends,omitempty"`   // inputs
 	Outputs []*CompactOutput `protobuf:"bytes,5,rep,name=outputs,proto3" json:"outputs,omitempty"` // outputs
+	// Only if requested (BlockRange.transparent):
+	Vin  []*CompactTxIn `protobuf:"bytes,6,rep,name=vin,proto3" json:"vin,omitempty"`   // transparent inputs (other than coinbase)
+	Vout []*TxOut       `protobuf:"bytes,7,rep,name=vout,proto3" json:"vout,omitempty"` // transparent outputs
 }
 
 func (x *CompactTx) Reset() {
 	*x = CompactTx{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_compact_formats_proto_msgTypes[1]
+		mi := &file_compact_formats_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -159,7 +268,7 @@ func (x *CompactTx) String() string {
 func (*CompactTx) ProtoMessage() {}
 
 func (x *CompactTx) ProtoReflect() protoreflect.Message {
-	mi := &file_compact_formats_proto_msgTypes[1]
+	mi := &file_compact_formats_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -172,7 +281,7 @@ func (x *CompactTx) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompactTx.ProtoReflect.Descriptor instead.
 func (*CompactTx) Descriptor() ([]byte, []int) {
-	return file_compact_formats_proto_rawDescGZIP(), []int{1}
+	return file_compact_formats_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CompactTx) GetIndex() uint64 {
@@ -210,6 +319,132 @@ func (x *CompactTx) GetOutputs() []*CompactOutput {
 	return nil
 }
 
+func (x *CompactTx) GetVin() []*CompactTxIn {
+	if x != nil {
+		return x.Vin
+	}
+	return nil
+}
+
+func (x *CompactTx) GetVout() []*TxOut {
+	if x != nil {
+		return x.Vout
+	}
+	return nil
+}
+
+// CompactTxIn is a transparent input: the output it spends.
+type CompactTxIn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PrevoutTxid  []byte `protobuf:"bytes,1,opt,name=prevoutTxid,proto3" json:"prevoutTxid,omitempty"`    // the ID (hash) of the transaction that created the output
+	PrevoutIndex uint32 `protobuf:"varint,2,opt,name=prevoutIndex,proto3" json:"prevoutIndex,omitempty"` // the index of the output in that transaction
+}
+
+func (x *CompactTxIn) Reset() {
+	*x = CompactTxIn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_compact_formats_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompactTxIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactTxIn) ProtoMessage() {}
+
+func (x *CompactTxIn) ProtoReflect() protoreflect.Message {
+	mi := &file_compact_formats_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactTxIn.ProtoReflect.Descriptor instead.
+func (*CompactTxIn) Descriptor() ([]byte, []int) {
+	return file_compact_formats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CompactTxIn) GetPrevoutTxid() []byte {
+	if x != nil {
+		return x.PrevoutTxid
+	}
+	return nil
+}
+
+func (x *CompactTxIn) GetPrevoutIndex() uint32 {
+	if x != nil {
+		return x.PrevoutIndex
+	}
+	return 0
+}
+
+// TxOut is a transparent output.
+type TxOut struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value        uint64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`              // in zatoshis
+	ScriptPubKey []byte `protobuf:"bytes,2,opt,name=scriptPubKey,proto3" json:"scriptPubKey,omitempty"` // the script that must be satisfied to spend it
+}
+
+func (x *TxOut) Reset() {
+	*x = TxOut{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_compact_formats_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxOut) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxOut) ProtoMessage() {}
+
+func (x *TxOut) ProtoReflect() protoreflect.Message {
+	mi := &file_compact_formats_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxOut.ProtoReflect.Descriptor instead.
+func (*TxOut) Descriptor() ([]byte, []int) {
+	return file_compact_formats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TxOut) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TxOut) GetScriptPubKey() []byte {
+	if x != nil {
+		return x.ScriptPubKey
+	}
+	return nil
+}
+
 // CompactSpend is a Sapling Spend Description as described in 7.3 of the Zcash
 // protocol specification.
 type CompactSpend struct {
@@ -223,7 +458,7 @@ type CompactSpend struct {
 func (x *CompactSpend) Reset() {
 	*x = CompactSpend{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_compact_formats_proto_msgTypes[2]
+		mi := &file_compact_formats_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -236,7 +471,7 @@ func (x *CompactSpend) String() string {
 func (*CompactSpend) ProtoMessage() {}
 
 func (x *CompactSpend) ProtoReflect() protoreflect.Message {
-	mi := &file_compact_formats_proto_msgTypes[2]
+	mi := &file_compact_formats_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +484,7 @@ func (x *CompactSpend) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompactSpend.ProtoReflect.Descriptor instead.
 func (*CompactSpend) Descriptor() ([]byte, []int) {
-	return file_compact_formats_proto_rawDescGZIP(), []int{2}
+	return file_compact_formats_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CompactSpend) GetNf() []byte {
@@ -274,7 +509,7 @@ type CompactOutput struct {
 func (x *CompactOutput) Reset() {
 	*x = CompactOutput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_compact_formats_proto_msgTypes[3]
+		mi := &file_compact_formats_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -287,7 +522,7 @@ func (x *CompactOutput) String() string {
 func (*CompactOutput) ProtoMessage() {}
 
 func (x *CompactOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_compact_formats_proto_msgTypes[3]
+	mi := &file_compact_formats_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +535,7 @@ func (x *CompactOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompactOutput.ProtoReflect.Descriptor instead.
 func (*CompactOutput) Descriptor() ([]byte, []int) {
-	return file_compact_formats_proto_rawDescGZIP(), []int{3}
+	return file_compact_formats_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CompactOutput) GetCmu() []byte {
@@ -329,8 +564,8 @@ var File_compact_formats_proto protoreflect.FileDescriptor
 var file_compact_formats_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
 	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x63, 0x61, 0x73, 0x68, 0x2e, 0x7a, 0x2e,
-	0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x2e, 0x73, 0x64, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x22, 0xda,
-	0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x2e, 0x73, 0x64, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x22, 0xb8,
+	0x02, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
 	0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x56, 0x65, 0x72, 0x73,
 	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20,
@@ -343,7 +578,26 @@ var file_compact_formats_proto_rawDesc = []byte{
 	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x03, 0x76, 0x74, 0x78, 0x18, 0x07,
 	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x2e, 0x7a, 0x2e, 0x77, 0x61,
 	0x6c, 0x6c, 0x65, 0x74, 0x2e, 0x73, 0x64, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x63, 0x74, 0x54, 0x78, 0x52, 0x03, 0x76, 0x74, 0x78, 0x22, 0xc4, 0x01, 0x0a, 0x09,
+	0x70, 0x61, 0x63, 0x74, 0x54, 0x78, 0x52, 0x03, 0x76, 0x74, 0x78, 0x12, 0x46, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x2e, 0x7a, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x2e,
+	0x73, 0x64, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x22, 0xcf, 0x01, 0x0a, 0x0f, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x6b, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f,
+	0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x6b, 0x65, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b,
+	0x73, 0x74, 0x61, 0x6b, 0x65, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x74, 0x61, 0x6b, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xac, 0x02, 0x0a, 0x09,
 	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x54, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
 	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12,
 	0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68,
@@ -356,7 +610,23 @@ var file_compact_formats_proto_rawDesc = []byte{
 	0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x2e, 0x7a, 0x2e, 0x77, 0x61, 0x6c,
 	0x6c, 0x65, 0x74, 0x2e, 0x73, 0x64, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
 	0x61, 0x63, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x73, 0x22, 0x1e, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x53, 0x70, 0x65,
+	0x74, 0x73, 0x12, 0x34, 0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x2e, 0x7a, 0x2e, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x2e,
+	0x73, 0x64, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x54,
+	0x78, 0x49, 0x6e, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x12, 0x30, 0x0a, 0x04, 0x76, 0x6f, 0x75, 0x74,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x2e, 0x7a, 0x2e,
+	0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x2e, 0x73, 0x64, 0x6b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54,
+	0x78, 0x4f, 0x75, 0x74, 0x52, 0x04, 0x76, 0x6f, 0x75, 0x74, 0x22, 0x53, 0x0a, 0x0b, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x63, 0x74, 0x54, 0x78, 0x49, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65,
+	0x76, 0x6f, 0x75, 0x74, 0x54, 0x78, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b,
+	0x70, 0x72, 0x65, 0x76, 0x6f, 0x75, 0x74, 0x54, 0x78, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x70,
+	0x72, 0x65, 0x76, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x6f, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0x41, 0x0a, 0x05, 0x54, 0x78, 0x4f, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x50, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x22, 0x1e, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x53, 0x70, 0x65,
 	0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6e, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02,
 	0x6e, 0x66, 0x22, 0x53, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x4f, 0x75, 0x74,
 	0x70, 0x75, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
@@ -380,22 +650,28 @@ func file_compact_formats_proto_rawDescGZIP() []byte {
 	return file_compact_formats_proto_rawDescData
 }
 
-var file_compact_formats_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_compact_formats_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_compact_formats_proto_goTypes = []interface{}{
-	(*CompactBlock)(nil),  // 0: cash.z.wallet.sdk.rpc.CompactBlock
-	(*CompactTx)(nil),     // 1: cash.z.wallet.sdk.rpc.CompactTx
-	(*CompactSpend)(nil),  // 2: cash.z.wallet.sdk.rpc.CompactSpend
-	(*CompactOutput)(nil), // 3: cash.z.wallet.sdk.rpc.CompactOutput
+	(*CompactBlock)(nil),    // 0: cash.z.wallet.sdk.rpc.CompactBlock
+	(*BlockProduction)(nil), // 1: cash.z.wallet.sdk.rpc.BlockProduction
+	(*CompactTx)(nil),       // 2: cash.z.wallet.sdk.rpc.CompactTx
+	(*CompactTxIn)(nil),     // 3: cash.z.wallet.sdk.rpc.CompactTxIn
+	(*TxOut)(nil),           // 4: cash.z.wallet.sdk.rpc.TxOut
+	(*CompactSpend)(nil),    // 5: cash.z.wallet.sdk.rpc.CompactSpend
+	(*CompactOutput)(nil),   // 6: cash.z.wallet.sdk.rpc.CompactOutput
 }
 var file_compact_formats_proto_depIdxs = []int32{
-	1, // 0: cash.z.wallet.sdk.rpc.CompactBlock.vtx:type_name -> cash.z.wallet.sdk.rpc.CompactTx
-	2, // 1: cash.z.wallet.sdk.rpc.CompactTx.spends:type_name -> cash.z.wallet.sdk.rpc.CompactSpend
-	3, // 2: cash.z.wallet.sdk.rpc.CompactTx.outputs:type_name -> cash.z.wallet.sdk.rpc.CompactOutput
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2, // 0: cash.z.wallet.sdk.rpc.CompactBlock.vtx:type_name -> cash.z.wallet.sdk.rpc.CompactTx
+	1, // 1: cash.z.wallet.sdk.rpc.CompactBlock.production:type_name -> cash.z.wallet.sdk.rpc.BlockProduction
+	5, // 2: cash.z.wallet.sdk.rpc.CompactTx.spends:type_name -> cash.z.wallet.sdk.rpc.CompactSpend
+	6, // 3: cash.z.wallet.sdk.rpc.CompactTx.outputs:type_name -> cash.z.wallet.sdk.rpc.CompactOutput
+	3, // 4: cash.z.wallet.sdk.rpc.CompactTx.vin:type_name -> cash.z.wallet.sdk.rpc.CompactTxIn
+	4, // 5: cash.z.wallet.sdk.rpc.CompactTx.vout:type_name -> cash.z.wallet.sdk.rpc.TxOut
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_compact_formats_proto_init() }
@@ -417,7 +693,7 @@ func file_compact_formats_proto_init() {
 			}
 		}
 		file_compact_formats_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompactTx); i {
+			switch v := v.(*BlockProduction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -429,7 +705,7 @@ func file_compact_formats_proto_init() {
 			}
 		}
 		file_compact_formats_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompactSpend); i {
+			switch v := v.(*CompactTx); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -441,6 +717,42 @@ func file_compact_formats_proto_init() {
 			}
 		}
 		file_compact_formats_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompactTxIn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_compact_formats_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxOut); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_compact_formats_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompactSpend); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_compact_formats_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CompactOutput); i {
 			case 0:
 				return &v.state
@@ -459,7 +771,7 @@ func file_compact_formats_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_compact_formats_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -81,6 +81,18 @@ type DarksideStreamerClient interface {
 	AddAddressUtxo(ctx context.Context, in *GetAddressUtxosReply, opts ...grpc.CallOption) (*Empty, error)
 	// Clear the list of GetAddressUtxos entries (can't fail)
 	ClearAddressUtxo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Add a revision of an identity to be returned by GetIdentity() and
+	// GetIdentityHistory(); it's visible once the latest block height
+	// reaches its height. There is no staging or applying for these.
+	AddIdentity(ctx context.Context, in *IdentityUpdate, opts ...grpc.CallOption) (*Empty, error)
+	// Clear the list of identity revisions (can't fail)
+	ClearIdentities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Add a currency to be returned by GetCurrency(), replacing any with the
+	// same ID; EstimateConversion() converts at its reserve prices, ignoring
+	// fees. There is no staging or applying for these.
+	AddCurrency(ctx context.Context, in *Currency, opts ...grpc.CallOption) (*Empty, error)
+	// Clear the list of currencies (can't fail)
+	ClearCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type darksideStreamerClient struct {
@@ -263,6 +275,42 @@ func (c *darksideStreamerClient) ClearAddressUtxo(ctx context.Context, in *Empty
 	return out, nil
 }
 
+func (c *darksideStreamerClient) AddIdentity(ctx context.Context, in *IdentityUpdate, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.DarksideStreamer/AddIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *darksideStreamerClient) ClearIdentities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.DarksideStreamer/ClearIdentities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *darksideStreamerClient) AddCurrency(ctx context.Context, in *Currency, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.DarksideStreamer/AddCurrency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *darksideStreamerClient) ClearCurrencies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.DarksideStreamer/ClearCurrencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DarksideStreamerServer is the server API for DarksideStreamer service.
 // All implementations must embed UnimplementedDarksideStreamerServer
 // for forward compatibility
@@ -330,6 +378,18 @@ type DarksideStreamerServer interface {
 	AddAddressUtxo(context.Context, *GetAddressUtxosReply) (*Empty, error)
 	// Clear the list of GetAddressUtxos entries (can't fail)
 	ClearAddressUtxo(context.Context, *Empty) (*Empty, error)
+	// Add a revision of an identity to be returned by GetIdentity() and
+	// GetIdentityHistory(); it's visible once the latest block height
+	// reaches its height. There is no staging or applying for these.
+	AddIdentity(context.Context, *IdentityUpdate) (*Empty, error)
+	// Clear the list of identity revisions (can't fail)
+	ClearIdentities(context.Context, *Empty) (*Empty, error)
+	// Add a currency to be returned by GetCurrency(), replacing any with the
+	// same ID; EstimateConversion() converts at its reserve prices, ignoring
+	// fees. There is no staging or applying for these.
+	AddCurrency(context.Context, *Currency) (*Empty, error)
+	// Clear the list of currencies (can't fail)
+	ClearCurrencies(context.Context, *Empty) (*Empty, error)
 	mustEmbedUnimplementedDarksideStreamerServer()
 }
 
@@ -370,6 +430,18 @@ func (UnimplementedDarksideStreamerServer) AddAddressUtxo(context.Context, *GetA
 func (UnimplementedDarksideStreamerServer) ClearAddressUtxo(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearAddressUtxo not implemented")
 }
+func (UnimplementedDarksideStreamerServer) AddIdentity(context.Context, *IdentityUpdate) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddIdentity not implemented")
+}
+func (UnimplementedDarksideStreamerServer) ClearIdentities(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearIdentities not implemented")
+}
+func (UnimplementedDarksideStreamerServer) AddCurrency(context.Context, *Currency) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCurrency not implemented")
+}
+func (UnimplementedDarksideStreamerServer) ClearCurrencies(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCurrencies not implemented")
+}
 func (UnimplementedDarksideStreamerServer) mustEmbedUnimplementedDarksideStreamerServer() {}
 
 // UnsafeDarksideStreamerServer may be embedded to opt out of forward compatibility for this service.
@@ -600,6 +672,78 @@ func _DarksideStreamer_ClearAddressUtxo_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DarksideStreamer_AddIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DarksideStreamerServer).AddIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.DarksideStreamer/AddIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DarksideStreamerServer).AddIdentity(ctx, req.(*IdentityUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DarksideStreamer_ClearIdentities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DarksideStreamerServer).ClearIdentities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.DarksideStreamer/ClearIdentities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DarksideStreamerServer).ClearIdentities(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DarksideStreamer_AddCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Currency)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DarksideStreamerServer).AddCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.DarksideStreamer/AddCurrency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DarksideStreamerServer).AddCurrency(ctx, req.(*Currency))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DarksideStreamer_ClearCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DarksideStreamerServer).ClearCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.DarksideStreamer/ClearCurrencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DarksideStreamerServer).ClearCurrencies(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DarksideStreamer_ServiceDesc is the grpc.ServiceDesc for DarksideStreamer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -639,6 +783,22 @@ var DarksideStreamer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ClearAddressUtxo",
 			Handler:    _DarksideStreamer_ClearAddressUtxo_Handler,
 		},
+		{
+			MethodName: "AddIdentity",
+			Handler:    _DarksideStreamer_AddIdentity_Handler,
+		},
+		{
+			MethodName: "ClearIdentities",
+			Handler:    _DarksideStreamer_ClearIdentities_Handler,
+		},
+		{
+			MethodName: "AddCurrency",
+			Handler:    _DarksideStreamer_AddCurrency_Handler,
+		},
+		{
+			MethodName: "ClearCurrencies",
+			Handler:    _DarksideStreamer_ClearCurrencies_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -24,23 +24,69 @@ type CompactTxStreamerClient interface {
 	GetBlock(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (*CompactBlock, error)
 	// Return a list of consecutive compact blocks
 	GetBlockRange(ctx context.Context, in *BlockRange, opts ...grpc.CallOption) (CompactTxStreamer_GetBlockRangeClient, error)
+	// Return the block filters, with their headers, of a range of blocks
+	// (BlockRange.start to end, in increasing order; its other fields aren't
+	// used), so that wallets can tell which blocks they need. Only served by
+	// servers started with --block-filters.
+	GetBlockFilters(ctx context.Context, in *BlockRange, opts ...grpc.CallOption) (CompactTxStreamer_GetBlockFiltersClient, error)
+	// Stream each change to the tip of the best chain as it happens; the
+	// stream stays open until the client cancels it.
+	SubscribeBlocks(ctx context.Context, in *SubscribeBlocksArg, opts ...grpc.CallOption) (CompactTxStreamer_SubscribeBlocksClient, error)
 	// Return the requested full (not compact) transaction (as from zcashd)
 	GetTransaction(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (*RawTransaction, error)
-	// Submit the given transaction to the Zcash network
+	// Submit the given transaction to the Zcash network. verusd's verdict is
+	// in the SendResponse; a transaction that verusd would certainly reject
+	// (malformed, expired, not valid on GetLightdInfo's consensusBranchId,
+	// too large, or just sent) fails with INVALID_ARGUMENT,
+	// FAILED_PRECONDITION or ALREADY_EXISTS and an ErrorInfo detail, without
+	// being submitted.
 	SendTransaction(ctx context.Context, in *RawTransaction, opts ...grpc.CallOption) (*SendResponse, error)
-	// Return the txids corresponding to the given t-address within the given block range
+	// Return the status of a transaction (by txid), such as one that was
+	// submitted; the stream variant sends the status again each time it changes.
+	GetTransactionStatus(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (*TransactionStatus, error)
+	GetTransactionStatusStream(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (CompactTxStreamer_GetTransactionStatusStreamClient, error)
+	// Suggest fees for the given confirmation targets, from verusd's estimate
+	// and lightwalletd's observations of recent blocks and the mempool
+	GetFeeEstimate(ctx context.Context, in *GetFeeEstimateArg, opts ...grpc.CallOption) (*FeeEstimateReply, error)
+	// Return the txids corresponding to the given t-address (or VerusID) within the given block range
 	GetTaddressTxids(ctx context.Context, in *TransparentAddressBlockFilter, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressTxidsClient, error)
+	// Return a page of the transactions involving the given t-addresses (or
+	// VerusIDs), with each one's net change to their balances
+	GetTaddressHistory(ctx context.Context, in *GetTaddressHistoryArg, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressHistoryClient, error)
 	GetTaddressBalance(ctx context.Context, in *AddressList, opts ...grpc.CallOption) (*Balance, error)
 	GetTaddressBalanceStream(ctx context.Context, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressBalanceStreamClient, error)
 	// Return a stream of current Mempool transactions. This will keep the output stream open while
 	// there are mempool transactions. It will close the returned stream when a new block is mined.
 	GetMempoolStream(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolStreamClient, error)
+	// Return the compact form of each Sapling transaction currently in the
+	// mempool, except those excluded; the stream ends when all are sent.
+	GetMempoolTx(ctx context.Context, in *Exclude, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolTxClient, error)
+	// Return each transaction now in the mempool as added, then each change to
+	// the mempool as it happens, until the client cancels the stream.
+	GetMempoolEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolEventsClient, error)
 	// GetTreeState returns the note commitment tree state corresponding to the given block.
 	// See section 3.7 of the Zcash protocol specification. It returns several other useful
 	// values also (even though they can be obtained using GetBlock).
 	// The block can be specified by either height or hash.
 	GetTreeState(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (*TreeState, error)
 	GetLatestTreeState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TreeState, error)
+	// Returns a stream of the roots of complete 2^16-leaf subtrees of the
+	// note commitment tree, in order, starting at startIndex. The stream
+	// ends at the first subtree that isn't complete. The roots are only
+	// known if the server's cache was downloaded from Sapling activation;
+	// otherwise, a subtree that is complete but not known fails with
+	// FAILED_PRECONDITION, until the server is restarted with --redownload.
+	GetSubtreeRoots(ctx context.Context, in *GetSubtreeRootsArg, opts ...grpc.CallOption) (CompactTxStreamer_GetSubtreeRootsClient, error)
+	// Return the given VerusID as of the given (or latest) block
+	GetIdentity(ctx context.Context, in *GetIdentityArg, opts ...grpc.CallOption) (*IdentityInfo, error)
+	// Return the revisions of the given VerusID within the given block range, oldest first
+	GetIdentityHistory(ctx context.Context, in *GetIdentityHistoryArg, opts ...grpc.CallOption) (CompactTxStreamer_GetIdentityHistoryClient, error)
+	// Return the definition and latest state of the given currency
+	GetCurrency(ctx context.Context, in *GetCurrencyArg, opts ...grpc.CallOption) (*Currency, error)
+	// Estimate the result of converting between currencies at the latest block
+	EstimateConversion(ctx context.Context, in *EstimateConversionArg, opts ...grpc.CallOption) (*ConversionEstimate, error)
+	// Return the finality of the given block (the latest block if the height is zero)
+	GetFinalityStatus(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (*FinalityStatus, error)
 	GetAddressUtxos(ctx context.Context, in *GetAddressUtxosArg, opts ...grpc.CallOption) (*GetAddressUtxosReplyList, error)
 	GetAddressUtxosStream(ctx context.Context, in *GetAddressUtxosArg, opts ...grpc.CallOption) (CompactTxStreamer_GetAddressUtxosStreamClient, error)
 	// Return information about this lightwalletd instance and the blockchain
@@ -107,6 +153,70 @@ func (x *compactTxStreamerGetBlockRangeClient) Recv() (*CompactBlock, error) {
 	return m, nil
 }
 
+func (c *compactTxStreamerClient) GetBlockFilters(ctx context.Context, in *BlockRange, opts ...grpc.CallOption) (CompactTxStreamer_GetBlockFiltersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[1], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetBlockFilters", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetBlockFiltersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetBlockFiltersClient interface {
+	Recv() (*BlockFilter, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetBlockFiltersClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetBlockFiltersClient) Recv() (*BlockFilter, error) {
+	m := new(BlockFilter)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compactTxStreamerClient) SubscribeBlocks(ctx context.Context, in *SubscribeBlocksArg, opts ...grpc.CallOption) (CompactTxStreamer_SubscribeBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[2], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/SubscribeBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerSubscribeBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_SubscribeBlocksClient interface {
+	Recv() (*BlockUpdate, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerSubscribeBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerSubscribeBlocksClient) Recv() (*BlockUpdate, error) {
+	m := new(BlockUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *compactTxStreamerClient) GetTransaction(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (*RawTransaction, error) {
 	out := new(RawTransaction)
 	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTransaction", in, out, opts...)
@@ -125,8 +235,58 @@ func (c *compactTxStreamerClient) SendTransaction(ctx context.Context, in *RawTr
 	return out, nil
 }
 
+func (c *compactTxStreamerClient) GetTransactionStatus(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (*TransactionStatus, error) {
+	out := new(TransactionStatus)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTransactionStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) GetTransactionStatusStream(ctx context.Context, in *TxFilter, opts ...grpc.CallOption) (CompactTxStreamer_GetTransactionStatusStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[3], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTransactionStatusStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetTransactionStatusStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetTransactionStatusStreamClient interface {
+	Recv() (*TransactionStatus, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetTransactionStatusStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetTransactionStatusStreamClient) Recv() (*TransactionStatus, error) {
+	m := new(TransactionStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compactTxStreamerClient) GetFeeEstimate(ctx context.Context, in *GetFeeEstimateArg, opts ...grpc.CallOption) (*FeeEstimateReply, error) {
+	out := new(FeeEstimateReply)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetFeeEstimate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *compactTxStreamerClient) GetTaddressTxids(ctx context.Context, in *TransparentAddressBlockFilter, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressTxidsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[1], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressTxids", opts...)
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[4], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressTxids", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -157,6 +317,38 @@ func (x *compactTxStreamerGetTaddressTxidsClient) Recv() (*RawTransaction, error
 	return m, nil
 }
 
+func (c *compactTxStreamerClient) GetTaddressHistory(ctx context.Context, in *GetTaddressHistoryArg, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[5], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressHistory", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetTaddressHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetTaddressHistoryClient interface {
+	Recv() (*TaddressTransaction, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetTaddressHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetTaddressHistoryClient) Recv() (*TaddressTransaction, error) {
+	m := new(TaddressTransaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *compactTxStreamerClient) GetTaddressBalance(ctx context.Context, in *AddressList, opts ...grpc.CallOption) (*Balance, error) {
 	out := new(Balance)
 	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressBalance", in, out, opts...)
@@ -167,7 +359,7 @@ func (c *compactTxStreamerClient) GetTaddressBalance(ctx context.Context, in *Ad
 }
 
 func (c *compactTxStreamerClient) GetTaddressBalanceStream(ctx context.Context, opts ...grpc.CallOption) (CompactTxStreamer_GetTaddressBalanceStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[2], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressBalanceStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[6], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTaddressBalanceStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +393,7 @@ func (x *compactTxStreamerGetTaddressBalanceStreamClient) CloseAndRecv() (*Balan
 }
 
 func (c *compactTxStreamerClient) GetMempoolStream(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[3], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetMempoolStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[7], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetMempoolStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +424,70 @@ func (x *compactTxStreamerGetMempoolStreamClient) Recv() (*RawTransaction, error
 	return m, nil
 }
 
+func (c *compactTxStreamerClient) GetMempoolTx(ctx context.Context, in *Exclude, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolTxClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[8], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetMempoolTx", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetMempoolTxClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetMempoolTxClient interface {
+	Recv() (*CompactTx, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetMempoolTxClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetMempoolTxClient) Recv() (*CompactTx, error) {
+	m := new(CompactTx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compactTxStreamerClient) GetMempoolEvents(ctx context.Context, in *Empty, opts ...grpc.CallOption) (CompactTxStreamer_GetMempoolEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[9], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetMempoolEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetMempoolEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetMempoolEventsClient interface {
+	Recv() (*MempoolTxEvent, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetMempoolEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetMempoolEventsClient) Recv() (*MempoolTxEvent, error) {
+	m := new(MempoolTxEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *compactTxStreamerClient) GetTreeState(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (*TreeState, error) {
 	out := new(TreeState)
 	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTreeState", in, out, opts...)
@@ -250,6 +506,106 @@ func (c *compactTxStreamerClient) GetLatestTreeState(ctx context.Context, in *Em
 	return out, nil
 }
 
+func (c *compactTxStreamerClient) GetSubtreeRoots(ctx context.Context, in *GetSubtreeRootsArg, opts ...grpc.CallOption) (CompactTxStreamer_GetSubtreeRootsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[10], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetSubtreeRoots", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetSubtreeRootsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetSubtreeRootsClient interface {
+	Recv() (*SubtreeRoot, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetSubtreeRootsClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetSubtreeRootsClient) Recv() (*SubtreeRoot, error) {
+	m := new(SubtreeRoot)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compactTxStreamerClient) GetIdentity(ctx context.Context, in *GetIdentityArg, opts ...grpc.CallOption) (*IdentityInfo, error) {
+	out := new(IdentityInfo)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetIdentity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) GetIdentityHistory(ctx context.Context, in *GetIdentityHistoryArg, opts ...grpc.CallOption) (CompactTxStreamer_GetIdentityHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[11], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetIdentityHistory", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &compactTxStreamerGetIdentityHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompactTxStreamer_GetIdentityHistoryClient interface {
+	Recv() (*IdentityUpdate, error)
+	grpc.ClientStream
+}
+
+type compactTxStreamerGetIdentityHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *compactTxStreamerGetIdentityHistoryClient) Recv() (*IdentityUpdate, error) {
+	m := new(IdentityUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *compactTxStreamerClient) GetCurrency(ctx context.Context, in *GetCurrencyArg, opts ...grpc.CallOption) (*Currency, error) {
+	out := new(Currency)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetCurrency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) EstimateConversion(ctx context.Context, in *EstimateConversionArg, opts ...grpc.CallOption) (*ConversionEstimate, error) {
+	out := new(ConversionEstimate)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/EstimateConversion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *compactTxStreamerClient) GetFinalityStatus(ctx context.Context, in *BlockID, opts ...grpc.CallOption) (*FinalityStatus, error) {
+	out := new(FinalityStatus)
+	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetFinalityStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *compactTxStreamerClient) GetAddressUtxos(ctx context.Context, in *GetAddressUtxosArg, opts ...grpc.CallOption) (*GetAddressUtxosReplyList, error) {
 	out := new(GetAddressUtxosReplyList)
 	err := c.cc.Invoke(ctx, "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetAddressUtxos", in, out, opts...)
@@ -260,7 +616,7 @@ func (c *compactTxStreamerClient) GetAddressUtxos(ctx context.Context, in *GetAd
 }
 
 func (c *compactTxStreamerClient) GetAddressUtxosStream(ctx context.Context, in *GetAddressUtxosArg, opts ...grpc.CallOption) (CompactTxStreamer_GetAddressUtxosStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[4], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetAddressUtxosStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &CompactTxStreamer_ServiceDesc.Streams[12], "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetAddressUtxosStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -319,23 +675,69 @@ type CompactTxStreamerServer interface {
 	GetBlock(context.Context, *BlockID) (*CompactBlock, error)
 	// Return a list of consecutive compact blocks
 	GetBlockRange(*BlockRange, CompactTxStreamer_GetBlockRangeServer) error
+	// Return the block filters, with their headers, of a range of blocks
+	// (BlockRange.start to end, in increasing order; its other fields aren't
+	// used), so that wallets can tell which blocks they need. Only served by
+	// servers started with --block-filters.
+	GetBlockFilters(*BlockRange, CompactTxStreamer_GetBlockFiltersServer) error
+	// Stream each change to the tip of the best chain as it happens; the
+	// stream stays open until the client cancels it.
+	SubscribeBlocks(*SubscribeBlocksArg, CompactTxStreamer_SubscribeBlocksServer) error
 	// Return the requested full (not compact) transaction (as from zcashd)
 	GetTransaction(context.Context, *TxFilter) (*RawTransaction, error)
-	// Submit the given transaction to the Zcash network
+	// Submit the given transaction to the Zcash network. verusd's verdict is
+	// in the SendResponse; a transaction that verusd would certainly reject
+	// (malformed, expired, not valid on GetLightdInfo's consensusBranchId,
+	// too large, or just sent) fails with INVALID_ARGUMENT,
+	// FAILED_PRECONDITION or ALREADY_EXISTS and an ErrorInfo detail, without
+	// being submitted.
 	SendTransaction(context.Context, *RawTransaction) (*SendResponse, error)
-	// Return the txids corresponding to the given t-address within the given block range
+	// Return the status of a transaction (by txid), such as one that was
+	// submitted; the stream variant sends the status again each time it changes.
+	GetTransactionStatus(context.Context, *TxFilter) (*TransactionStatus, error)
+	GetTransactionStatusStream(*TxFilter, CompactTxStreamer_GetTransactionStatusStreamServer) error
+	// Suggest fees for the given confirmation targets, from verusd's estimate
+	// and lightwalletd's observations of recent blocks and the mempool
+	GetFeeEstimate(context.Context, *GetFeeEstimateArg) (*FeeEstimateReply, error)
+	// Return the txids corresponding to the given t-address (or VerusID) within the given block range
 	GetTaddressTxids(*TransparentAddressBlockFilter, CompactTxStreamer_GetTaddressTxidsServer) error
+	// Return a page of the transactions involving the given t-addresses (or
+	// VerusIDs), with each one's net change to their balances
+	GetTaddressHistory(*GetTaddressHistoryArg, CompactTxStreamer_GetTaddressHistoryServer) error
 	GetTaddressBalance(context.Context, *AddressList) (*Balance, error)
 	GetTaddressBalanceStream(CompactTxStreamer_GetTaddressBalanceStreamServer) error
 	// Return a stream of current Mempool transactions. This will keep the output stream open while
 	// there are mempool transactions. It will close the returned stream when a new block is mined.
 	GetMempoolStream(*Empty, CompactTxStreamer_GetMempoolStreamServer) error
+	// Return the compact form of each Sapling transaction currently in the
+	// mempool, except those excluded; the stream ends when all are sent.
+	GetMempoolTx(*Exclude, CompactTxStreamer_GetMempoolTxServer) error
+	// Return each transaction now in the mempool as added, then each change to
+	// the mempool as it happens, until the client cancels the stream.
+	GetMempoolEvents(*Empty, CompactTxStreamer_GetMempoolEventsServer) error
 	// GetTreeState returns the note commitment tree state corresponding to the given block.
 	// See section 3.7 of the Zcash protocol specification. It returns several other useful
 	// values also (even though they can be obtained using GetBlock).
 	// The block can be specified by either height or hash.
 	GetTreeState(context.Context, *BlockID) (*TreeState, error)
 	GetLatestTreeState(context.Context, *Empty) (*TreeState, error)
+	// Returns a stream of the roots of complete 2^16-leaf subtrees of the
+	// note commitment tree, in order, starting at startIndex. The stream
+	// ends at the first subtree that isn't complete. The roots are only
+	// known if the server's cache was downloaded from Sapling activation;
+	// otherwise, a subtree that is complete but not known fails with
+	// FAILED_PRECONDITION, until the server is restarted with --redownload.
+	GetSubtreeRoots(*GetSubtreeRootsArg, CompactTxStreamer_GetSubtreeRootsServer) error
+	// Return the given VerusID as of the given (or latest) block
+	GetIdentity(context.Context, *GetIdentityArg) (*IdentityInfo, error)
+	// Return the revisions of the given VerusID within the given block range, oldest first
+	GetIdentityHistory(*GetIdentityHistoryArg, CompactTxStreamer_GetIdentityHistoryServer) error
+	// Return the definition and latest state of the given currency
+	GetCurrency(context.Context, *GetCurrencyArg) (*Currency, error)
+	// Estimate the result of converting between currencies at the latest block
+	EstimateConversion(context.Context, *EstimateConversionArg) (*ConversionEstimate, error)
+	// Return the finality of the given block (the latest block if the height is zero)
+	GetFinalityStatus(context.Context, *BlockID) (*FinalityStatus, error)
 	GetAddressUtxos(context.Context, *GetAddressUtxosArg) (*GetAddressUtxosReplyList, error)
 	GetAddressUtxosStream(*GetAddressUtxosArg, CompactTxStreamer_GetAddressUtxosStreamServer) error
 	// Return information about this lightwalletd instance and the blockchain
@@ -358,15 +760,33 @@ func (UnimplementedCompactTxStreamerServer) GetBlock(context.Context, *BlockID)
 func (UnimplementedCompactTxStreamerServer) GetBlockRange(*BlockRange, CompactTxStreamer_GetBlockRangeServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetBlockRange not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetBlockFilters(*BlockRange, CompactTxStreamer_GetBlockFiltersServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBlockFilters not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) SubscribeBlocks(*SubscribeBlocksArg, CompactTxStreamer_SubscribeBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlocks not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) GetTransaction(context.Context, *TxFilter) (*RawTransaction, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
 }
 func (UnimplementedCompactTxStreamerServer) SendTransaction(context.Context, *RawTransaction) (*SendResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetTransactionStatus(context.Context, *TxFilter) (*TransactionStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionStatus not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetTransactionStatusStream(*TxFilter, CompactTxStreamer_GetTransactionStatusStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetTransactionStatusStream not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetFeeEstimate(context.Context, *GetFeeEstimateArg) (*FeeEstimateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeeEstimate not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) GetTaddressTxids(*TransparentAddressBlockFilter, CompactTxStreamer_GetTaddressTxidsServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetTaddressTxids not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetTaddressHistory(*GetTaddressHistoryArg, CompactTxStreamer_GetTaddressHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetTaddressHistory not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) GetTaddressBalance(context.Context, *AddressList) (*Balance, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTaddressBalance not implemented")
 }
@@ -376,12 +796,36 @@ func (UnimplementedCompactTxStreamerServer) GetTaddressBalanceStream(CompactTxSt
 func (UnimplementedCompactTxStreamerServer) GetMempoolStream(*Empty, CompactTxStreamer_GetMempoolStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetMempoolStream not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetMempoolTx(*Exclude, CompactTxStreamer_GetMempoolTxServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetMempoolTx not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetMempoolEvents(*Empty, CompactTxStreamer_GetMempoolEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetMempoolEvents not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) GetTreeState(context.Context, *BlockID) (*TreeState, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTreeState not implemented")
 }
 func (UnimplementedCompactTxStreamerServer) GetLatestTreeState(context.Context, *Empty) (*TreeState, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLatestTreeState not implemented")
 }
+func (UnimplementedCompactTxStreamerServer) GetSubtreeRoots(*GetSubtreeRootsArg, CompactTxStreamer_GetSubtreeRootsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetSubtreeRoots not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetIdentity(context.Context, *GetIdentityArg) (*IdentityInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdentity not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetIdentityHistory(*GetIdentityHistoryArg, CompactTxStreamer_GetIdentityHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetIdentityHistory not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetCurrency(context.Context, *GetCurrencyArg) (*Currency, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrency not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) EstimateConversion(context.Context, *EstimateConversionArg) (*ConversionEstimate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateConversion not implemented")
+}
+func (UnimplementedCompactTxStreamerServer) GetFinalityStatus(context.Context, *BlockID) (*FinalityStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFinalityStatus not implemented")
+}
 func (UnimplementedCompactTxStreamerServer) GetAddressUtxos(context.Context, *GetAddressUtxosArg) (*GetAddressUtxosReplyList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAddressUtxos not implemented")
 }
@@ -464,6 +908,48 @@ func (x *compactTxStreamerGetBlockRangeServer) Send(m *CompactBlock) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CompactTxStreamer_GetBlockFilters_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockRange)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetBlockFilters(m, &compactTxStreamerGetBlockFiltersServer{stream})
+}
+
+type CompactTxStreamer_GetBlockFiltersServer interface {
+	Send(*BlockFilter) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetBlockFiltersServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetBlockFiltersServer) Send(m *BlockFilter) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompactTxStreamer_SubscribeBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlocksArg)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).SubscribeBlocks(m, &compactTxStreamerSubscribeBlocksServer{stream})
+}
+
+type CompactTxStreamer_SubscribeBlocksServer interface {
+	Send(*BlockUpdate) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerSubscribeBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerSubscribeBlocksServer) Send(m *BlockUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _CompactTxStreamer_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TxFilter)
 	if err := dec(in); err != nil {
@@ -500,6 +986,63 @@ func _CompactTxStreamer_SendTransaction_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CompactTxStreamer_GetTransactionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetTransactionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetTransactionStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetTransactionStatus(ctx, req.(*TxFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_GetTransactionStatusStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TxFilter)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetTransactionStatusStream(m, &compactTxStreamerGetTransactionStatusStreamServer{stream})
+}
+
+type CompactTxStreamer_GetTransactionStatusStreamServer interface {
+	Send(*TransactionStatus) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetTransactionStatusStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetTransactionStatusStreamServer) Send(m *TransactionStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompactTxStreamer_GetFeeEstimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeeEstimateArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetFeeEstimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetFeeEstimate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetFeeEstimate(ctx, req.(*GetFeeEstimateArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CompactTxStreamer_GetTaddressTxids_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(TransparentAddressBlockFilter)
 	if err := stream.RecvMsg(m); err != nil {
@@ -521,6 +1064,27 @@ func (x *compactTxStreamerGetTaddressTxidsServer) Send(m *RawTransaction) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CompactTxStreamer_GetTaddressHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTaddressHistoryArg)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetTaddressHistory(m, &compactTxStreamerGetTaddressHistoryServer{stream})
+}
+
+type CompactTxStreamer_GetTaddressHistoryServer interface {
+	Send(*TaddressTransaction) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetTaddressHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetTaddressHistoryServer) Send(m *TaddressTransaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _CompactTxStreamer_GetTaddressBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddressList)
 	if err := dec(in); err != nil {
@@ -586,6 +1150,48 @@ func (x *compactTxStreamerGetMempoolStreamServer) Send(m *RawTransaction) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CompactTxStreamer_GetMempoolTx_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Exclude)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetMempoolTx(m, &compactTxStreamerGetMempoolTxServer{stream})
+}
+
+type CompactTxStreamer_GetMempoolTxServer interface {
+	Send(*CompactTx) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetMempoolTxServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetMempoolTxServer) Send(m *CompactTx) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompactTxStreamer_GetMempoolEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetMempoolEvents(m, &compactTxStreamerGetMempoolEventsServer{stream})
+}
+
+type CompactTxStreamer_GetMempoolEventsServer interface {
+	Send(*MempoolTxEvent) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetMempoolEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetMempoolEventsServer) Send(m *MempoolTxEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _CompactTxStreamer_GetTreeState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlockID)
 	if err := dec(in); err != nil {
@@ -622,6 +1228,120 @@ func _CompactTxStreamer_GetLatestTreeState_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CompactTxStreamer_GetSubtreeRoots_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetSubtreeRootsArg)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetSubtreeRoots(m, &compactTxStreamerGetSubtreeRootsServer{stream})
+}
+
+type CompactTxStreamer_GetSubtreeRootsServer interface {
+	Send(*SubtreeRoot) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetSubtreeRootsServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetSubtreeRootsServer) Send(m *SubtreeRoot) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompactTxStreamer_GetIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIdentityArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetIdentity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetIdentity(ctx, req.(*GetIdentityArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_GetIdentityHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetIdentityHistoryArg)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompactTxStreamerServer).GetIdentityHistory(m, &compactTxStreamerGetIdentityHistoryServer{stream})
+}
+
+type CompactTxStreamer_GetIdentityHistoryServer interface {
+	Send(*IdentityUpdate) error
+	grpc.ServerStream
+}
+
+type compactTxStreamerGetIdentityHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *compactTxStreamerGetIdentityHistoryServer) Send(m *IdentityUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CompactTxStreamer_GetCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrencyArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetCurrency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetCurrency(ctx, req.(*GetCurrencyArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_EstimateConversion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateConversionArg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).EstimateConversion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/EstimateConversion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).EstimateConversion(ctx, req.(*EstimateConversionArg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompactTxStreamer_GetFinalityStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompactTxStreamerServer).GetFinalityStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cash.z.wallet.sdk.rpc.CompactTxStreamer/GetFinalityStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompactTxStreamerServer).GetFinalityStatus(ctx, req.(*BlockID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CompactTxStreamer_GetAddressUtxos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAddressUtxosArg)
 	if err := dec(in); err != nil {
@@ -720,6 +1440,14 @@ var CompactTxStreamer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendTransaction",
 			Handler:    _CompactTxStreamer_SendTransaction_Handler,
 		},
+		{
+			MethodName: "GetTransactionStatus",
+			Handler:    _CompactTxStreamer_GetTransactionStatus_Handler,
+		},
+		{
+			MethodName: "GetFeeEstimate",
+			Handler:    _CompactTxStreamer_GetFeeEstimate_Handler,
+		},
 		{
 			MethodName: "GetTaddressBalance",
 			Handler:    _CompactTxStreamer_GetTaddressBalance_Handler,
@@ -732,6 +1460,22 @@ var CompactTxStreamer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLatestTreeState",
 			Handler:    _CompactTxStreamer_GetLatestTreeState_Handler,
 		},
+		{
+			MethodName: "GetIdentity",
+			Handler:    _CompactTxStreamer_GetIdentity_Handler,
+		},
+		{
+			MethodName: "GetCurrency",
+			Handler:    _CompactTxStreamer_GetCurrency_Handler,
+		},
+		{
+			MethodName: "EstimateConversion",
+			Handler:    _CompactTxStreamer_EstimateConversion_Handler,
+		},
+		{
+			MethodName: "GetFinalityStatus",
+			Handler:    _CompactTxStreamer_GetFinalityStatus_Handler,
+		},
 		{
 			MethodName: "GetAddressUtxos",
 			Handler:    _CompactTxStreamer_GetAddressUtxos_Handler,
@@ -751,11 +1495,31 @@ var CompactTxStreamer_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CompactTxStreamer_GetBlockRange_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetBlockFilters",
+			Handler:       _CompactTxStreamer_GetBlockFilters_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeBlocks",
+			Handler:       _CompactTxStreamer_SubscribeBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetTransactionStatusStream",
+			Handler:       _CompactTxStreamer_GetTransactionStatusStream_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetTaddressTxids",
 			Handler:       _CompactTxStreamer_GetTaddressTxids_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetTaddressHistory",
+			Handler:       _CompactTxStreamer_GetTaddressHistory_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetTaddressBalanceStream",
 			Handler:       _CompactTxStreamer_GetTaddressBalanceStream_Handler,
@@ -766,6 +1530,26 @@ var CompactTxStreamer_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CompactTxStreamer_GetMempoolStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetMempoolTx",
+			Handler:       _CompactTxStreamer_GetMempoolTx_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetMempoolEvents",
+			Handler:       _CompactTxStreamer_GetMempoolEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetSubtreeRoots",
+			Handler:       _CompactTxStreamer_GetSubtreeRoots_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetIdentityHistory",
+			Handler:       _CompactTxStreamer_GetIdentityHistory_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetAddressUtxosStream",
 			Handler:       _CompactTxStreamer_GetAddressUtxosStream_Handler,